@@ -0,0 +1,154 @@
+package streams
+
+import "fmt"
+
+// An Acker acknowledges the outcome of processing an element: either its
+// successful processing, or its failure, after which the element may be
+// redelivered.
+type Acker interface {
+	Ack() error
+	Nack(err error) error
+}
+
+// A Delivery is an element along with its acknowledgment handle.
+type Delivery[T any] struct {
+	Value T
+	Acker Acker
+}
+
+// Ack acknowledges the successful processing of the delivery, if it has an
+// acknowledgment handle.
+func (d Delivery[T]) Ack() error {
+	if d.Acker == nil {
+		return nil
+	}
+
+	return d.Acker.Ack()
+}
+
+// Nack acknowledges the failed processing of the delivery, if it has an
+// acknowledgment handle.
+func (d Delivery[T]) Nack(err error) error {
+	if d.Acker == nil {
+		return nil
+	}
+
+	return d.Acker.Nack(err)
+}
+
+// An AckableStream is a stream of deliveries, elements that must be
+// acknowledged once processed, for at least once processing.
+//
+// An AckableStream is processed through `AckOnSuccess`, or the operators
+// `AckMap` and `AckFilter`, which acknowledge each delivery only after it is
+// handled successfully downstream. Note that this relies on the downstream
+// handling being done by the time the handler returns, which is not the
+// case for operators that buffer elements, such as `Windowed` or `Truncate`,
+// nor for those that resolve their base stream concurrently.
+type AckableStream[T any] interface {
+	Stream[Delivery[T]]
+}
+
+// settle acknowledges the delivery `d` according to the outcome `err` of
+// its handling, and returns the error to report for it.
+func settle[T any](d Delivery[T], err error) error {
+	if err == nil {
+		return d.Ack()
+	}
+
+	nerr := d.Nack(err)
+	if nerr != nil {
+		return fmt.Errorf("%w (nack: %v)", err, nerr)
+	}
+
+	return err
+}
+
+// An AckingStream represents the stream of the values of the deliveries of
+// a given base stream. Each delivery is acknowledged once the handling of
+// its value succeeds, or negatively acknowledged if it fails.
+type AckingStream[T any] struct {
+	base Stream[Delivery[T]]
+}
+
+func AckOnSuccess[T any](s Stream[Delivery[T]]) Stream[T] {
+	return &AckingStream[T]{base: s}
+}
+
+func (s *AckingStream[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(d Delivery[T]) error {
+		return settle(d, handled(h(d.Value)))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+// AckMap is as `Map`, over the values of the deliveries of `s`. A delivery
+// for which `f` fails is negatively acknowledged.
+func AckMap[T, U any](s Stream[Delivery[T]], f func(T) (U, error)) Stream[Delivery[U]] {
+	return Map(s, func(d Delivery[T]) (Delivery[U], error) {
+		u, err := f(d.Value)
+		if err != nil {
+			return Delivery[U]{}, settle(d, handled(err))
+		}
+
+		return Delivery[U]{Value: u, Acker: d.Acker}, nil
+	})
+}
+
+// An AckFilterer is as a Filterer, over the values of the deliveries of a
+// given base stream. The deliveries filtered out are acknowledged, as they
+// are fully processed.
+type AckFilterer[T any] struct {
+	base Stream[Delivery[T]]
+	f    func(v T) bool
+}
+
+func AckFilter[T any](s Stream[Delivery[T]], f func(v T) bool) Stream[Delivery[T]] {
+	return &AckFilterer[T]{base: s, f: f}
+}
+
+func (s *AckFilterer[T]) Resolve(h func(v Delivery[T]) error) (bool, Stream[Delivery[T]], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(d Delivery[T]) error {
+		if !s.f(d.Value) {
+			return d.Ack()
+		}
+
+		return handled(h(d))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+// AckEach handles each delivery of the stream `s` with `h`, acknowledging it
+// if `h` succeeds, and stopping with a negative acknowledgment otherwise.
+func AckEach[T any](s Stream[Delivery[T]], h func(v T) error) error {
+	var t Stream[T] = AckOnSuccess(s)
+	for {
+		eos, nxs, err := t.Resolve(h)
+		t = nxs
+		if eos || err != nil {
+			return driverError(err)
+		}
+	}
+}
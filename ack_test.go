@@ -0,0 +1,110 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// A recordingAcker records the acknowledgments of the deliveries of an
+// ackable stream.
+type recordingAcker struct {
+	acked, nacked []int
+}
+
+type recordedDelivery struct {
+	r *recordingAcker
+	v int
+}
+
+func (d recordedDelivery) Ack() error {
+	d.r.acked = append(d.r.acked, d.v)
+	return nil
+}
+
+func (d recordedDelivery) Nack(err error) error {
+	d.r.nacked = append(d.r.nacked, d.v)
+	return nil
+}
+
+func (r *recordingAcker) deliveries(elems []int) Stream[Delivery[int]] {
+	s := NewFromSlice(elems)
+	return Map(s, func(v int) (Delivery[int], error) {
+		return Delivery[int]{Value: v, Acker: recordedDelivery{r: r, v: v}}, nil
+	})
+}
+
+func TestShouldAckOnSuccess(t *testing.T) {
+	r := &recordingAcker{}
+
+	c, _ := Collect(AckOnSuccess(r.deliveries([]int{3, 1, 4})))
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4}) || !reflect.DeepEqual(r.acked, []int{3, 1, 4}) {
+		t.Error(`Didn't AckOnSuccess`)
+	}
+}
+
+func TestShouldAckOnSuccessNackOnFailure(t *testing.T) {
+	r := &recordingAcker{}
+	s := Map(AckOnSuccess(r.deliveries([]int{3, 1, 4})), failingAt4)
+
+	_, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(r.acked, []int{3, 1}) || !reflect.DeepEqual(r.nacked, []int{4}) {
+		t.Error(`Didn't AckOnSuccess nack on failure`)
+	}
+}
+
+func TestShouldAckOnSuccessAckFilteredOut(t *testing.T) {
+	r := &recordingAcker{}
+	s := Filter(AckOnSuccess(r.deliveries([]int{3, 1, 4})), func(v int) bool { return v != 1 })
+
+	Collect(s)
+
+	if !reflect.DeepEqual(r.acked, []int{3, 1, 4}) {
+		t.Error(`Didn't AckOnSuccess ack filtered out`)
+	}
+}
+
+func TestShouldAckMap(t *testing.T) {
+	r := &recordingAcker{}
+	s := AckMap(r.deliveries([]int{3, 1, 4}), failingAt4)
+
+	c, err := Collect(AckOnSuccess(s))
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't AckMap`)
+	}
+	if !reflect.DeepEqual(r.acked, []int{3, 1}) || !reflect.DeepEqual(r.nacked, []int{4}) {
+		t.Error(`Didn't AckMap acknowledge`)
+	}
+}
+
+func TestShouldAckFilter(t *testing.T) {
+	r := &recordingAcker{}
+	s := AckFilter(r.deliveries([]int{3, 1, 4}), func(v int) bool { return v != 1 })
+	s = AckMap(s, func(v int) (int, error) { return v, nil })
+
+	c, _ := Collect(AckOnSuccess(s))
+
+	if !reflect.DeepEqual(c, []int{3, 4}) || !reflect.DeepEqual(r.acked, []int{3, 1, 4}) {
+		t.Error(`Didn't AckFilter`)
+	}
+}
+
+func TestShouldAckEach(t *testing.T) {
+	r := &recordingAcker{}
+	e := errors.New("error")
+
+	err := AckEach(r.deliveries([]int{3, 1, 4}), func(v int) error {
+		if v == 1 {
+			return fmt.Errorf("wrapped: %w", e)
+		}
+		return nil
+	})
+
+	if !errors.Is(err, e) || !reflect.DeepEqual(r.acked, []int{3}) || !reflect.DeepEqual(r.nacked, []int{1}) {
+		t.Error(`Didn't AckEach`)
+	}
+}
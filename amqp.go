@@ -0,0 +1,133 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// An AMQPAcknowledger acknowledges deliveries by their tag, as the channel
+// of an AMQP client does.
+type AMQPAcknowledger interface {
+	Ack(tag uint64, multiple bool) error
+	Nack(tag uint64, multiple bool, requeue bool) error
+}
+
+// An AMQPDelivery is a message delivered from an AMQP queue.
+type AMQPDelivery struct {
+	Acknowledger AMQPAcknowledger
+	DeliveryTag  uint64
+	Redelivered  bool
+	MessageID    string
+	Exchange     string
+	RoutingKey   string
+	Headers      map[string]any
+	Body         []byte
+}
+
+// AMQPOptions are the options of an AMQPSource.
+type AMQPOptions struct {
+	// Requeue tells whether the deliveries whose handling fails are
+	// requeued, or dropped or dead-lettered by the broker
+	Requeue bool
+	// Dedup, if not nil, records the message ids of the deliveries handled
+	// successfully, so that redeliveries of those, as after a failure to
+	// acknowledge them, are acknowledged without being handled again
+	Dedup DedupStore
+}
+
+// An AMQPSource represents the stream of the deliveries received on a
+// channel from an AMQP queue. Each delivery is acknowledged once its
+// handling downstream succeeds, or negatively acknowledged if it fails. A
+// delivery whose handling stops the stream, with `ErrStop`, is requeued. The
+// stream ends when the channel is closed, or the context is done.
+type AMQPSource struct {
+	ctx        context.Context
+	deliveries <-chan AMQPDelivery
+	opts       AMQPOptions
+	index      int64
+	tag        uint64
+}
+
+func NewAMQPSource(ctx context.Context, deliveries <-chan AMQPDelivery, opts AMQPOptions) *AMQPSource {
+	return &AMQPSource{ctx: ctx, deliveries: deliveries, opts: opts}
+}
+
+// duplicate tells whether the delivery `d` is a redelivery of a message
+// already handled.
+func (s *AMQPSource) duplicate(d AMQPDelivery) (bool, error) {
+	if s.opts.Dedup == nil || !d.Redelivered || d.MessageID == "" {
+		return false, nil
+	}
+
+	return s.opts.Dedup.Seen(d.MessageID)
+}
+
+func (s *AMQPSource) handle(d AMQPDelivery, h func(v AMQPDelivery) error) error {
+	dup, err := s.duplicate(d)
+	if err != nil {
+		return err
+	}
+
+	if !dup {
+		err = handled(h(d))
+		if err != nil {
+			// A delivery stopping the stream is not processed, rather than
+			// failed, and is requeued whatever the options
+			requeue := s.opts.Requeue || errors.Is(err, ErrStop)
+
+			nerr := d.Acknowledger.Nack(d.DeliveryTag, false, requeue)
+			if nerr != nil {
+				return fmt.Errorf("%w (nack: %v)", err, nerr)
+			}
+
+			return err
+		}
+
+		if s.opts.Dedup != nil && d.MessageID != "" {
+			err = s.opts.Dedup.Mark(d.MessageID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return d.Acknowledger.Ack(d.DeliveryTag, false)
+}
+
+func (s *AMQPSource) Resolve(h func(v AMQPDelivery) error) (bool, Stream[AMQPDelivery], error) {
+	if s == nil || s.deliveries == nil {
+		return true, s, nil
+	}
+
+	select {
+	case <-s.ctx.Done():
+		s.deliveries = nil
+
+		return true, s, nil
+	case d, ok := <-s.deliveries:
+		if !ok {
+			s.deliveries = nil
+
+			return true, s, nil
+		}
+
+		s.index++
+		s.tag = d.DeliveryTag
+
+		err := s.handle(d, h)
+		if err != nil {
+			s.deliveries = nil
+
+			return true, s, internal(err)
+		}
+
+		return false, s, nil
+	}
+}
+
+// Position is that of the last delivery received, with its delivery tag as
+// the offset, which increases on the channel.
+func (s *AMQPSource) Position() Position {
+	return Position{Index: s.index, Offset: int64(s.tag)}
+}
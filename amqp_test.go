@@ -0,0 +1,124 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// A fakeAMQP records the acknowledged delivery tags.
+type fakeAMQP struct {
+	acked, nacked []uint64
+	requeued      bool
+}
+
+func (f *fakeAMQP) Ack(tag uint64, multiple bool) error {
+	f.acked = append(f.acked, tag)
+	return nil
+}
+
+func (f *fakeAMQP) Nack(tag uint64, multiple bool, requeue bool) error {
+	f.nacked = append(f.nacked, tag)
+	f.requeued = requeue
+	return nil
+}
+
+func amqpDeliveries(ack AMQPAcknowledger, ds ...AMQPDelivery) <-chan AMQPDelivery {
+	c := make(chan AMQPDelivery, len(ds))
+	for i, d := range ds {
+		d.Acknowledger = ack
+		d.DeliveryTag = uint64(i + 1)
+		c <- d
+	}
+	close(c)
+	return c
+}
+
+func amqpBodies(ds []AMQPDelivery) []string {
+	var bs []string
+	for _, d := range ds {
+		bs = append(bs, string(d.Body))
+	}
+	return bs
+}
+
+func TestShouldAMQPSource(t *testing.T) {
+	ack := &fakeAMQP{}
+	s := NewAMQPSource(context.Background(), amqpDeliveries(ack,
+		AMQPDelivery{Body: []byte("3")},
+		AMQPDelivery{Body: []byte("1")},
+	), AMQPOptions{})
+
+	c, err := Collect[AMQPDelivery](s)
+
+	if err != nil || !reflect.DeepEqual(amqpBodies(c), []string{"3", "1"}) || !reflect.DeepEqual(ack.acked, []uint64{1, 2}) {
+		t.Error(`Didn't AMQPSource`)
+	}
+}
+
+func TestShouldAMQPSourceNackOnError(t *testing.T) {
+	ack := &fakeAMQP{}
+	var s Stream[AMQPDelivery] = NewAMQPSource(context.Background(), amqpDeliveries(ack,
+		AMQPDelivery{Body: []byte("3")},
+		AMQPDelivery{Body: []byte("4")},
+	), AMQPOptions{Requeue: true})
+	s = Map(s, func(d AMQPDelivery) (AMQPDelivery, error) {
+		if string(d.Body) == "4" {
+			return d, errors.New("even")
+		}
+		return d, nil
+	})
+
+	_, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(ack.acked, []uint64{1}) || !reflect.DeepEqual(ack.nacked, []uint64{2}) || !ack.requeued {
+		t.Error(`Didn't AMQPSource nack on error`)
+	}
+}
+
+func TestShouldAMQPSourceRequeueOnStop(t *testing.T) {
+	ack := &fakeAMQP{}
+	s := NewAMQPSource(context.Background(), amqpDeliveries(ack,
+		AMQPDelivery{Body: []byte("3")},
+		AMQPDelivery{Body: []byte("1")},
+	), AMQPOptions{})
+
+	c, err := Collect(Map[AMQPDelivery](s, func(d AMQPDelivery) (AMQPDelivery, error) {
+		if string(d.Body) == "1" {
+			return d, ErrStop
+		}
+		return d, nil
+	}))
+
+	if err != nil || len(c) != 1 || !reflect.DeepEqual(ack.acked, []uint64{1}) || !reflect.DeepEqual(ack.nacked, []uint64{2}) || !ack.requeued {
+		t.Error(`Didn't AMQPSource requeue on stop`)
+	}
+}
+
+func TestShouldAMQPSourceDedupRedelivered(t *testing.T) {
+	ack := &fakeAMQP{}
+	s := NewAMQPSource(context.Background(), amqpDeliveries(ack,
+		AMQPDelivery{MessageID: "a", Body: []byte("3")},
+		AMQPDelivery{MessageID: "a", Body: []byte("3"), Redelivered: true},
+		AMQPDelivery{MessageID: "b", Body: []byte("1"), Redelivered: true},
+	), AMQPOptions{Dedup: NewMemoryDedupStore()})
+
+	c, err := Collect[AMQPDelivery](s)
+
+	if err != nil || !reflect.DeepEqual(amqpBodies(c), []string{"3", "1"}) || !reflect.DeepEqual(ack.acked, []uint64{1, 2, 3}) {
+		t.Error(`Didn't AMQPSource dedup redelivered`)
+	}
+}
+
+func TestShouldAMQPSourceEndOnDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := NewAMQPSource(ctx, make(chan AMQPDelivery), AMQPOptions{})
+
+	c, err := Collect[AMQPDelivery](s)
+
+	if err != nil || len(c) != 0 {
+		t.Error(`Didn't AMQPSource end on done`)
+	}
+}
@@ -0,0 +1,491 @@
+package streams
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// ErrAvro is the error of malformed Avro container files.
+var ErrAvro = errors.New("streams: malformed avro")
+
+// maxAvroLength bounds the lengths read from a container file, as of its
+// blocks, so that a malformed file does not allocate without limit.
+const maxAvroLength = 1 << 30
+
+// A BlockDecompressor decompresses the blocks of an Avro container file
+// written with a given codec.
+type BlockDecompressor interface {
+	Decompress(data []byte) ([]byte, error)
+}
+
+// A BlockDecompressorFunc is a function used as a BlockDecompressor.
+type BlockDecompressorFunc func(data []byte) ([]byte, error)
+
+func (f BlockDecompressorFunc) Decompress(data []byte) ([]byte, error) {
+	return f(data)
+}
+
+// AvroCodecs are the codecs ReadAvro supports. Other codecs, such as snappy,
+// are supported with `ReadAvroCodecs`.
+var AvroCodecs = map[string]BlockDecompressor{
+	"null": BlockDecompressorFunc(func(data []byte) ([]byte, error) {
+		return data, nil
+	}),
+	"deflate": BlockDecompressorFunc(func(data []byte) ([]byte, error) {
+		return io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	}),
+}
+
+const avroSyncSize = 16
+
+// An avroSchema decodes values of a parsed Avro schema, resolving named
+// types.
+type avroSchema struct {
+	names map[string]any
+}
+
+func (a *avroSchema) define(namespace string, t map[string]any) string {
+	name, _ := t["name"].(string)
+	if ns, ok := t["namespace"].(string); ok && !strings.Contains(name, ".") {
+		namespace = ns
+	}
+	if namespace != "" && !strings.Contains(name, ".") {
+		name = namespace + "." + name
+	}
+
+	a.names[name] = t
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		a.names[name[i+1:]] = t
+		namespace = name[:i]
+	}
+
+	return namespace
+}
+
+// collect defines the named types of the schema `t`.
+func (a *avroSchema) collect(namespace string, t any) {
+	switch t := t.(type) {
+	case []any:
+		for _, u := range t {
+			a.collect(namespace, u)
+		}
+	case map[string]any:
+		switch t["type"] {
+		case "record", "error":
+			ns := a.define(namespace, t)
+			fields, _ := t["fields"].([]any)
+			for _, f := range fields {
+				if f, ok := f.(map[string]any); ok {
+					a.collect(ns, f["type"])
+				}
+			}
+		case "enum", "fixed":
+			a.define(namespace, t)
+		case "array":
+			a.collect(namespace, t["items"])
+		case "map":
+			a.collect(namespace, t["values"])
+		}
+	}
+}
+
+func readAvroLong(r *bytes.Reader) (int64, error) {
+	v, err := binary.ReadVarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrAvro, err)
+	}
+
+	return v, nil
+}
+
+func readAvroBytes(r *bytes.Reader) ([]byte, error) {
+	n, err := readAvroLong(r)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || int64(r.Len()) < n {
+		return nil, fmt.Errorf("%w: bad length %d", ErrAvro, n)
+	}
+
+	b := make([]byte, n)
+	r.Read(b)
+
+	return b, nil
+}
+
+// readBlocks reads the blocks of an array or map, calling `item` for each of
+// its items.
+func readAvroBlocks(r *bytes.Reader, item func() error) error {
+	for {
+		n, err := readAvroLong(r)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+		if n < 0 {
+			n = -n
+			// The block byte size, which is not needed
+			_, err = readAvroLong(r)
+			if err != nil {
+				return err
+			}
+		}
+
+		for ; n > 0; n-- {
+			err = item()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (a *avroSchema) read(r *bytes.Reader, t any) (any, error) {
+	switch t := t.(type) {
+	case string:
+		return a.readNamed(r, t)
+	case []any:
+		i, err := readAvroLong(r)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || int64(len(t)) <= i {
+			return nil, fmt.Errorf("%w: bad union index %d", ErrAvro, i)
+		}
+
+		return a.read(r, t[i])
+	case map[string]any:
+		return a.readComplex(r, t)
+	}
+
+	return nil, fmt.Errorf("%w: bad schema %v", ErrAvro, t)
+}
+
+func (a *avroSchema) readNamed(r *bytes.Reader, t string) (any, error) {
+	switch t {
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrAvro, err)
+		}
+		return b != 0, nil
+	case "int":
+		v, err := readAvroLong(r)
+		return int32(v), err
+	case "long":
+		return readAvroLong(r)
+	case "float":
+		var b [4]byte
+		_, err := io.ReadFull(r, b[:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrAvro, err)
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b[:])), nil
+	case "double":
+		var b [8]byte
+		_, err := io.ReadFull(r, b[:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrAvro, err)
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b[:])), nil
+	case "bytes":
+		return readAvroBytes(r)
+	case "string":
+		b, err := readAvroBytes(r)
+		return string(b), err
+	}
+
+	named, ok := a.names[t]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrAvro, t)
+	}
+
+	return a.read(r, named)
+}
+
+func (a *avroSchema) readComplex(r *bytes.Reader, t map[string]any) (any, error) {
+	switch typ := t["type"].(type) {
+	case string:
+		switch typ {
+		case "record", "error":
+			fields, _ := t["fields"].([]any)
+			rec := make(map[string]any, len(fields))
+			for _, f := range fields {
+				f, _ := f.(map[string]any)
+				name, _ := f["name"].(string)
+
+				v, err := a.read(r, f["type"])
+				if err != nil {
+					return nil, err
+				}
+
+				rec[name] = v
+			}
+			return rec, nil
+		case "enum":
+			symbols, _ := t["symbols"].([]any)
+			i, err := readAvroLong(r)
+			if err != nil {
+				return nil, err
+			}
+			if i < 0 || int64(len(symbols)) <= i {
+				return nil, fmt.Errorf("%w: bad enum index %d", ErrAvro, i)
+			}
+			return symbols[i], nil
+		case "array":
+			items := []any{}
+			err := readAvroBlocks(r, func() error {
+				v, err := a.read(r, t["items"])
+				items = append(items, v)
+				return err
+			})
+			return items, err
+		case "map":
+			values := map[string]any{}
+			err := readAvroBlocks(r, func() error {
+				k, err := readAvroBytes(r)
+				if err != nil {
+					return err
+				}
+				v, err := a.read(r, t["values"])
+				values[string(k)] = v
+				return err
+			})
+			return values, err
+		case "fixed":
+			size, _ := t["size"].(float64)
+			if size < 0 || size != float64(int(size)) || float64(r.Len()) < size {
+				return nil, fmt.Errorf("%w: bad fixed size %v", ErrAvro, t["size"])
+			}
+			b := make([]byte, int(size))
+			_, err := io.ReadFull(r, b)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrAvro, err)
+			}
+			return b, nil
+		}
+
+		// A primitive type, possibly with a logical type
+		return a.readNamed(r, typ)
+	case nil:
+		return nil, fmt.Errorf("%w: bad schema %v", ErrAvro, t)
+	default:
+		return a.read(r, typ)
+	}
+}
+
+// An AvroReader represents the stream of the records of an Avro object
+// container file, decoded from their generic representation, where records
+// and maps are `map[string]any`, arrays are `[]any`, enums are strings, and
+// unions are the value of their branch. The header is read on the first
+// resolution, and the records are read block by block.
+type AvroReader[T any] struct {
+	in     *bufio.Reader
+	decode func(map[string]any) (T, error)
+	codecs map[string]BlockDecompressor
+	// The codec, sync marker and schema, once the header is read
+	codec  BlockDecompressor
+	sync   [avroSyncSize]byte
+	schema *avroSchema
+	root   any
+	// The current block, and how many records it still has
+	block *bytes.Reader
+	left  int64
+}
+
+// ReadAvro is the stream of the records of the Avro container file read from
+// `r`, written with one of the AvroCodecs.
+func ReadAvro[T any](r io.Reader, decode func(map[string]any) (T, error)) Stream[T] {
+	return ReadAvroCodecs(r, decode, AvroCodecs)
+}
+
+// ReadAvroCodecs is as `ReadAvro`, with the given codecs by name.
+func ReadAvroCodecs[T any](r io.Reader, decode func(map[string]any) (T, error), codecs map[string]BlockDecompressor) Stream[T] {
+	return &AvroReader[T]{in: bufio.NewReader(r), decode: decode, codecs: codecs}
+}
+
+func (s *AvroReader[T]) readLong() (int64, error) {
+	v, err := binary.ReadVarint(s.in)
+	if err == io.EOF {
+		return 0, io.EOF
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrAvro, err)
+	}
+
+	return v, nil
+}
+
+func (s *AvroReader[T]) readBytes() ([]byte, error) {
+	n, err := s.readLong()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || maxAvroLength < n {
+		return nil, fmt.Errorf("%w: bad length %d", ErrAvro, n)
+	}
+
+	b := make([]byte, n)
+	_, err = io.ReadFull(s.in, b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAvro, err)
+	}
+
+	return b, nil
+}
+
+func (s *AvroReader[T]) readHeader() error {
+	var magic [4]byte
+	_, err := io.ReadFull(s.in, magic[:])
+	if err != nil || string(magic[:]) != "Obj\x01" {
+		return fmt.Errorf("%w: not a container file", ErrAvro)
+	}
+
+	meta := map[string][]byte{}
+	for {
+		n, err := s.readLong()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+		if n < 0 {
+			n = -n
+			_, err = s.readLong()
+			if err != nil {
+				return err
+			}
+		}
+
+		for ; n > 0; n-- {
+			k, err := s.readBytes()
+			if err != nil {
+				return err
+			}
+			v, err := s.readBytes()
+			if err != nil {
+				return err
+			}
+			meta[string(k)] = v
+		}
+	}
+
+	_, err = io.ReadFull(s.in, s.sync[:])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAvro, err)
+	}
+
+	var ok bool
+	codec := "null"
+	if c, ok := meta["avro.codec"]; ok && len(c) != 0 {
+		codec = string(c)
+	}
+	s.codec, ok = s.codecs[codec]
+	if !ok {
+		return fmt.Errorf("%w: unsupported codec %q", ErrAvro, codec)
+	}
+
+	err = json.Unmarshal(meta["avro.schema"], &s.root)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAvro, err)
+	}
+
+	s.schema = &avroSchema{names: map[string]any{}}
+	s.schema.collect("", s.root)
+
+	return nil
+}
+
+// readBlock reads the next block, returning io.EOF if there is none.
+func (s *AvroReader[T]) readBlock() error {
+	n, err := s.readLong()
+	if err != nil {
+		return err
+	}
+
+	data, err := s.readBytes()
+	if err == io.EOF {
+		return fmt.Errorf("%w: truncated block", ErrAvro)
+	}
+	if err != nil {
+		return err
+	}
+
+	var sync [avroSyncSize]byte
+	_, err = io.ReadFull(s.in, sync[:])
+	if err != nil || sync != s.sync {
+		return fmt.Errorf("%w: bad sync marker", ErrAvro)
+	}
+
+	data, err = s.codec.Decompress(data)
+	if err != nil {
+		return err
+	}
+
+	s.block = bytes.NewReader(data)
+	s.left = n
+
+	return nil
+}
+
+func (s *AvroReader[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.in == nil {
+		return true, s, nil
+	}
+
+	if s.schema == nil {
+		err := s.readHeader()
+		if err == io.EOF {
+			err = fmt.Errorf("%w: truncated header", ErrAvro)
+		}
+		if err != nil {
+			return true, s, internal(err)
+		}
+	}
+
+	for s.left <= 0 {
+		err := s.readBlock()
+		if err == io.EOF {
+			return true, s, nil
+		}
+		if err != nil {
+			return true, s, internal(err)
+		}
+	}
+
+	v, err := s.schema.read(s.block, s.root)
+	if err != nil {
+		return true, s, internal(err)
+	}
+	s.left--
+
+	rec, ok := v.(map[string]any)
+	if !ok {
+		return true, s, internal(fmt.Errorf("%w: not a record %v", ErrAvro, v))
+	}
+
+	t, err := s.decode(rec)
+	if err != nil {
+		return true, s, handled(err)
+	}
+
+	err = handled(h(t))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,198 @@
+package streams
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+const avroReadingSchema = `{
+	"type": "record", "name": "Reading", "namespace": "test",
+	"fields": [
+		{"name": "sensor", "type": "string"},
+		{"name": "value", "type": "long"},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "level", "type": ["null", "double"]},
+		{"name": "next", "type": ["null", "Reading"]}
+	]
+}`
+
+type avroWriter struct {
+	bytes.Buffer
+}
+
+func (w *avroWriter) long(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	w.Write(b[:binary.PutVarint(b[:], v)])
+}
+
+func (w *avroWriter) bytes(b []byte) {
+	w.long(int64(len(b)))
+	w.Write(b)
+}
+
+func (w *avroWriter) reading(sensor string, value int64, tags []string, level *float64) {
+	w.bytes([]byte(sensor))
+	w.long(value)
+	if len(tags) != 0 {
+		w.long(int64(len(tags)))
+		for _, t := range tags {
+			w.bytes([]byte(t))
+		}
+	}
+	w.long(0)
+	if level == nil {
+		w.long(0)
+	} else {
+		w.long(1)
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(*level))
+		w.Write(b[:])
+	}
+	w.long(0)
+}
+
+var avroSync = []byte("0123456789abcdef")
+
+// avroFile is an Avro container file of the given blocks, with the given
+// codec.
+func avroFile(codec string, compress func([]byte) []byte, blocks ...func(w *avroWriter) int64) []byte {
+	var f avroWriter
+	f.WriteString("Obj\x01")
+	f.long(2)
+	f.bytes([]byte("avro.schema"))
+	f.bytes([]byte(avroReadingSchema))
+	f.bytes([]byte("avro.codec"))
+	f.bytes([]byte(codec))
+	f.long(0)
+	f.Write(avroSync)
+
+	for _, block := range blocks {
+		var w avroWriter
+		n := block(&w)
+		f.long(n)
+		f.bytes(compress(w.Bytes()))
+		f.Write(avroSync)
+	}
+
+	return f.Bytes()
+}
+
+type avroReading struct {
+	Sensor string
+	Value  int64
+	Tags   []any
+	Level  any
+}
+
+func decodeAvroReading(m map[string]any) (avroReading, error) {
+	return avroReading{m["sensor"].(string), m["value"].(int64), m["tags"].([]any), m["level"]}, nil
+}
+
+func avroReadingBlocks() []func(w *avroWriter) int64 {
+	level := 0.5
+	return []func(w *avroWriter) int64{
+		func(w *avroWriter) int64 {
+			w.reading("a", 3, []string{"x", "y"}, nil)
+			w.reading("b", -1, nil, &level)
+			return 2
+		},
+		func(w *avroWriter) int64 {
+			w.reading("c", 4, []string{"z"}, nil)
+			return 1
+		},
+	}
+}
+
+var avroReadings = []avroReading{
+	{"a", 3, []any{"x", "y"}, nil},
+	{"b", -1, []any{}, 0.5},
+	{"c", 4, []any{"z"}, nil},
+}
+
+func TestShouldReadAvro(t *testing.T) {
+	data := avroFile("null", func(b []byte) []byte { return b }, avroReadingBlocks()...)
+
+	c, err := Collect(ReadAvro(bytes.NewReader(data), decodeAvroReading))
+
+	if err != nil || !reflect.DeepEqual(c, avroReadings) {
+		t.Error(`Didn't ReadAvro`, c, err)
+	}
+}
+
+func TestShouldReadAvroDeflate(t *testing.T) {
+	deflate := func(b []byte) []byte {
+		var buf bytes.Buffer
+		w, _ := flate.NewWriter(&buf, flate.BestSpeed)
+		w.Write(b)
+		w.Close()
+		return buf.Bytes()
+	}
+	data := avroFile("deflate", deflate, avroReadingBlocks()...)
+
+	c, err := Collect(ReadAvro(bytes.NewReader(data), decodeAvroReading))
+
+	if err != nil || !reflect.DeepEqual(c, avroReadings) {
+		t.Error(`Didn't ReadAvro deflate`)
+	}
+}
+
+func TestShouldReadAvroErrorOnUnsupportedCodec(t *testing.T) {
+	data := avroFile("snappy", func(b []byte) []byte { return b }, avroReadingBlocks()...)
+
+	_, err := Collect(ReadAvro(bytes.NewReader(data), decodeAvroReading))
+
+	if !errors.Is(err, ErrAvro) {
+		t.Error(`Didn't ReadAvro error on unsupported codec`)
+	}
+}
+
+func TestShouldReadAvroErrorOnBadSync(t *testing.T) {
+	data := avroFile("null", func(b []byte) []byte { return b }, avroReadingBlocks()...)
+	data[len(data)-1] = 'x'
+
+	c, err := Collect(ReadAvro(bytes.NewReader(data), decodeAvroReading))
+
+	if !errors.Is(err, ErrAvro) || len(c) != 2 {
+		t.Error(`Didn't ReadAvro error on bad sync`)
+	}
+}
+
+func TestShouldReadAvroErrorOnHugeLength(t *testing.T) {
+	var f avroWriter
+	f.WriteString("Obj\x01")
+	f.long(1)
+	f.long(1 << 40)
+
+	_, err := Collect(ReadAvro(bytes.NewReader(f.Bytes()), decodeAvroReading))
+
+	if !errors.Is(err, ErrAvro) {
+		t.Error(`Didn't ReadAvro error on huge length`)
+	}
+}
+
+func TestShouldReadAvroErrorOnBadFixedSize(t *testing.T) {
+	a := &avroSchema{}
+	for _, size := range []any{-1.0, 1.5, 4.0} {
+		_, err := a.read(bytes.NewReader([]byte{1, 2}), map[string]any{"type": "fixed", "name": "f", "size": size})
+
+		if !errors.Is(err, ErrAvro) {
+			t.Error(`Didn't ReadAvro error on bad fixed size`, size)
+		}
+	}
+}
+
+func TestShouldReadAvroOnZeroValue(t *testing.T) {
+	var s *AvroReader[avroReading]
+
+	eos, _, err := s.Resolve(func(v avroReading) error { return nil })
+	zeos, _, zerr := (&AvroReader[avroReading]{}).Resolve(func(v avroReading) error { return nil })
+
+	if !eos || err != nil || !zeos || zerr != nil {
+		t.Error(`Didn't ReadAvro on zero value`)
+	}
+}
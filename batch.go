@@ -0,0 +1,166 @@
+package streams
+
+// A BatchBuilder builds columnar record batches of type B, such as Arrow
+// record batches, out of rows of type T. It adapts a columnar library
+// without the package depending on it.
+type BatchBuilder[T any, B any] interface {
+	Append(v T) error
+	// Len is the number of rows appended since the last batch
+	Len() int
+	// Build is the batch of the rows appended since the last batch
+	Build() (B, error)
+}
+
+// A BatchReader reads the rows of type T of columnar record batches of type
+// B, such as Arrow record batches.
+type BatchReader[B any, T any] interface {
+	NumRows(b B) int
+	Row(b B, i int) (T, error)
+	// Release is called once all the rows of a batch are read
+	Release(b B)
+}
+
+// A Batcher represents the stream of the record batches built out of the
+// elements of a given base stream, each of a given number of rows but the
+// last, which has the remaining rows.
+type Batcher[T any, B any] struct {
+	base    Stream[T]
+	size    int
+	builder BatchBuilder[T, B]
+}
+
+func ToBatches[T any, B any](s Stream[T], size int, builder BatchBuilder[T, B]) Stream[B] {
+	return &Batcher[T, B]{base: s, size: size, builder: builder}
+}
+
+func (s *Batcher[T, B]) build(h func(v B) error) error {
+	b, err := s.builder.Build()
+	if err != nil {
+		return internal(err)
+	}
+
+	return handled(h(b))
+}
+
+func (s *Batcher[T, B]) Resolve(h func(v B) error) (bool, Stream[B], error) {
+	if s == nil {
+		return true, nil, nil
+	}
+
+	if s.base == nil {
+		// The base stream is exhausted, only the last batch remains
+		if s.builder == nil || s.builder.Len() == 0 {
+			return true, s, nil
+		}
+
+		err := s.build(h)
+		if err != nil {
+			return true, s, err
+		}
+
+		return false, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		e := s.builder.Append(v)
+		if e != nil {
+			return internal(e)
+		}
+
+		if s.builder.Len() < s.size {
+			return nil
+		}
+
+		return s.build(h)
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	if eos {
+		s.base = nil
+
+		return s.builder.Len() == 0, s, nil
+	}
+
+	return false, s, nil
+}
+
+// An Unbatcher represents the stream of the rows of the record batches of a
+// given base stream, batch after batch.
+type Unbatcher[B any, T any] struct {
+	base   Stream[B]
+	reader BatchReader[B, T]
+	batch  B
+	n      int
+	next   int
+}
+
+func FromBatches[B any, T any](s Stream[B], reader BatchReader[B, T]) Stream[T] {
+	return &Unbatcher[B, T]{base: s, reader: reader}
+}
+
+func (s *Unbatcher[B, T]) release() {
+	if s.next < s.n {
+		s.reader.Release(s.batch)
+	}
+
+	var zero B
+	s.batch = zero
+	s.n = 0
+	s.next = 0
+}
+
+func (s *Unbatcher[B, T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if s.next == s.n {
+		eos, nxs, err := s.base.Resolve(func(b B) error {
+			n := s.reader.NumRows(b)
+			if n == 0 {
+				s.reader.Release(b)
+				return nil
+			}
+
+			s.batch = b
+			s.n = n
+			s.next = 0
+
+			return nil
+		})
+
+		s.base = nxs
+
+		if err != nil {
+			return true, s, err
+		}
+
+		if s.next == s.n {
+			return eos, s, nil
+		}
+	}
+
+	v, err := s.reader.Row(s.batch, s.next)
+	if err != nil {
+		s.release()
+		return true, s, internal(err)
+	}
+
+	s.next++
+	if s.next == s.n {
+		s.reader.Release(s.batch)
+	}
+
+	err = handled(h(v))
+	if err != nil {
+		s.release()
+		return true, s, err
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,95 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+)
+
+// A pointBatch is a columnar batch of points.
+type pointBatch struct {
+	xs, ys []int
+}
+
+type point struct {
+	x, y int
+}
+
+type pointBuilder struct {
+	b pointBatch
+}
+
+func (p *pointBuilder) Append(v point) error {
+	p.b.xs = append(p.b.xs, v.x)
+	p.b.ys = append(p.b.ys, v.y)
+	return nil
+}
+
+func (p *pointBuilder) Len() int {
+	return len(p.b.xs)
+}
+
+func (p *pointBuilder) Build() (pointBatch, error) {
+	b := p.b
+	p.b = pointBatch{}
+	return b, nil
+}
+
+type pointReader struct {
+	released int
+}
+
+func (p *pointReader) NumRows(b pointBatch) int {
+	return len(b.xs)
+}
+
+func (p *pointReader) Row(b pointBatch, i int) (point, error) {
+	return point{b.xs[i], b.ys[i]}, nil
+}
+
+func (p *pointReader) Release(b pointBatch) {
+	p.released++
+}
+
+func TestShouldToBatches(t *testing.T) {
+	s := NewFromSlice([]point{{3, 1}, {4, 1}, {5, 9}})
+	bs := ToBatches[point, pointBatch](s, 2, &pointBuilder{})
+
+	c, _ := Collect(bs)
+
+	want := []pointBatch{{xs: []int{3, 4}, ys: []int{1, 1}}, {xs: []int{5}, ys: []int{9}}}
+	if !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't ToBatches`)
+	}
+}
+
+func TestShouldToBatchesOnEmpty(t *testing.T) {
+	bs := ToBatches[point, pointBatch](NewFromSlice([]point{}), 2, &pointBuilder{})
+
+	c, _ := Collect(bs)
+
+	if len(c) != 0 {
+		t.Error(`Didn't ToBatches on empty`)
+	}
+}
+
+func TestShouldFromBatches(t *testing.T) {
+	r := &pointReader{}
+	bs := NewFromSlice([]pointBatch{{xs: []int{3, 4}, ys: []int{1, 1}}, {}, {xs: []int{5}, ys: []int{9}}})
+	s := FromBatches[pointBatch, point](bs, r)
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []point{{3, 1}, {4, 1}, {5, 9}}) || r.released != 3 {
+		t.Error(`Didn't FromBatches`)
+	}
+}
+
+func TestShouldFromBatchesOnZeroValue(t *testing.T) {
+	s := &Unbatcher[pointBatch, point]{}
+
+	eos, _, _ := s.Resolve(func(v point) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't FromBatches on zero value`)
+	}
+}
@@ -0,0 +1,176 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrCircuitOpen is the error of the elements rejected by an open circuit.
+var ErrCircuitOpen = errors.New("streams: circuit open")
+
+// A BreakerPolicy determines what `CircuitBreak` does with elements while
+// the circuit is open.
+type BreakerPolicy int
+
+const (
+	// BreakerSkip drops the elements.
+	BreakerSkip BreakerPolicy = iota
+	// BreakerFail fails with `ErrCircuitOpen` on the first element.
+	BreakerFail
+)
+
+// BreakerOptions are the options of a CircuitBreaker.
+type BreakerOptions struct {
+	// Failures is the number of consecutive failures that trips the
+	// circuit, or 0 to not trip on consecutive failures
+	Failures int
+	// FailureRate is the fraction of failures among the last Window
+	// handlings that trips the circuit, or 0 to not trip on a rate
+	FailureRate float64
+	Window      int
+	// CoolDown is how long the circuit stays open before the next element
+	// probes it
+	CoolDown time.Duration
+	Policy   BreakerPolicy
+	// OnReject, if not nil, is called with each element not handled
+	// successfully, and the error of its handling or `ErrCircuitOpen`
+	OnReject func(v any, err error)
+	// Clock is the clock for the cool-down, or nil for the system clock
+	Clock Clock
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// A CircuitBreaker represents the stream of the elements of a given base
+// stream, whose handling failures are counted rather than ending the stream.
+// Once too many handlings fail, the circuit trips open, and elements are
+// skipped or fail fast for a cool-down period, after which the next element
+// probes the handling: the circuit closes if it succeeds, and opens again
+// otherwise. Stopping with `ErrStop` is not a failure.
+type CircuitBreaker[T any] struct {
+	base     Stream[T]
+	opts     BreakerOptions
+	state    breakerState
+	openedAt time.Time
+	cause    error
+	// The consecutive failures, and the outcomes of the last handlings
+	consecutive int
+	outcomes    []bool
+	next        int
+	failed      int
+}
+
+func CircuitBreak[T any](s Stream[T], opts BreakerOptions) Stream[T] {
+	if opts.Clock == nil {
+		opts.Clock = SystemClock
+	}
+
+	return &CircuitBreaker[T]{base: s, opts: opts}
+}
+
+func (s *CircuitBreaker[T]) reject(v T, err error) {
+	if s.opts.OnReject != nil {
+		s.opts.OnReject(v, err)
+	}
+}
+
+func (s *CircuitBreaker[T]) reset() {
+	s.consecutive = 0
+	s.outcomes = s.outcomes[:0]
+	s.next = 0
+	s.failed = 0
+}
+
+func (s *CircuitBreaker[T]) trip(err error) {
+	s.state = breakerOpen
+	s.openedAt = s.opts.Clock.Now()
+	s.cause = err
+	s.reset()
+}
+
+// record records the outcome of a handling while closed, and tells whether
+// the circuit trips.
+func (s *CircuitBreaker[T]) record(failed bool) bool {
+	if failed {
+		s.consecutive++
+	} else {
+		s.consecutive = 0
+	}
+
+	if s.opts.Window > 0 {
+		if len(s.outcomes) < s.opts.Window {
+			s.outcomes = append(s.outcomes, failed)
+		} else {
+			if s.outcomes[s.next] {
+				s.failed--
+			}
+			s.outcomes[s.next] = failed
+			s.next = (s.next + 1) % s.opts.Window
+		}
+		if failed {
+			s.failed++
+		}
+	}
+
+	if s.opts.Failures > 0 && s.opts.Failures <= s.consecutive {
+		return true
+	}
+
+	return s.opts.FailureRate > 0 && len(s.outcomes) == s.opts.Window &&
+		s.opts.FailureRate <= float64(s.failed)/float64(s.opts.Window)
+}
+
+func (s *CircuitBreaker[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if s.state == breakerOpen && s.opts.CoolDown <= s.opts.Clock.Now().Sub(s.openedAt) {
+		s.state = breakerHalfOpen
+	}
+
+	if s.state == breakerOpen && s.opts.Policy == BreakerFail {
+		return true, s, internal(fmt.Errorf("%w: %v", ErrCircuitOpen, s.cause))
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		if s.state == breakerOpen {
+			s.reject(v, ErrCircuitOpen)
+			return nil
+		}
+
+		err := handled(h(v))
+		if errors.Is(err, ErrStop) {
+			return err
+		}
+		if err != nil {
+			s.reject(v, err)
+		}
+
+		switch {
+		case s.state == breakerHalfOpen && err != nil:
+			s.trip(err)
+		case s.state == breakerHalfOpen:
+			s.state = breakerClosed
+		case s.record(err != nil):
+			s.trip(err)
+		}
+
+		return nil
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
@@ -0,0 +1,116 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// flakySink fails on the elements in `failing`, and keeps the others.
+type flakySink struct {
+	failing map[int]bool
+	kept    []int
+}
+
+func (f *flakySink) handle(v int) error {
+	if f.failing[v] {
+		return errors.New("flaky")
+	}
+	f.kept = append(f.kept, v)
+	return nil
+}
+
+func resolveAll(s Stream[int], h func(v int) error) error {
+	for {
+		eos, nxs, err := s.Resolve(h)
+		s = nxs
+		if eos {
+			return err
+		}
+	}
+}
+
+func TestShouldCircuitBreakSkip(t *testing.T) {
+	clock := newManualClock(time.Unix(0, 0))
+	sink := &flakySink{failing: map[int]bool{2: true, 3: true}}
+	var rejected []int
+	s := CircuitBreak(NewFromSlice([]int{1, 2, 3, 4, 5}), BreakerOptions{
+		Failures: 2,
+		CoolDown: time.Second,
+		OnReject: func(v any, err error) { rejected = append(rejected, v.(int)) },
+		Clock:    clock,
+	})
+
+	var err error
+	var eos bool
+	for i := 0; !eos; i++ {
+		if i == 4 {
+			clock.Advance(time.Second)
+		}
+		eos, s, err = s.Resolve(sink.handle)
+	}
+
+	if err != nil || !reflect.DeepEqual(sink.kept, []int{1, 5}) || !reflect.DeepEqual(rejected, []int{2, 3, 4}) {
+		t.Error(`Didn't CircuitBreak skip`, sink.kept, rejected)
+	}
+}
+
+func TestShouldCircuitBreakFail(t *testing.T) {
+	sink := &flakySink{failing: map[int]bool{1: true}}
+	s := CircuitBreak(NewFromSlice([]int{1, 2}), BreakerOptions{
+		Failures: 1,
+		CoolDown: time.Hour,
+		Policy:   BreakerFail,
+	})
+
+	err := resolveAll(s, sink.handle)
+
+	if !errors.Is(err, ErrCircuitOpen) || len(sink.kept) != 0 {
+		t.Error(`Didn't CircuitBreak fail`)
+	}
+}
+
+func TestShouldCircuitBreakOnRate(t *testing.T) {
+	sink := &flakySink{failing: map[int]bool{1: true, 3: true}}
+	s := CircuitBreak(NewFromSlice([]int{1, 2, 3, 4, 5, 6}), BreakerOptions{
+		FailureRate: 0.5,
+		Window:      4,
+		CoolDown:    time.Hour,
+	})
+
+	err := resolveAll(s, sink.handle)
+
+	if err != nil || !reflect.DeepEqual(sink.kept, []int{2, 4}) {
+		t.Error(`Didn't CircuitBreak on rate`)
+	}
+}
+
+func TestShouldCircuitBreakReopenOnFailedProbe(t *testing.T) {
+	clock := newManualClock(time.Unix(0, 0))
+	sink := &flakySink{failing: map[int]bool{1: true, 2: true}}
+	s := CircuitBreak(NewFromSlice([]int{1, 2, 3}), BreakerOptions{
+		Failures: 1,
+		CoolDown: time.Second,
+		Clock:    clock,
+	})
+
+	_, s, _ = s.Resolve(sink.handle)
+	clock.Advance(time.Second)
+	_, s, _ = s.Resolve(sink.handle)
+	err := resolveAll(s, sink.handle)
+
+	if err != nil || len(sink.kept) != 0 {
+		t.Error(`Didn't CircuitBreak reopen on failed probe`)
+	}
+}
+
+func TestShouldCircuitBreakOnZeroValue(t *testing.T) {
+	s := &CircuitBreaker[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't CircuitBreak on zero value`)
+	}
+}
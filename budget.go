@@ -0,0 +1,140 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// ErrMemoryBudget is the error reported by a buffering operator that would
+// exceed its memory budget.
+var ErrMemoryBudget = errors.New("streams: memory budget exceeded")
+
+// A MemoryBudget bounds the memory, in bytes, that the buffering operators
+// sharing it may hold at any time. Operators that cannot spill what exceeds
+// the budget fail with `ErrMemoryBudget`, rather than growing without bound.
+//
+// The memory used by an operator is accounted as the size of the elements it
+// holds, without what they refer to, such as the bytes of strings.
+type MemoryBudget struct {
+	mu          sync.Mutex
+	limit, used int64
+}
+
+func NewMemoryBudget(limit int64) *MemoryBudget {
+	return &MemoryBudget{limit: limit}
+}
+
+// Used is the memory currently accounted in the budget.
+func (b *MemoryBudget) Used() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.used
+}
+
+func (b *MemoryBudget) reserve(op string, n int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limit < b.used+n {
+		return internal(fmt.Errorf("%w: %s needs %d bytes, with %d of %d bytes in use", ErrMemoryBudget, op, n, b.used, b.limit))
+	}
+
+	b.used += n
+
+	return nil
+}
+
+func (b *MemoryBudget) release(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.used -= n
+}
+
+// sizeOf is the memory accounted for `n` elements of type T.
+func sizeOf[T any](n int) int64 {
+	var v T
+
+	return int64(unsafe.Sizeof(v)) * int64(n)
+}
+
+// A budgetShare is the share of an operator in a memory budget, if any.
+type budgetShare struct {
+	budget   *MemoryBudget
+	reserved int64
+}
+
+func (a *budgetShare) reserve(op string, n int64) error {
+	if a.budget == nil {
+		return nil
+	}
+
+	err := a.budget.reserve(op, n)
+	if err != nil {
+		return err
+	}
+
+	a.reserved += n
+
+	return nil
+}
+
+func (a *budgetShare) release(n int64) {
+	if a.budget == nil {
+		return
+	}
+
+	a.budget.release(n)
+	a.reserved -= n
+}
+
+// done releases all of the share, and leaves the budget.
+func (a *budgetShare) done() {
+	a.release(a.reserved)
+	a.budget = nil
+}
+
+// A budgetUser is a buffering operator, which accounts its memory usage in
+// a memory budget.
+type budgetUser interface {
+	useBudget(b *MemoryBudget)
+}
+
+// WithMemoryBudget is the stream `s`, with the memory used by the buffering
+// operators in it accounted in the memory budget `b`.
+func WithMemoryBudget[T any](s Stream[T], b *MemoryBudget) Stream[T] {
+	walk(s, func(s any) {
+		if u, ok := s.(budgetUser); ok {
+			u.useBudget(b)
+		}
+	})
+
+	return s
+}
+
+func (s *Truncater[T]) useBudget(b *MemoryBudget)       { s.acct.budget = b }
+func (s *Windower[T]) useBudget(b *MemoryBudget)        { s.acct.budget = b }
+func (s *RunGrouper[T, K]) useBudget(b *MemoryBudget)   { s.acct.budget = b }
+func (s *AlignedWindower[T]) useBudget(b *MemoryBudget) { s.acct.budget = b }
+func (s *DiskBuffer[T]) useBudget(b *MemoryBudget)      { s.acct.budget = b }
+func (s *ViewWindower[T]) useBudget(b *MemoryBudget)    { s.acct.budget = b }
+func (s *Reorderer[T]) useBudget(b *MemoryBudget)       { s.acct.budget = b }
+func (s *Delayer[T]) useBudget(b *MemoryBudget)         { s.acct.budget = b }
+func (s *Lagger[T]) useBudget(b *MemoryBudget)          { s.acct.budget = b }
+func (s *Chunker[T]) useBudget(b *MemoryBudget)         { s.acct.budget = b }
+func (s *Buffer[T]) useBudget(b *MemoryBudget)          { s.acct.budget = b }
+func (s *Prefetcher[T]) useBudget(b *MemoryBudget)      { s.acct.budget = b }
+
+func (s *DemuxBranch[T, K]) useBudget(b *MemoryBudget) {
+	if s.d == nil {
+		return
+	}
+
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	s.d.acct.budget = b
+}
@@ -0,0 +1,116 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestShouldWithMemoryBudget(t *testing.T) {
+	b := NewMemoryBudget(sizeOf[int](8))
+	ss := Windowed(NewFromSlice([]int{3, 1, 4, 1}), 2, 2)
+	s := Map(ss, func(v Stream[int]) ([]int, error) { return Collect(v) })
+
+	c, err := Collect(WithMemoryBudget(s, b))
+
+	if err != nil || !reflect.DeepEqual(c, [][]int{{3, 1}, {1, 4}, {4, 1}}) {
+		t.Error(`Didn't WithMemoryBudget`)
+	}
+	if b.Used() != 0 {
+		t.Error(`Didn't WithMemoryBudget release`)
+	}
+}
+
+func TestShouldWithMemoryBudgetFailWindowed(t *testing.T) {
+	b := NewMemoryBudget(sizeOf[int](3))
+	ss := Windowed(NewFromSlice([]int{3, 1, 4, 1}), 2, 2)
+	s := Map(ss, func(v Stream[int]) ([]int, error) { return Collect(v) })
+
+	_, err := Collect(WithMemoryBudget(s, b))
+
+	if !errors.Is(err, ErrMemoryBudget) {
+		t.Error(`Didn't WithMemoryBudget fail windowed`)
+	}
+}
+
+func TestShouldWithMemoryBudgetFailTruncate(t *testing.T) {
+	b := NewMemoryBudget(sizeOf[int](1))
+	s := Filter(Truncate(NewFromSlice([]int{3, 1, 4, 1}), 2), func(v int) bool { return true })
+
+	_, err := Collect(WithMemoryBudget(s, b))
+
+	if !errors.Is(err, ErrMemoryBudget) {
+		t.Error(`Didn't WithMemoryBudget fail truncate`)
+	}
+}
+
+func TestShouldWithMemoryBudgetFailLongRun(t *testing.T) {
+	b := NewMemoryBudget(sizeOf[int](2))
+	s := GroupRuns(NewFromSlice([]int{3, 3, 1, 1, 4, 4, 4}))
+
+	c, err := Collect(WithMemoryBudget(s, b))
+
+	if !errors.Is(err, ErrMemoryBudget) || !reflect.DeepEqual(c, [][]int{{3, 3}, {1, 1}}) {
+		t.Error(`Didn't WithMemoryBudget fail long run`)
+	}
+	if b.Used() != 0 {
+		t.Error(`Didn't WithMemoryBudget release on failure`)
+	}
+}
+
+func TestShouldWithMemoryBudgetSpill(t *testing.T) {
+	b := NewMemoryBudget(0)
+	elems := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	s := BufferedToDisk(NewFromSlice(elems), 100, Codec[int](JSONCodec[int]{}), t.TempDir())
+
+	c, err := Collect(WithMemoryBudget(s, b))
+
+	if err != nil || !reflect.DeepEqual(c, elems) {
+		t.Error(`Didn't WithMemoryBudget spill`)
+	}
+}
+
+func TestShouldWithMemoryBudgetBuffered(t *testing.T) {
+	b := NewMemoryBudget(sizeOf[int](4))
+	s := Map(Buffered(NewFromSlice([]int{3, 1, 4, 1}), 4), func(v int) (int, error) { return v, nil })
+
+	c, err := Collect(WithMemoryBudget(s, b))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4, 1}) || b.Used() != 0 {
+		t.Error(`Didn't WithMemoryBudget Buffered`)
+	}
+}
+
+func TestShouldWithMemoryBudgetFailBuffered(t *testing.T) {
+	b := NewMemoryBudget(sizeOf[int](3))
+
+	_, err := Collect(WithMemoryBudget(Buffered(NewFromSlice([]int{3, 1, 4, 1}), 4), b))
+
+	if !errors.Is(err, ErrMemoryBudget) || b.Used() != 0 {
+		t.Error(`Didn't WithMemoryBudget fail Buffered`)
+	}
+}
+
+func TestShouldWithMemoryBudgetFailPrefetch(t *testing.T) {
+	b := NewMemoryBudget(sizeOf[int](1))
+	s, _ := Prefetch(NewFromSlice([]int{3, 1, 4, 1}), 4)
+
+	_, err := Collect(WithMemoryBudget(s, b))
+
+	if !errors.Is(err, ErrMemoryBudget) || b.Used() != 0 {
+		t.Error(`Didn't WithMemoryBudget fail Prefetch`)
+	}
+}
+
+func TestShouldWithMemoryBudgetPartition(t *testing.T) {
+	b := NewMemoryBudget(sizeOf[int](2))
+	odd, even := Partition(NewFromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6}), func(v int) bool { return v%2 != 0 })
+	WithMemoryBudget(odd, b)
+
+	_, eerr := Collect(even)
+	c, err := Collect(odd)
+
+	if !errors.Is(eerr, ErrMemoryBudget) || !errors.Is(err, ErrMemoryBudget) || !reflect.DeepEqual(c, []int{3, 1}) || b.Used() != 0 {
+		t.Error(`Didn't WithMemoryBudget Partition`)
+	}
+}
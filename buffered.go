@@ -0,0 +1,139 @@
+package streams
+
+// A Buffer represents the stream of the elements of a given base stream,
+// which is resolved ahead, concurrently, into a buffer of a given size, so
+// that a slow consumer overlaps with a slow base stream. What the buffer
+// does with further elements once full depends on its overflow policy. See
+// `Prefetch` for a blocking buffer that also measures where the pipeline
+// waits.
+type Buffer[T any] struct {
+	base   Stream[T]
+	n      int
+	policy BufferPolicy
+	in     *inbox[T]
+	// Closed once the base stream has ended, along with err
+	ended chan struct{}
+	err   error
+	// The base stream as left by its resolution, once ended
+	rest    Stream[T]
+	stopped bool
+	acct    budgetShare
+}
+
+// Buffered is the stream `s`, resolved ahead into a buffer of `n` elements,
+// waiting for room in the buffer once full.
+func Buffered[T any](s Stream[T], n int) Stream[T] {
+	return BufferedPolicy(s, n, BufferBlock)
+}
+
+// BufferedPolicy is as `Buffered`, handling the elements that find the
+// buffer full according to `policy`.
+func BufferedPolicy[T any](s Stream[T], n int, policy BufferPolicy) Stream[T] {
+	return &Buffer[T]{base: s, n: n, policy: policy}
+}
+
+// Dropped is the number of elements dropped so far because the buffer was
+// full.
+func (s *Buffer[T]) Dropped() int64 {
+	if s.in == nil {
+		return 0
+	}
+
+	return s.in.Dropped()
+}
+
+func (s *Buffer[T]) produce(base Stream[T]) {
+	for {
+		eos, nxs, err := base.Resolve(func(v T) error {
+			select {
+			case <-s.in.done:
+				return ErrStop
+			case <-s.in.overflow:
+				return ErrStop
+			default:
+			}
+
+			s.in.push(v)
+
+			return nil
+		})
+		base = nxs
+		if eos || err != nil {
+			s.rest, s.err = base, driverError(err)
+			close(s.ended)
+
+			return
+		}
+	}
+}
+
+func (s *Buffer[T]) stop() {
+	if s.in != nil {
+		s.in.close()
+	}
+	s.stopped = true
+	s.acct.done()
+}
+
+// Close stops the resolution of the base stream, once the resolution in
+// progress, if any, is done, ending the stream.
+func (s *Buffer[T]) Close() error {
+	s.stop()
+	if s.in != nil {
+		<-s.ended
+		s.base = s.rest
+	}
+
+	return nil
+}
+
+func (s *Buffer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil || s.stopped {
+		return true, s, nil
+	}
+
+	if s.in == nil {
+		in := newInbox[T](s.n, s.policy)
+		err := s.acct.reserve("Buffered", sizeOf[T](cap(in.c)))
+		if err != nil {
+			s.stopped = true
+
+			return true, s, err
+		}
+
+		s.in = in
+		s.ended = make(chan struct{})
+		go s.produce(s.base)
+	}
+
+	var v T
+	select {
+	case v = <-s.in.c:
+	case <-s.in.overflow:
+		s.stop()
+
+		return true, s, internal(ErrBufferOverflow)
+	case <-s.ended:
+		// The elements resolved before the end are all in the buffer
+		select {
+		case v = <-s.in.c:
+		case <-s.in.overflow:
+			s.stop()
+
+			return true, s, internal(ErrBufferOverflow)
+		default:
+			s.stop()
+
+			return true, s, s.err
+		}
+	}
+
+	err := handled(h(v))
+	if err != nil {
+		s.stop()
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,107 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// slowlyConsumed resolves the elements of a buffered stream of ten elements,
+// whose base stream has ended before the first element is consumed.
+func slowlyConsumed(policy BufferPolicy) ([]int, *Buffer[int], error) {
+	b := BufferedPolicy(NewFromSlice([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}), 2, policy).(*Buffer[int])
+
+	var c []int
+	_, s, err := b.Resolve(func(v int) error {
+		<-b.ended
+		c = append(c, v)
+
+		return nil
+	})
+	if err != nil {
+		return c, b, err
+	}
+
+	rest, err := Collect(s)
+
+	return append(c, rest...), b, err
+}
+
+func TestShouldBuffered(t *testing.T) {
+	c, err := Collect(Buffered(NewFromSlice([]int{3, 1, 4, 1, 5}), 2))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4, 1, 5}) {
+		t.Error(`Didn't Buffered`)
+	}
+}
+
+func TestShouldBufferedBlock(t *testing.T) {
+	s := Buffered(NewFromSlice([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}), 2)
+
+	c, err := Collect(Map(s, func(v int) (int, error) {
+		time.Sleep(time.Millisecond)
+
+		return v, nil
+	}))
+
+	if err != nil || !reflect.DeepEqual(c, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Error(`Didn't Buffered block`)
+	}
+}
+
+func TestShouldBufferedDropNewest(t *testing.T) {
+	c, b, err := slowlyConsumed(BufferDropNewest)
+	dropped := b.Dropped()
+
+	if err != nil || len(c) < 2 || 3 < len(c) || !sort.IntsAreSorted(c) || c[0] != 0 || int(dropped) != 10-len(c) {
+		t.Error(`Didn't Buffered drop newest`, c, dropped)
+	}
+}
+
+func TestShouldBufferedDropOldest(t *testing.T) {
+	c, b, err := slowlyConsumed(BufferDropOldest)
+	dropped := b.Dropped()
+
+	if err != nil || len(c) < 2 || !reflect.DeepEqual(c[len(c)-2:], []int{8, 9}) || int(dropped) != 10-len(c) {
+		t.Error(`Didn't Buffered drop oldest`, c, dropped)
+	}
+}
+
+func TestShouldBufferedError(t *testing.T) {
+	_, _, err := slowlyConsumed(BufferError)
+
+	if !errors.Is(err, ErrBufferOverflow) {
+		t.Error(`Didn't Buffered error`)
+	}
+}
+
+func TestShouldBufferedErrorOnError(t *testing.T) {
+	s := Map(NewFromSlice([]int{3, 1, 4}), func(v int) (int, error) {
+		if v == 4 {
+			return 0, errors.New("failed")
+		}
+
+		return v, nil
+	})
+
+	c, err := Collect(Buffered(s, 2))
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't Buffered error on error`)
+	}
+}
+
+func TestShouldCloseStreamBuffered(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n1\n5\n")
+	s := Buffered(NewStreamOfLinesOpen(r), 1)
+
+	c, s, _ := CollectN(s, 1)
+	err := CloseStream(s)
+	rest, _ := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3"}) || len(rest) != 0 || r.closes != 1 {
+		t.Error(`Didn't CloseStream Buffered`)
+	}
+}
@@ -0,0 +1,319 @@
+package streams
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrChangeFormat is the error of malformed change events.
+var ErrChangeFormat = errors.New("streams: malformed change event")
+
+// A ChangeOp is the operation of a change event.
+type ChangeOp int
+
+const (
+	ChangeInsert ChangeOp = iota + 1
+	ChangeUpdate
+	ChangeDelete
+	// ChangeRead is the read of a row in an initial snapshot.
+	ChangeRead
+	ChangeTruncate
+)
+
+// A ChangeEvent is the change of a row of a database table, as captured
+// from its replication feed. Before is nil for inserts, and After is nil
+// for deletes. Position is the position of the change in the feed, such as
+// a log sequence number, from which to resume after it.
+type ChangeEvent struct {
+	Schema    string
+	Table     string
+	Op        ChangeOp
+	Before    map[string]any
+	After     map[string]any
+	Position  string
+	Timestamp time.Time
+}
+
+// A ChangeFeed is a feed of change events, such as a replication slot.
+type ChangeFeed interface {
+	// Next waits for the next change event, failing with `io.EOF` at the
+	// end of the feed
+	Next(ctx context.Context) (ChangeEvent, error)
+}
+
+// A ChangeDecoder decodes a message of a change feed into the change events
+// it carries, which may be none.
+type ChangeDecoder func(data []byte) ([]ChangeEvent, error)
+
+type lineChangeFeed struct {
+	in     *bufio.Scanner
+	decode ChangeDecoder
+	events []ChangeEvent
+}
+
+// ReadChanges is the ChangeFeed of the messages read from `r`, one per line,
+// decoded by `decode`, such as `DecodeDebezium` or `DecodeWal2JSON`.
+func ReadChanges(r io.Reader, decode ChangeDecoder) ChangeFeed {
+	in := bufio.NewScanner(r)
+	in.Buffer(nil, 16*1024*1024)
+
+	return &lineChangeFeed{in: in, decode: decode}
+}
+
+func (f *lineChangeFeed) Next(ctx context.Context) (ChangeEvent, error) {
+	for len(f.events) == 0 {
+		if err := ctx.Err(); err != nil {
+			return ChangeEvent{}, err
+		}
+
+		if !f.in.Scan() {
+			err := f.in.Err()
+			if err == nil {
+				err = io.EOF
+			}
+
+			return ChangeEvent{}, err
+		}
+
+		line := bytes.TrimSpace(f.in.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		events, err := f.decode(line)
+		if err != nil {
+			return ChangeEvent{}, err
+		}
+		f.events = events
+	}
+
+	e := f.events[0]
+	f.events = f.events[1:]
+
+	return e, nil
+}
+
+var debeziumOps = map[string]ChangeOp{
+	"c": ChangeInsert,
+	"u": ChangeUpdate,
+	"d": ChangeDelete,
+	"r": ChangeRead,
+	"t": ChangeTruncate,
+}
+
+// DecodeDebezium decodes a Debezium change event in JSON, with or without
+// its schema envelope. Tombstones, which are null, carry no change event.
+func DecodeDebezium(data []byte) ([]ChangeEvent, error) {
+	var msg struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+	err := json.Unmarshal(data, &msg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrChangeFormat, err)
+	}
+	if len(msg.Payload) != 0 {
+		data = msg.Payload
+	}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, nil
+	}
+
+	var p struct {
+		Op     string         `json:"op"`
+		Before map[string]any `json:"before"`
+		After  map[string]any `json:"after"`
+		TsMs   int64          `json:"ts_ms"`
+		Source struct {
+			Schema string          `json:"schema"`
+			DB     string          `json:"db"`
+			Table  string          `json:"table"`
+			LSN    json.RawMessage `json:"lsn"`
+			File   string          `json:"file"`
+			Pos    json.RawMessage `json:"pos"`
+		} `json:"source"`
+	}
+	err = json.Unmarshal(data, &p)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrChangeFormat, err)
+	}
+
+	op, ok := debeziumOps[p.Op]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown op %q", ErrChangeFormat, p.Op)
+	}
+
+	e := ChangeEvent{
+		Schema: p.Source.Schema,
+		Table:  p.Source.Table,
+		Op:     op,
+		Before: p.Before,
+		After:  p.After,
+	}
+	if e.Schema == "" {
+		e.Schema = p.Source.DB
+	}
+	if p.TsMs != 0 {
+		e.Timestamp = time.UnixMilli(p.TsMs)
+	}
+
+	switch {
+	case len(p.Source.LSN) != 0 && string(p.Source.LSN) != "null":
+		e.Position = string(bytes.Trim(p.Source.LSN, `"`))
+	case p.Source.File != "":
+		e.Position = p.Source.File + ":" + string(p.Source.Pos)
+	}
+
+	return []ChangeEvent{e}, nil
+}
+
+var wal2jsonOps = map[string]ChangeOp{
+	"I": ChangeInsert,
+	"U": ChangeUpdate,
+	"D": ChangeDelete,
+	"T": ChangeTruncate,
+}
+
+type wal2jsonColumn struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+func wal2jsonRow(cs []wal2jsonColumn) map[string]any {
+	if cs == nil {
+		return nil
+	}
+
+	row := make(map[string]any, len(cs))
+	for _, c := range cs {
+		row[c.Name] = c.Value
+	}
+
+	return row
+}
+
+// DecodeWal2JSON decodes a change of the wal2json format version 2, of the
+// PostgreSQL logical decoding plugin. Transaction boundaries and messages
+// carry no change event. The Before row of updates and deletes only has
+// the replica identity columns.
+func DecodeWal2JSON(data []byte) ([]ChangeEvent, error) {
+	var c struct {
+		Action    string           `json:"action"`
+		Schema    string           `json:"schema"`
+		Table     string           `json:"table"`
+		Columns   []wal2jsonColumn `json:"columns"`
+		Identity  []wal2jsonColumn `json:"identity"`
+		LSN       string           `json:"lsn"`
+		Timestamp string           `json:"timestamp"`
+	}
+	err := json.Unmarshal(data, &c)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrChangeFormat, err)
+	}
+
+	switch c.Action {
+	case "B", "C", "M":
+		return nil, nil
+	}
+
+	op, ok := wal2jsonOps[c.Action]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown action %q", ErrChangeFormat, c.Action)
+	}
+
+	e := ChangeEvent{
+		Schema:   c.Schema,
+		Table:    c.Table,
+		Op:       op,
+		Before:   wal2jsonRow(c.Identity),
+		Position: c.LSN,
+	}
+	if op != ChangeDelete {
+		e.After = wal2jsonRow(c.Columns)
+	}
+	if c.Timestamp != "" {
+		e.Timestamp, _ = time.Parse("2006-01-02 15:04:05.999999-07", c.Timestamp)
+	}
+
+	return []ChangeEvent{e}, nil
+}
+
+// CDCOptions are the options of a CDCSource.
+type CDCOptions struct {
+	// Checkpoint, if not nil, is called with the position of the last
+	// change event handled successfully, after every CheckpointEvery events
+	// and at the end of stream, so that the feed can resume after it
+	Checkpoint      func(position string) error
+	CheckpointEvery int
+}
+
+// A CDCSource represents the stream of the change events of a ChangeFeed.
+// The stream ends at the end of the feed, or when the context is done.
+type CDCSource struct {
+	ctx     context.Context
+	feed    ChangeFeed
+	opts    CDCOptions
+	pos     string
+	pending int
+}
+
+func NewCDCSource(ctx context.Context, feed ChangeFeed, opts CDCOptions) *CDCSource {
+	return &CDCSource{ctx: ctx, feed: feed, opts: opts}
+}
+
+func (s *CDCSource) checkpoint() error {
+	if s.opts.Checkpoint == nil || s.pending == 0 {
+		return nil
+	}
+
+	s.pending = 0
+
+	return s.opts.Checkpoint(s.pos)
+}
+
+// end ends the stream, checkpointing the last position.
+func (s *CDCSource) end(err error) (bool, Stream[ChangeEvent], error) {
+	s.feed = nil
+
+	cerr := s.checkpoint()
+	if err == nil {
+		err = cerr
+	}
+
+	return true, s, internal(err)
+}
+
+func (s *CDCSource) Resolve(h func(v ChangeEvent) error) (bool, Stream[ChangeEvent], error) {
+	if s == nil || s.feed == nil {
+		return true, s, nil
+	}
+
+	e, err := s.feed.Next(s.ctx)
+	if err == io.EOF || s.ctx.Err() != nil {
+		return s.end(nil)
+	}
+	if err != nil {
+		return s.end(err)
+	}
+
+	err = handled(h(e))
+	if err != nil {
+		return s.end(err)
+	}
+
+	s.pos = e.Position
+	s.pending++
+	if 0 < s.opts.CheckpointEvery && s.opts.CheckpointEvery <= s.pending {
+		err = s.checkpoint()
+		if err != nil {
+			return s.end(err)
+		}
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,90 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShouldDecodeDebezium(t *testing.T) {
+	in := `{"schema": {}, "payload": {"op": "u", "ts_ms": 1000, "before": {"id": 1, "v": "a"}, "after": {"id": 1, "v": "b"}, "source": {"schema": "public", "table": "t", "lsn": 33}}}
+null
+{"op": "c", "after": {"id": 2}, "source": {"db": "shop", "table": "orders", "file": "binlog.3", "pos": 154}}`
+
+	c, err := Collect[ChangeEvent](NewCDCSource(context.Background(), ReadChanges(strings.NewReader(in), DecodeDebezium), CDCOptions{}))
+
+	want := []ChangeEvent{
+		{
+			Schema: "public", Table: "t", Op: ChangeUpdate,
+			Before:    map[string]any{"id": 1.0, "v": "a"},
+			After:     map[string]any{"id": 1.0, "v": "b"},
+			Position:  "33",
+			Timestamp: time.UnixMilli(1000),
+		},
+		{Schema: "shop", Table: "orders", Op: ChangeInsert, After: map[string]any{"id": 2.0}, Position: "binlog.3:154"},
+	}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't DecodeDebezium`, c, err)
+	}
+}
+
+func TestShouldDecodeWal2JSON(t *testing.T) {
+	in := `{"action":"B"}
+{"action":"I","schema":"public","table":"t","columns":[{"name":"id","type":"integer","value":1}],"lsn":"0/16B3748"}
+{"action":"D","schema":"public","table":"t","identity":[{"name":"id","type":"integer","value":1}],"lsn":"0/16B3750"}
+{"action":"C"}`
+
+	c, err := Collect[ChangeEvent](NewCDCSource(context.Background(), ReadChanges(strings.NewReader(in), DecodeWal2JSON), CDCOptions{}))
+
+	want := []ChangeEvent{
+		{Schema: "public", Table: "t", Op: ChangeInsert, After: map[string]any{"id": 1.0}, Position: "0/16B3748"},
+		{Schema: "public", Table: "t", Op: ChangeDelete, Before: map[string]any{"id": 1.0}, Position: "0/16B3750"},
+	}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't DecodeWal2JSON`)
+	}
+}
+
+func TestShouldCDCSourceCheckpoint(t *testing.T) {
+	var in strings.Builder
+	for _, lsn := range []string{"1", "2", "3"} {
+		in.WriteString(`{"action":"I","table":"t","lsn":"` + lsn + `"}` + "\n")
+	}
+
+	var positions []string
+	s := NewCDCSource(context.Background(), ReadChanges(strings.NewReader(in.String()), DecodeWal2JSON), CDCOptions{
+		Checkpoint: func(pos string) error {
+			positions = append(positions, pos)
+			return nil
+		},
+		CheckpointEvery: 2,
+	})
+
+	c, err := Collect[ChangeEvent](s)
+
+	if err != nil || len(c) != 3 || !reflect.DeepEqual(positions, []string{"2", "3"}) {
+		t.Error(`Didn't CDCSource checkpoint`)
+	}
+}
+
+func TestShouldCDCSourceErrorOnMalformed(t *testing.T) {
+	in := `{"action":"I","table":"t","lsn":"1"}
+{"action":"X"}`
+
+	var positions []string
+	s := NewCDCSource(context.Background(), ReadChanges(strings.NewReader(in), DecodeWal2JSON), CDCOptions{
+		Checkpoint: func(pos string) error {
+			positions = append(positions, pos)
+			return nil
+		},
+	})
+
+	c, err := Collect[ChangeEvent](s)
+
+	if !errors.Is(err, ErrChangeFormat) || len(c) != 1 || !reflect.DeepEqual(positions, []string{"1"}) {
+		t.Error(`Didn't CDCSource error on malformed`)
+	}
+}
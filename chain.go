@@ -0,0 +1,145 @@
+package streams
+
+// An upstreamer is an operator, which tells the streams it resolves.
+type upstreamer interface {
+	upstreams() []any
+}
+
+// walk applies `f` to the stream `s`, and then to each of the streams
+// upstream of it, transitively.
+func walk(s any, f func(s any)) {
+	if s == nil {
+		return
+	}
+
+	f(s)
+
+	if u, ok := s.(upstreamer); ok {
+		for _, b := range u.upstreams() {
+			walk(b, f)
+		}
+	}
+}
+
+// upstream is the stream `s` as upstream of an operator, where a nil stream
+// is none.
+func upstream[T any](s Stream[T]) any {
+	if s == nil {
+		return nil
+	}
+
+	return s
+}
+
+func (s *Mapper[T, U]) upstreams() []any          { return []any{upstream(s.base)} }
+func (s *IndexedMapper[T, U]) upstreams() []any   { return []any{upstream(s.base)} }
+func (s *FlatMapper[T, U]) upstreams() []any      { return []any{upstream(s.base), upstream(s.current)} }
+func (s *Tapper[T]) upstreams() []any             { return []any{upstream(s.base)} }
+func (s *Pairer[T]) upstreams() []any             { return []any{upstream(s.base)} }
+func (s *Dropper[T]) upstreams() []any            { return []any{upstream(s.base)} }
+func (s *Taker[T]) upstreams() []any              { return []any{upstream(s.base)} }
+func (s *Stepper[T]) upstreams() []any            { return []any{upstream(s.base)} }
+func (s *Scanner[T, R]) upstreams() []any         { return []any{upstream(s.base)} }
+func (s *WhileTaker[T]) upstreams() []any         { return []any{upstream(s.base)} }
+func (s *WhileDropper[T]) upstreams() []any       { return []any{upstream(s.base)} }
+func (s *Truncater[T]) upstreams() []any          { return []any{upstream(s.base)} }
+func (s *Differ[T]) upstreams() []any             { return []any{upstream(s.base)} }
+func (s *Filterer[T]) upstreams() []any           { return []any{upstream(s.base)} }
+func (s *IndexedFilterer[T]) upstreams() []any    { return []any{upstream(s.base)} }
+func (s *Windower[T]) upstreams() []any           { return []any{upstream(s.base)} }
+func (s *RunGrouper[T, K]) upstreams() []any      { return []any{upstream(s.base)} }
+func (s *Chunker[T]) upstreams() []any            { return []any{upstream(s.base)} }
+func (s *Validator[T]) upstreams() []any          { return []any{upstream(s.base)} }
+func (s *Resulter[T]) upstreams() []any           { return []any{upstream(s.base)} }
+func (s *MonotonicEnforcer[T]) upstreams() []any  { return []any{upstream(s.base)} }
+func (s *AlignedWindower[T]) upstreams() []any    { return []any{upstream(s.base)} }
+func (s *AckingStream[T]) upstreams() []any       { return []any{upstream(s.base)} }
+func (s *AckFilterer[T]) upstreams() []any        { return []any{upstream(s.base)} }
+func (s *Committer[T]) upstreams() []any          { return []any{upstream(s.base)} }
+func (s *Checkpointer[T]) upstreams() []any       { return []any{upstream(s.base)} }
+func (s *Prefetcher[T]) upstreams() []any         { return []any{upstream(s.base)} }
+func (s *RateMeter[T]) upstreams() []any          { return []any{upstream(s.base)} }
+func (s *Timer[T]) upstreams() []any              { return []any{upstream(s.base)} }
+func (s *CircuitBreaker[T]) upstreams() []any     { return []any{upstream(s.base)} }
+func (s *ConcurrencyLimiter[T]) upstreams() []any { return []any{upstream(s.base)} }
+func (s *DeadLetterer[T]) upstreams() []any       { return []any{upstream(s.base)} }
+func (s *PersistentDeduper[T]) upstreams() []any  { return []any{upstream(s.base)} }
+func (s *Batcher[T, B]) upstreams() []any         { return []any{upstream(s.base)} }
+func (s *Unbatcher[B, T]) upstreams() []any       { return []any{upstream(s.base)} }
+func (s *ViewWindower[T]) upstreams() []any       { return []any{upstream(s.base)} }
+func (s *Reorderer[T]) upstreams() []any          { return []any{upstream(s.base)} }
+func (s *Delayer[T]) upstreams() []any            { return []any{upstream(s.base)} }
+func (s *Lagger[T]) upstreams() []any             { return []any{upstream(s.base)} }
+func (s *ConsecutiveDeduper[T]) upstreams() []any { return []any{upstream(s.base)} }
+func (s *TimeLimiter[T]) upstreams() []any        { return []any{upstream(s.base)} }
+func (s *Buffer[T]) upstreams() []any             { return []any{upstream(s.base)} }
+func (s *Throttler[T]) upstreams() []any          { return []any{upstream(s.base)} }
+func (s *Retrier[T]) upstreams() []any            { return []any{upstream(s.base)} }
+func (s *ErrorResumer[T]) upstreams() []any       { return []any{upstream(s.base)} }
+func (s *ErrorSkipper[T]) upstreams() []any       { return []any{upstream(s.base)} }
+func (s *ContextResolver[T]) upstreams() []any    { return []any{upstream(s.base)} }
+
+func (s *Fused[T]) upstreams() []any {
+	if s.src == nil {
+		return nil
+	}
+
+	return []any{s.src.root()}
+}
+
+func (m *SortedMerger[T]) upstreams() []any {
+	us := make([]any, 0, len(m.ss)+len(m.heap.heads))
+	for _, s := range m.ss {
+		us = append(us, upstream(s))
+	}
+	for _, h := range m.heap.heads {
+		us = append(us, upstream(h.s))
+	}
+
+	return us
+}
+
+func (m *Merger[T]) upstreams() []any {
+	us := make([]any, 0, len(m.ss))
+	for _, s := range m.ss {
+		us = append(us, upstream(s))
+	}
+
+	return us
+}
+
+func (s *DemuxBranch[T, K]) upstreams() []any {
+	if s.d == nil {
+		return nil
+	}
+
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	return []any{upstream(s.d.base)}
+}
+
+func (s *Muxer[T, K]) upstreams() []any {
+	us := make([]any, 0, len(s.ss))
+	for _, u := range s.ss {
+		us = append(us, upstream(u))
+	}
+
+	return us
+}
+
+func (s *Concatenator[T]) upstreams() []any {
+	us := make([]any, 0, len(s.ss))
+	for _, u := range s.ss {
+		us = append(us, upstream(u))
+	}
+
+	return us
+}
+
+func (s *DiskBuffer[T]) upstreams() []any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return []any{upstream(s.base), upstream(s.rest)}
+}
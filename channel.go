@@ -0,0 +1,73 @@
+package streams
+
+import "context"
+
+// A ChannelReceiver represents the stream of the values received from a
+// given channel, until it is closed.
+type ChannelReceiver[T any] struct {
+	ch <-chan T
+}
+
+func FromChannel[T any](ch <-chan T) Stream[T] {
+	return &ChannelReceiver[T]{ch: ch}
+}
+
+func (s *ChannelReceiver[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.ch == nil {
+		return true, s, nil
+	}
+
+	v, ok := <-s.ch
+	if !ok {
+		s.ch = nil
+
+		return true, s, nil
+	}
+
+	err := handled(h(v))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// ToChannel sends each element of the stream `s` on `ch`, which is left
+// open.
+func ToChannel[T any](s Stream[T], ch chan<- T) error {
+	_, err := SendAll[T](s, SinkFunc[T](func(v T) error {
+		ch <- v
+
+		return nil
+	}))
+
+	return err
+}
+
+// Emit resolves the stream `s` in a new goroutine, sending its elements on
+// the returned channel, which has a buffer of `n` elements, and is closed at
+// the end of stream. The outcome of the resolution is then sent on the
+// returned error channel: nil, the error resolving `s`, or the error of
+// `ctx`, if done before the end of stream.
+func Emit[T any](ctx context.Context, s Stream[T], n int) (<-chan T, <-chan error) {
+	out := make(chan T, n)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+
+		_, err := SendAll[T](s, SinkFunc[T](func(v T) error {
+			select {
+			case out <- v:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}))
+
+		close(out)
+		errc <- err
+	}()
+
+	return out, errc
+}
@@ -0,0 +1,72 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestShouldStreamFromChannel(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 3
+	ch <- 1
+	ch <- 4
+	close(ch)
+
+	c, err := Collect(FromChannel(ch))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't stream from channel`)
+	}
+}
+
+func TestShouldSendToChannel(t *testing.T) {
+	ch := make(chan int, 3)
+
+	err := ToChannel(NewFromSlice([]int{3, 1, 4}), ch)
+	close(ch)
+
+	c, _ := Collect(FromChannel(ch))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't send to channel`)
+	}
+}
+
+func TestShouldEmit(t *testing.T) {
+	out, errc := Emit(context.Background(), NewFromSlice([]int{3, 1, 4}), 0)
+
+	var c []int
+	for v := range out {
+		c = append(c, v)
+	}
+
+	if err := <-errc; err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't Emit`)
+	}
+}
+
+func TestShouldEmitUntilCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out, errc := Emit(ctx, Repeat(1), 0)
+
+	<-out
+	cancel()
+	for range out {
+	}
+
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Error(`Didn't Emit until canceled`, err)
+	}
+}
+
+func TestShouldEmitError(t *testing.T) {
+	out, errc := Emit(context.Background(), Map(NewFromSlice([]int{3, 1, 4}), failingAt4), 1)
+
+	c, _ := Collect(FromChannel(out))
+
+	if err := <-errc; err == nil || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't Emit error`)
+	}
+}
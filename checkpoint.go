@@ -0,0 +1,402 @@
+package streams
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+)
+
+// A Checkpointable stream can take a snapshot of its state, and restore it
+// from a snapshot later, e.g. after a restart. The state of an operator
+// includes that of its base stream, so that a whole pipeline is snapshot by
+// its last stream, as long as every stream in it is Checkpointable.
+//
+// A snapshot is to be taken, and restored, between resolutions. States are
+// encoded with `encoding/gob`, so the elements of the streams holding them
+// in their state must be encodable as such.
+type Checkpointable interface {
+	Snapshot() ([]byte, error)
+	Restore(data []byte) error
+}
+
+// ErrNotCheckpointable is the error reported on taking a snapshot of, or
+// restoring, a stream that is not Checkpointable.
+var ErrNotCheckpointable = errors.New("streams: not checkpointable")
+
+// A stageSnapshot is the snapshot of an operator: its own state along with
+// the snapshot of its base stream.
+type stageSnapshot struct {
+	State []byte
+	Base  []byte
+}
+
+func gobEncode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func gobDecode(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// snapshotStage takes a snapshot of the operator with the given `state` and
+// `base` stream. A nil base stream is empty, and has no state.
+func snapshotStage(state any, base any) ([]byte, error) {
+	var snap stageSnapshot
+	var err error
+
+	if state != nil {
+		snap.State, err = gobEncode(state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if base != nil {
+		c, ok := base.(Checkpointable)
+		if !ok {
+			return nil, ErrNotCheckpointable
+		}
+
+		snap.Base, err = c.Snapshot()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return gobEncode(snap)
+}
+
+// restoreStage restores the operator with the given `state` and `base`
+// stream from the snapshot `data`.
+func restoreStage(data []byte, state any, base any) error {
+	var snap stageSnapshot
+	err := gobDecode(data, &snap)
+	if err != nil {
+		return err
+	}
+
+	if state != nil && snap.State != nil {
+		err = gobDecode(snap.State, state)
+		if err != nil {
+			return err
+		}
+	}
+
+	if snap.Base != nil {
+		c, ok := base.(Checkpointable)
+		if !ok {
+			return ErrNotCheckpointable
+		}
+
+		return c.Restore(snap.Base)
+	}
+
+	return nil
+}
+
+func (s *Mapper[T, U]) Snapshot() ([]byte, error) {
+	return snapshotStage(s.i, upstream(s.base))
+}
+
+func (s *Mapper[T, U]) Restore(data []byte) error {
+	return restoreStage(data, &s.i, s.base)
+}
+
+func (s *IndexedMapper[T, U]) Snapshot() ([]byte, error) {
+	return snapshotStage(s.i, upstream(s.base))
+}
+
+func (s *IndexedMapper[T, U]) Restore(data []byte) error {
+	return restoreStage(data, &s.i, s.base)
+}
+
+func (s *Filterer[T]) Snapshot() ([]byte, error) {
+	return snapshotStage(nil, upstream(s.base))
+}
+
+func (s *Filterer[T]) Restore(data []byte) error {
+	return restoreStage(data, nil, s.base)
+}
+
+func (s *IndexedFilterer[T]) Snapshot() ([]byte, error) {
+	return snapshotStage(s.i, upstream(s.base))
+}
+
+func (s *IndexedFilterer[T]) Restore(data []byte) error {
+	return restoreStage(data, &s.i, s.base)
+}
+
+func (s *Dropper[T]) Snapshot() ([]byte, error) {
+	return snapshotStage(s.c, upstream(s.base))
+}
+
+func (s *Dropper[T]) Restore(data []byte) error {
+	return restoreStage(data, &s.c, s.base)
+}
+
+// bufferState is the state of the operators holding a circular buffer.
+type bufferState[T any] struct {
+	Hold []T
+	I    int
+}
+
+func (s *Truncater[T]) Snapshot() ([]byte, error) {
+	return snapshotStage(bufferState[T]{Hold: s.hold, I: s.i}, upstream(s.base))
+}
+
+func (s *Truncater[T]) Restore(data []byte) error {
+	var state bufferState[T]
+	err := restoreStage(data, &state, s.base)
+	if err != nil {
+		return err
+	}
+
+	s.hold = append(make([]T, 0, s.n), state.Hold...)
+	s.i = state.I
+
+	return nil
+}
+
+func (s *Differ[T]) Snapshot() ([]byte, error) {
+	return snapshotStage(bufferState[T]{Hold: s.hold, I: s.i}, upstream(s.base))
+}
+
+func (s *Differ[T]) Restore(data []byte) error {
+	var state bufferState[T]
+	err := restoreStage(data, &state, s.base)
+	if err != nil {
+		return err
+	}
+
+	s.hold = state.Hold
+	s.i = state.I
+
+	return nil
+}
+
+func (s *Windower[T]) Snapshot() ([]byte, error) {
+	// Only the elements of the last, incomplete, window are needed
+	hold := s.hold
+	if s.n <= s.i {
+		hold = hold[s.i-s.n+1 : s.i]
+	}
+
+	return snapshotStage(hold, upstream(s.base))
+}
+
+func (s *Windower[T]) Restore(data []byte) error {
+	var hold []T
+	err := restoreStage(data, &hold, s.base)
+	if err != nil {
+		return err
+	}
+
+	s.hold = append(make([]T, 0, s.f*s.n), hold...)
+	s.i = len(hold)
+
+	return nil
+}
+
+// runState is the state of a RunGrouper.
+type runState[T any, K comparable] struct {
+	Run []T
+	K   K
+}
+
+func (s *RunGrouper[T, K]) Snapshot() ([]byte, error) {
+	return snapshotStage(runState[T, K]{Run: s.run, K: s.k}, upstream(s.base))
+}
+
+func (s *RunGrouper[T, K]) Restore(data []byte) error {
+	var state runState[T, K]
+	err := restoreStage(data, &state, s.base)
+	if err != nil {
+		return err
+	}
+
+	s.run = state.Run
+	s.k = state.K
+
+	return nil
+}
+
+func (s *StreamFromSlice[T]) Snapshot() ([]byte, error) {
+	return snapshotStage(s.next, nil)
+}
+
+func (s *StreamFromSlice[T]) Restore(data []byte) error {
+	return restoreStage(data, &s.next, nil)
+}
+
+func (s *StreamOfFileInts) Snapshot() ([]byte, error) {
+	return snapshotStage(s.offset, nil)
+}
+
+func (s *StreamOfFileInts) Restore(data []byte) error {
+	return restoreStage(data, &s.offset, nil)
+}
+
+func (s *StreamOfFileIntsOpen) Snapshot() ([]byte, error) {
+	if s.closed {
+		return snapshotStage(s.offset, nil)
+	}
+
+	in, ok := s.in.(io.Seeker)
+	if !ok {
+		return nil, ErrNotCheckpointable
+	}
+
+	offset, err := in.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshotStage(offset, nil)
+}
+
+func (s *StreamOfFileIntsOpen) Restore(data []byte) error {
+	in, ok := s.in.(io.Seeker)
+	if !ok {
+		return ErrNotCheckpointable
+	}
+
+	var offset int64
+	err := restoreStage(data, &offset, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = in.Seek(offset, io.SeekStart)
+
+	return err
+}
+
+// A CheckpointStore persists the last checkpoint of a pipeline.
+type CheckpointStore interface {
+	Save(data []byte) error
+	// Load returns a nil checkpoint if there is none
+	Load() ([]byte, error)
+}
+
+// A FileCheckpointStore persists checkpoints in the file at Path. Each
+// checkpoint is first written to a temporary file, which then replaces the
+// previous checkpoint.
+type FileCheckpointStore struct {
+	Path string
+}
+
+func (f FileCheckpointStore) Save(data []byte) error {
+	tmp := f.Path + ".tmp"
+
+	err := os.WriteFile(tmp, data, 0o644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, f.Path)
+}
+
+func (f FileCheckpointStore) Load() ([]byte, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	return data, err
+}
+
+// A Checkpointer represents the stream of the elements of a given pipeline,
+// which it checkpoints to a given store. The pipeline is restored from the
+// last checkpoint, if any, on the first resolution, and snapshots are saved
+// after a given number of elements and at the end of stream.
+type Checkpointer[T any] struct {
+	base     Stream[T]
+	store    CheckpointStore
+	every    int
+	n        int
+	restored bool
+}
+
+func Checkpoint[T any](s Stream[T], store CheckpointStore, every int) Stream[T] {
+	return &Checkpointer[T]{base: s, store: store, every: every}
+}
+
+func (s *Checkpointer[T]) save() error {
+	c, ok := s.base.(Checkpointable)
+	if !ok {
+		return ErrNotCheckpointable
+	}
+
+	data, err := c.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	s.n = 0
+
+	return s.store.Save(data)
+}
+
+func (s *Checkpointer[T]) restore() error {
+	data, err := s.store.Load()
+	if err != nil || data == nil {
+		return err
+	}
+
+	c, ok := s.base.(Checkpointable)
+	if !ok {
+		return ErrNotCheckpointable
+	}
+
+	return c.Restore(data)
+}
+
+func (s *Checkpointer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if !s.restored {
+		s.restored = true
+
+		err := s.restore()
+		if err != nil {
+			s.base = nil
+
+			return true, s, internal(err)
+		}
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		s.n++
+
+		return handled(h(v))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		s.base = nil
+
+		return true, s, err
+	}
+
+	if eos || (0 < s.every && s.every <= s.n) {
+		err = s.save()
+		if err != nil {
+			s.base = nil
+
+			return true, s, internal(err)
+		}
+	}
+
+	return eos, s, nil
+}
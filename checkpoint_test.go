@@ -0,0 +1,127 @@
+package streams
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type memoryCheckpointStore struct {
+	data  []byte
+	saves int
+}
+
+func (m *memoryCheckpointStore) Save(data []byte) error {
+	m.data = data
+	m.saves++
+	return nil
+}
+
+func (m *memoryCheckpointStore) Load() ([]byte, error) {
+	return m.data, nil
+}
+
+func diffPipeline() Stream[int] {
+	s := NewFromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6})
+	s = Drop(s, 1)
+	s = Diff(s)
+	return Map(s, func(v int) (int, error) { return v * 10, nil })
+}
+
+func TestShouldCheckpoint(t *testing.T) {
+	store := &memoryCheckpointStore{}
+
+	c, _, _ := CollectN(Checkpoint(diffPipeline(), store, 3), 3)
+	r, _ := Collect(Checkpoint(diffPipeline(), store, 3))
+
+	if !reflect.DeepEqual(c, []int{30, -30, 40}) || !reflect.DeepEqual(r, []int{40, -70, 40}) {
+		t.Error(`Didn't Checkpoint`)
+	}
+}
+
+func TestShouldCheckpointAtEndOfStream(t *testing.T) {
+	store := &memoryCheckpointStore{}
+
+	Collect(Checkpoint(diffPipeline(), store, 100))
+	r, _ := Collect(Checkpoint(diffPipeline(), store, 100))
+
+	if store.saves != 2 || len(r) != 0 {
+		t.Error(`Didn't Checkpoint at end of stream`)
+	}
+}
+
+func TestShouldCheckpointWindowed(t *testing.T) {
+	s := Windowed(NewFromSlice([]int{3, 1, 4, 1, 5}), 3, 1)
+	windows := func(s Stream[Stream[int]]) Stream[[]int] {
+		return Map(s, func(w Stream[int]) ([]int, error) { return Collect(w) })
+	}
+	store := &memoryCheckpointStore{}
+
+	c, _, _ := CollectN(Checkpoint(windows(s), store, 1), 1)
+	s = Windowed(NewFromSlice([]int{3, 1, 4, 1, 5}), 3, 1)
+	r, _ := Collect(Checkpoint(windows(s), store, 1))
+
+	if !reflect.DeepEqual(c, [][]int{{3, 1, 4}}) || !reflect.DeepEqual(r, [][]int{{1, 4, 1}, {4, 1, 5}}) {
+		t.Error(`Didn't Checkpoint windowed`)
+	}
+}
+
+func TestShouldCheckpointFileInts(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "input")
+	os.WriteFile(filename, []byte("3\n1\n4\n1\n"), 0o644)
+	store := FileCheckpointStore{Path: filepath.Join(t.TempDir(), "checkpoint")}
+
+	c, _, _ := CollectN(Checkpoint(NewStreamOfFileInts(filename), store, 1), 2)
+	r, err := Collect(Checkpoint(NewStreamOfFileInts(filename), store, 1))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1}) || !reflect.DeepEqual(r, []int{4, 1}) {
+		t.Error(`Didn't Checkpoint file ints`)
+	}
+}
+
+func TestShouldCheckpointFileIntsAtEndOfFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "input")
+	os.WriteFile(filename, []byte("3\n1\n4\n1\n"), 0o644)
+	store := FileCheckpointStore{Path: filepath.Join(t.TempDir(), "checkpoint")}
+
+	c, err := Collect(Checkpoint(NewStreamOfFileInts(filename), store, 1))
+	r, rerr := Collect(Checkpoint(NewStreamOfFileInts(filename), store, 1))
+
+	if err != nil || rerr != nil || !reflect.DeepEqual(c, []int{3, 1, 4, 1}) || len(r) != 0 {
+		t.Error(`Didn't Checkpoint file ints at end of file`, err, rerr, r)
+	}
+}
+
+func TestShouldCheckpointErrorOnNotCheckpointable(t *testing.T) {
+	s := FlatMap(Windowed(NewFromSlice([]int{3, 1, 4}), 2, 1), func(v int) (int, error) { return v, nil })
+
+	_, err := Collect(Checkpoint(s, &memoryCheckpointStore{}, 1))
+
+	if !errors.Is(err, ErrNotCheckpointable) {
+		t.Error(`Didn't Checkpoint error on not checkpointable`)
+	}
+}
+
+func TestShouldCheckpointErrorOnNotSeekableInts(t *testing.T) {
+	s := NewStreamOfIntsOpen(io.NopCloser(strings.NewReader("3\n1\n4\n")))
+
+	_, err := Collect(Checkpoint(s, &memoryCheckpointStore{}, 1))
+
+	if !errors.Is(err, ErrNotCheckpointable) {
+		t.Error(`Didn't Checkpoint error on not seekable ints`)
+	}
+}
+
+func TestShouldFileCheckpointStoreLoadNone(t *testing.T) {
+	store := FileCheckpointStore{Path: filepath.Join(t.TempDir(), "checkpoint")}
+
+	data, err := store.Load()
+
+	if data != nil || err != nil {
+		t.Error(`Didn't FileCheckpointStore load none`)
+	}
+}
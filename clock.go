@@ -0,0 +1,25 @@
+package streams
+
+import "time"
+
+// A Clock tells the current time, and waits for time to pass. Time based
+// operators take a Clock so that they can be driven by other than the wall
+// clock, e.g. in tests or when replaying recorded streams.
+type Clock interface {
+	Now() time.Time
+	// After is as `time.After`
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock is the Clock of the wall clock time.
+var SystemClock Clock = systemClock{}
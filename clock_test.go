@@ -0,0 +1,70 @@
+package streams
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// A manualClock is a Clock whose time only passes when advanced.
+type manualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []manualTimer
+}
+
+type manualTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newManualClock(now time.Time) *manualClock {
+	return &manualClock{now: now}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := manualTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+	} else {
+		c.timers = append(c.timers, t)
+	}
+
+	return t.c
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	var pending []manualTimer
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			t.c <- c.now
+		}
+	}
+	c.timers = pending
+}
+
+func TestShouldSystemClockNow(t *testing.T) {
+	before := time.Now()
+	now := SystemClock.Now()
+
+	if now.Before(before) {
+		t.Error(`Didn't SystemClock now`)
+	}
+}
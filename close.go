@@ -0,0 +1,24 @@
+package streams
+
+import "io"
+
+// CloseStream closes the stream `s`, along with the streams upstream of it,
+// releasing the resources they hold, such as open files, even though they
+// have not ended, as when a consumer stops early. The streams that hold a
+// resource are those that implement `io.Closer`, and they resolve to the
+// empty stream once closed. Closing a stream that has ended, or that is
+// already closed, does nothing. The first error of closing is returned,
+// once all the streams are closed.
+func CloseStream[T any](s Stream[T]) error {
+	var err error
+	walk(s, func(s any) {
+		if c, ok := s.(io.Closer); ok {
+			cerr := c.Close()
+			if err == nil {
+				err = cerr
+			}
+		}
+	})
+
+	return err
+}
@@ -0,0 +1,182 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A trackedReader is a reader that counts its closes, failing with `err`.
+type trackedReader struct {
+	io.Reader
+	closes int
+	err    error
+}
+
+func (r *trackedReader) Close() error {
+	r.closes++
+
+	return r.err
+}
+
+func newTrackedReader(text string) *trackedReader {
+	return &trackedReader{Reader: strings.NewReader(text)}
+}
+
+func TestShouldCloseStreamAfterTake(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n")
+	s := Take(NewStreamOfLinesOpen(r), 2)
+
+	c, _ := Collect(s)
+	err := CloseStream(s)
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "1"}) || r.closes != 1 {
+		t.Error(`Didn't CloseStream after Take`)
+	}
+}
+
+func TestShouldCloseStreamAfterTakeWhile(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n")
+	s := TakeWhile(NewStreamOfIntsOpen(r), func(v int) bool { return v != 1 })
+
+	c, _ := Collect(s)
+	err := CloseStream(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3}) || r.closes != 1 {
+		t.Error(`Didn't CloseStream after TakeWhile`)
+	}
+}
+
+func TestShouldCloseStreamOnce(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n")
+	s := Map(NewStreamOfLinesOpen(r), func(v string) (string, error) { return v, nil })
+
+	c, s, _ := CollectN(s, 1)
+	CloseStream(s)
+	CloseStream(s)
+	rest, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3"}) || len(rest) != 0 || r.closes != 1 {
+		t.Error(`Didn't CloseStream once`)
+	}
+}
+
+func TestShouldCloseStreamEnded(t *testing.T) {
+	r := newTrackedReader("3\n")
+	s := NewStreamOfLinesOpen(r)
+
+	Collect(s)
+	err := CloseStream(s)
+
+	if err != nil || r.closes != 1 {
+		t.Error(`Didn't CloseStream ended`)
+	}
+}
+
+func TestShouldCloseStreamError(t *testing.T) {
+	failure := errors.New("close")
+	r := newTrackedReader("3\n1\n")
+	r.err = failure
+	u := newTrackedReader("4\n")
+	s := Concat(NewStreamOfLinesOpen(r), NewStreamOfLinesOpen(u))
+
+	CollectN(s, 1)
+	err := CloseStream(s)
+
+	if !errors.Is(err, failure) || r.closes != 1 || u.closes != 1 {
+		t.Error(`Didn't CloseStream error`)
+	}
+}
+
+func TestShouldCloseStreamPrefetch(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n1\n5\n")
+	s, _ := Prefetch(NewStreamOfLinesOpen(r), 1)
+
+	c, s, _ := CollectN(s, 1)
+	err := CloseStream(s)
+	rest, _ := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3"}) || len(rest) != 0 || r.closes != 1 {
+		t.Error(`Didn't CloseStream Prefetch`)
+	}
+}
+
+func TestShouldCloseStreamPrefetchNotStarted(t *testing.T) {
+	r := newTrackedReader("3\n")
+	s, _ := Prefetch(NewStreamOfLinesOpen(r), 1)
+
+	err := CloseStream(s)
+	c, _ := Collect(s)
+
+	if err != nil || len(c) != 0 || r.closes != 1 {
+		t.Error(`Didn't CloseStream Prefetch not started`)
+	}
+}
+
+func TestShouldCloseStreamDelay(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n")
+	s := Delay(NewStreamOfLinesOpen(r), time.Millisecond, nil)
+
+	c, s, _ := CollectN(s, 1)
+	err := CloseStream(s)
+	rest, _ := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3"}) || len(rest) != 0 || r.closes != 1 {
+		t.Error(`Didn't CloseStream Delay`)
+	}
+}
+
+func TestShouldCloseStreamBufferedToDisk(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n")
+	s := BufferedToDisk(NewStreamOfIntsOpen(r), 1, Codec[int](JSONCodec[int]{}), t.TempDir())
+
+	c, s, _ := CollectN(s, 1)
+	err := CloseStream(s)
+	rest, _ := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3}) || len(rest) != 0 || r.closes != 1 {
+		t.Error(`Didn't CloseStream BufferedToDisk`)
+	}
+}
+
+func TestShouldCloseStreamScan(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n")
+	s := Scan(NewStreamOfLinesOpen(r), "", func(a, v string) (string, error) { return a + v, nil })
+
+	c, s, _ := CollectN(s, 1)
+	err := CloseStream(s)
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3"}) || r.closes != 1 {
+		t.Error(`Didn't CloseStream Scan`)
+	}
+}
+
+// A trackedBucket is a Bucket of a single object, read from `r`.
+type trackedBucket struct {
+	r *trackedReader
+}
+
+func (b trackedBucket) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	return []ObjectInfo{{Key: "a"}}, nil
+}
+
+func (b trackedBucket) Open(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	return b.r, nil
+}
+
+func TestShouldCloseStreamObjectSource(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n")
+	s := Stream[ObjectRecord](ObjectLines(context.Background(), trackedBucket{r: r}, ""))
+
+	c, s, _ := CollectN(s, 1)
+	err := CloseStream(s)
+	rest, _ := Collect(s)
+
+	if err != nil || len(c) != 1 || len(rest) != 0 || r.closes != 1 {
+		t.Error(`Didn't CloseStream ObjectSource`)
+	}
+}
@@ -0,0 +1,37 @@
+package streams
+
+import "encoding/json"
+
+// A Codec encodes values of type T as bytes, and decodes them back.
+type Codec[T any] interface {
+	Marshal(v T) ([]byte, error)
+	Unmarshal(data []byte) (T, error)
+}
+
+// A GobCodec is a Codec using `encoding/gob`.
+type GobCodec[T any] struct{}
+
+func (GobCodec[T]) Marshal(v T) ([]byte, error) {
+	return gobEncode(&v)
+}
+
+func (GobCodec[T]) Unmarshal(data []byte) (T, error) {
+	var v T
+	err := gobDecode(data, &v)
+
+	return v, err
+}
+
+// A JSONCodec is a Codec using `encoding/json`.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Marshal(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec[T]) Unmarshal(data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+
+	return v, err
+}
@@ -0,0 +1,28 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShouldJSONCodec(t *testing.T) {
+	var codec Codec[[]int] = JSONCodec[[]int]{}
+
+	data, _ := codec.Marshal([]int{3, 1, 4})
+	v, _ := codec.Unmarshal(data)
+
+	if !reflect.DeepEqual(v, []int{3, 1, 4}) {
+		t.Error(`Didn't JSONCodec`)
+	}
+}
+
+func TestShouldGobCodec(t *testing.T) {
+	var codec Codec[[]int] = GobCodec[[]int]{}
+
+	data, _ := codec.Marshal([]int{3, 1, 4})
+	v, _ := codec.Unmarshal(data)
+
+	if !reflect.DeepEqual(v, []int{3, 1, 4}) {
+		t.Error(`Didn't GobCodec`)
+	}
+}
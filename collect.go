@@ -0,0 +1,178 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CollectN collects at most `n` elements from the stream `s`, returning
+// them along with the remaining stream, from which collection may resume.
+func CollectN[T any](s Stream[T], n int) ([]T, Stream[T], error) {
+	var collection []T
+	for len(collection) < n {
+		eos, nxs, err := s.Resolve(func(v T) error {
+			collection = append(collection, v)
+
+			return nil
+		})
+		s = nxs
+		if eos || err != nil {
+			return collection, s, driverError(err)
+		}
+	}
+
+	return collection, s, nil
+}
+
+// CollectInto collects all the elements from the stream `s`, appending them
+// to `dst`, and returns the extended slice. The slice may be reused with a
+// zero length across collections to avoid reallocating its backing array.
+func CollectInto[T any](s Stream[T], dst []T) ([]T, error) {
+	if elems, b, ok := backingOf(s); ok {
+		b.advance(len(elems))
+
+		return append(dst, elems...), nil
+	}
+
+	for {
+		eos, nxs, err := s.Resolve(func(v T) error {
+			dst = append(dst, v)
+
+			return nil
+		})
+		s = nxs
+		if eos || err != nil {
+			return dst, driverError(err)
+		}
+	}
+}
+
+// A DuplicateKeyPolicy determines how `CollectMapPolicy` handles elements
+// whose key has already been collected.
+type DuplicateKeyPolicy int
+
+const (
+	// KeepLast keeps the value of the last element with a given key.
+	KeepLast DuplicateKeyPolicy = iota
+	// KeepFirst keeps the value of the first element with a given key.
+	KeepFirst
+	// RejectDuplicates fails the collection with `ErrDuplicateKey`.
+	RejectDuplicates
+)
+
+// ErrDuplicateKey is the error reported by `CollectMapPolicy` on a duplicate
+// key, under the `RejectDuplicates` policy.
+var ErrDuplicateKey = errors.New("streams: duplicate key")
+
+// CollectMap collects the stream `s` into a map, with the key and value for
+// each element given by `kv`. Later elements replace earlier elements with
+// the same key.
+func CollectMap[T any, K comparable, V any](s Stream[T], kv func(T) (K, V, error)) (map[K]V, error) {
+	return CollectMapPolicy(s, kv, KeepLast)
+}
+
+// CollectMapPolicy is as `CollectMap`, handling duplicate keys according to
+// the given `policy`.
+func CollectMapPolicy[T any, K comparable, V any](s Stream[T], kv func(T) (K, V, error), policy DuplicateKeyPolicy) (map[K]V, error) {
+	collection := make(map[K]V)
+	i := 0
+	for {
+		eos, nxs, err := s.Resolve(func(v T) error {
+			k, u, e := kv(v)
+			i++
+			if e != nil {
+				return handled(positioned("CollectMap", i-1, e))
+			}
+
+			if _, ok := collection[k]; ok {
+				switch policy {
+				case KeepFirst:
+					return nil
+				case RejectDuplicates:
+					// A duplicate key is of the data, rather than of the
+					// library
+					return handled(positioned("CollectMap", i-1, fmt.Errorf("%w: %v", ErrDuplicateKey, k)))
+				}
+			}
+
+			collection[k] = u
+
+			return nil
+		})
+		s = nxs
+		if eos || err != nil {
+			return collection, driverError(err)
+		}
+	}
+}
+
+// GroupBy collects the stream `s` into a map of the elements with each key,
+// as given by `key`, in their order in the stream.
+func GroupBy[T any, K comparable](s Stream[T], key func(T) K) (map[K][]T, error) {
+	return GroupByAggregate(s, key, nil, func(g []T, v T) ([]T, error) { return append(g, v), nil })
+}
+
+// GroupByAggregate folds the elements of the stream `s` with each key, as
+// given by `key`, from the initial result `init`, without collecting them.
+func GroupByAggregate[T any, K comparable, R any](s Stream[T], key func(T) K, init R, f func(R, T) (R, error)) (map[K]R, error) {
+	groups := make(map[K]R)
+	i := 0
+	for {
+		eos, nxs, err := s.Resolve(func(v T) error {
+			k := key(v)
+			r, ok := groups[k]
+			if !ok {
+				r = init
+			}
+
+			r, e := f(r, v)
+			i++
+			if e != nil {
+				return handled(positioned("GroupByAggregate", i-1, e))
+			}
+
+			groups[k] = r
+
+			return nil
+		})
+		s = nxs
+		if eos || err != nil {
+			return groups, driverError(err)
+		}
+	}
+}
+
+// CollectChunks collects the stream `s` into consecutive chunks of
+// `chunkSize` elements. The last chunk may have fewer elements, but is
+// never empty. A chunk size less than 1 is taken as 1, as for `Chunk`.
+func CollectChunks[T any](s Stream[T], chunkSize int) ([][]T, error) {
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+
+	var chunks [][]T
+	var chunk []T
+	for {
+		eos, nxs, err := s.Resolve(func(v T) error {
+			if chunk == nil {
+				chunk = make([]T, 0, chunkSize)
+			}
+
+			chunk = append(chunk, v)
+			if len(chunk) == chunkSize {
+				chunks = append(chunks, chunk)
+				chunk = nil
+			}
+
+			return nil
+		})
+		s = nxs
+		if eos || err != nil {
+			if len(chunk) != 0 {
+				chunks = append(chunks, chunk)
+			}
+
+			return chunks, driverError(err)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestShouldCollectN(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1, 5})
+
+	c, s, _ := CollectN(s, 2)
+	if !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't CollectN`)
+	}
+
+	c, _, _ = CollectN(s, 2)
+	if !reflect.DeepEqual(c, []int{4, 1}) {
+		t.Error(`Didn't CollectN from remainder`)
+	}
+}
+
+func TestShouldCollectNOnShortStream(t *testing.T) {
+	s := NewFromSlice([]int{3, 1})
+
+	c, s, _ := CollectN(s, 3)
+	if !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't CollectN on short stream`)
+	}
+
+	c, _, _ = CollectN(s, 3)
+	if len(c) != 0 {
+		t.Error(`Didn't CollectN on exhausted stream`)
+	}
+}
+
+func TestShouldCollectNoneOnZero(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4})
+
+	c, s, _ := CollectN(s, 0)
+	r, _ := Collect(s)
+
+	if len(c) != 0 || !reflect.DeepEqual(r, []int{3, 1, 4}) {
+		t.Error(`Didn't CollectN none`)
+	}
+}
+
+func TestShouldCollectInto(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4})
+	buf := make([]int, 0, 4)
+	buf = append(buf, 2)
+
+	c, _ := CollectInto(s, buf)
+
+	if !reflect.DeepEqual(c, []int{2, 3, 1, 4}) {
+		t.Error(`Didn't CollectInto`)
+	}
+	if &c[0] != &buf[0] {
+		t.Error(`Didn't CollectInto the given buffer`)
+	}
+}
+
+func TestShouldCollectIntoReused(t *testing.T) {
+	buf := make([]int, 0, 4)
+
+	buf, _ = CollectInto(NewFromSlice([]int{3, 1, 4}), buf[:0])
+	buf, _ = CollectInto(NewFromSlice([]int{1, 5}), buf[:0])
+
+	if !reflect.DeepEqual(buf, []int{1, 5}) {
+		t.Error(`Didn't CollectInto reused buffer`)
+	}
+}
+
+func pairKV(v []int) (int, int, error) {
+	return v[0], v[1], nil
+}
+
+func TestShouldCollectMap(t *testing.T) {
+	s := NewFromSlice([][]int{{3, 1}, {4, 1}, {3, 5}})
+
+	m, _ := CollectMap(s, pairKV)
+
+	if !reflect.DeepEqual(m, map[int]int{3: 5, 4: 1}) {
+		t.Error(`Didn't CollectMap`)
+	}
+}
+
+func TestShouldCollectMapKeepingFirst(t *testing.T) {
+	s := NewFromSlice([][]int{{3, 1}, {4, 1}, {3, 5}})
+
+	m, _ := CollectMapPolicy(s, pairKV, KeepFirst)
+
+	if !reflect.DeepEqual(m, map[int]int{3: 1, 4: 1}) {
+		t.Error(`Didn't CollectMap keeping first`)
+	}
+}
+
+func TestShouldCollectMapRejectingDuplicates(t *testing.T) {
+	s := NewFromSlice([][]int{{3, 1}, {4, 1}, {3, 5}})
+
+	_, err := CollectMapPolicy(s, pairKV, RejectDuplicates)
+
+	var he *HandlerError
+	var pe *PositionError
+	if !errors.Is(err, ErrDuplicateKey) || !errors.As(err, &he) || !errors.As(err, &pe) || pe.Op != "CollectMap" || pe.Index != 2 {
+		t.Error(`Didn't CollectMap rejecting duplicates`)
+	}
+}
+
+func TestShouldGroupBy(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6})
+
+	g, err := GroupBy(s, func(v int) bool { return v%2 == 0 })
+
+	if err != nil || !reflect.DeepEqual(g, map[bool][]int{false: {3, 1, 1, 5, 9}, true: {4, 2, 6}}) {
+		t.Error(`Didn't GroupBy`)
+	}
+}
+
+func TestShouldGroupByAggregatePositionError(t *testing.T) {
+	e := errors.New("error")
+
+	_, err := GroupByAggregate(NewFromSlice([]int{3, 1, 4}), func(v int) int { return v % 3 }, 0, func(r, v int) (int, error) {
+		if v == 4 {
+			return r, e
+		}
+		return r + v, nil
+	})
+
+	var pe *PositionError
+	if !errors.As(err, &pe) || pe.Op != "GroupByAggregate" || pe.Index != 2 || !errors.Is(err, e) {
+		t.Error(`Didn't GroupByAggregate position error`)
+	}
+}
+
+func TestShouldGroupByAggregate(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6})
+
+	g, err := GroupByAggregate(s, func(v int) int { return v % 3 }, 0, func(r, v int) (int, error) { return r + v, nil })
+
+	if err != nil || !reflect.DeepEqual(g, map[int]int{0: 18, 1: 6, 2: 7}) {
+		t.Error(`Didn't GroupByAggregate`, g)
+	}
+}
+
+func TestShouldGroupByAggregateErrorOnError(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4})
+
+	_, err := GroupByAggregate(s, func(v int) int { return v }, 0, func(r, v int) (int, error) {
+		if v == 4 {
+			return 0, errors.New("failed")
+		}
+		return r + v, nil
+	})
+
+	if err == nil {
+		t.Error(`Didn't GroupByAggregate error on error`)
+	}
+}
+
+func TestShouldCollectChunks(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1, 5})
+
+	c, _ := CollectChunks(s, 2)
+
+	if !reflect.DeepEqual(c, [][]int{{3, 1}, {4, 1}, {5}}) {
+		t.Error(`Didn't CollectChunks`)
+	}
+}
+
+func TestShouldCollectChunksOnEmpty(t *testing.T) {
+	s := NewFromSlice([]int{})
+
+	c, _ := CollectChunks(s, 2)
+
+	if len(c) != 0 {
+		t.Error(`Didn't CollectChunks on empty`)
+	}
+}
+
+func TestShouldCollectChunksOfAtLeastOne(t *testing.T) {
+	zero, _ := CollectChunks(NewFromSlice([]int{3, 1}), 0)
+	negative, _ := CollectChunks(NewFromSlice([]int{3, 1}), -1)
+
+	if !reflect.DeepEqual(zero, [][]int{{3}, {1}}) || !reflect.DeepEqual(negative, [][]int{{3}, {1}}) {
+		t.Error(`Didn't CollectChunks of at least one`)
+	}
+}
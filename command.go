@@ -0,0 +1,229 @@
+package streams
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os/exec"
+	"sync"
+)
+
+// A command is a subprocess whose output is being streamed. It is started
+// on the first resolution of any of its output streams.
+type command struct {
+	ctx      context.Context
+	cmd      *exec.Cmd
+	once     sync.Once
+	err      error
+	stdout   *bufio.Scanner
+	finished chan struct{}
+
+	// The lines of the standard error, if streamed, as read so far
+	mu       sync.Mutex
+	cond     *sync.Cond
+	stderr   bool
+	errLines []string
+	errDone  bool
+}
+
+func (c *command) start() error {
+	c.once.Do(func() {
+		stdout, err := c.cmd.StdoutPipe()
+		if err != nil {
+			c.err = err
+
+			return
+		}
+
+		var stderr io.Reader
+		if c.stderr {
+			stderr, err = c.cmd.StderrPipe()
+			if err != nil {
+				c.err = err
+
+				return
+			}
+		}
+
+		err = c.cmd.Start()
+		if err != nil {
+			c.err = err
+
+			return
+		}
+
+		c.stdout = bufio.NewScanner(stdout)
+		c.finished = make(chan struct{})
+
+		go func() {
+			select {
+			case <-c.ctx.Done():
+				c.cmd.Process.Kill()
+			case <-c.finished:
+			}
+		}()
+
+		if c.stderr {
+			go c.readStderr(stderr)
+		}
+	})
+
+	return c.err
+}
+
+func (c *command) readStderr(r io.Reader) {
+	in := bufio.NewScanner(r)
+	for in.Scan() {
+		c.mu.Lock()
+		c.errLines = append(c.errLines, in.Text())
+		c.cond.Broadcast()
+		c.mu.Unlock()
+	}
+
+	c.mu.Lock()
+	c.errDone = true
+	c.cond.Broadcast()
+	c.mu.Unlock()
+}
+
+// wait waits for the command to exit, once all its output has been read,
+// and returns its exit status as an error.
+func (c *command) wait() error {
+	if c.stderr {
+		c.mu.Lock()
+		for !c.errDone {
+			c.cond.Wait()
+		}
+		c.mu.Unlock()
+	}
+
+	err := c.cmd.Wait()
+	close(c.finished)
+
+	if c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+
+	return err
+}
+
+// A CommandStream represents the stream of the lines of the standard output
+// of a given command. The command is started on the first resolution, and
+// killed if the context is done before it exits. The stream ends with a
+// non nil error if the command does not exit successfully.
+type CommandStream struct {
+	c *command
+}
+
+func CommandLines(ctx context.Context, cmd *exec.Cmd) Stream[string] {
+	return &CommandStream{c: &command{ctx: ctx, cmd: cmd}}
+}
+
+// CommandLinesStderr is as `CommandLines`, but also streams the lines of the
+// standard error of the command. The standard error is read concurrently,
+// and buffered until resolved.
+func CommandLinesStderr(ctx context.Context, cmd *exec.Cmd) (Stream[string], Stream[string]) {
+	c := &command{ctx: ctx, cmd: cmd, stderr: true}
+	c.cond = sync.NewCond(&c.mu)
+
+	return &CommandStream{c: c}, &CommandStderrStream{c: c}
+}
+
+// Close kills the command, if started and yet to exit, ending the stream.
+func (s *CommandStream) Close() error {
+	if s.c == nil {
+		return nil
+	}
+
+	c := s.c
+	s.c = nil
+	if c.finished != nil {
+		c.cmd.Process.Kill()
+		c.wait()
+	}
+
+	return nil
+}
+
+func (s *CommandStream) Resolve(h func(v string) error) (bool, Stream[string], error) {
+	if s == nil || s.c == nil {
+		return true, s, nil
+	}
+
+	c := s.c
+
+	err := c.start()
+	if err != nil {
+		s.c = nil
+
+		return true, s, internal(err)
+	}
+
+	if !c.stdout.Scan() {
+		err := c.stdout.Err()
+		werr := c.wait()
+		s.c = nil
+		if err == nil {
+			err = werr
+		}
+
+		return true, s, internal(err)
+	}
+
+	err = handled(h(c.stdout.Text()))
+	if err != nil {
+		c.cmd.Process.Kill()
+		c.wait()
+		s.c = nil
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// A CommandStderrStream represents the stream of the lines of the standard
+// error of a command, as streamed by `CommandLinesStderr`.
+type CommandStderrStream struct {
+	c    *command
+	next int
+}
+
+func (s *CommandStderrStream) Resolve(h func(v string) error) (bool, Stream[string], error) {
+	if s == nil || s.c == nil {
+		return true, s, nil
+	}
+
+	c := s.c
+
+	err := c.start()
+	if err != nil {
+		s.c = nil
+
+		return true, s, internal(err)
+	}
+
+	c.mu.Lock()
+	for s.next == len(c.errLines) && !c.errDone {
+		c.cond.Wait()
+	}
+	if s.next == len(c.errLines) {
+		c.mu.Unlock()
+		s.c = nil
+
+		return true, s, nil
+	}
+	line := c.errLines[s.next]
+	c.errLines[s.next] = ""
+	s.next++
+	c.mu.Unlock()
+
+	err = handled(h(line))
+	if err != nil {
+		s.c = nil
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
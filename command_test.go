@@ -0,0 +1,77 @@
+//go:build !windows
+
+package streams
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestShouldCommandLines(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "echo 3; echo 1; echo 4")
+
+	c, err := Collect(CommandLines(context.Background(), cmd))
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "1", "4"}) {
+		t.Error(`Didn't CommandLines`)
+	}
+}
+
+func TestShouldCommandLinesErrorOnExitStatus(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "echo 3; exit 1")
+
+	c, err := Collect(CommandLines(context.Background(), cmd))
+
+	var ee *exec.ExitError
+	if !errors.As(err, &ee) || !reflect.DeepEqual(c, []string{"3"}) {
+		t.Error(`Didn't CommandLines error on exit status`)
+	}
+}
+
+func TestShouldCommandLinesUntilStop(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "while true; do echo 3; done")
+
+	n := 0
+	s := Map(CommandLines(context.Background(), cmd), func(v string) (string, error) {
+		n++
+		if n == 2 {
+			return "", ErrStop
+		}
+		return v, nil
+	})
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []string{"3"}) {
+		t.Error(`Didn't CommandLines until stop`)
+	}
+}
+
+func TestShouldCommandLinesStderr(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "echo 3; echo 1 >&2; echo 4; echo 5 >&2")
+	stdout, stderr := CommandLinesStderr(context.Background(), cmd)
+
+	e, _ := Collect(stderr)
+	c, err := Collect(stdout)
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "4"}) || !reflect.DeepEqual(e, []string{"1", "5"}) {
+		t.Error(`Didn't CommandLinesStderr`)
+	}
+}
+
+func TestShouldCommandLinesCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := exec.Command("sh", "-c", "echo 3; exec sleep 10")
+	s := CommandLines(ctx, cmd)
+
+	c, s, _ := CollectN(s, 1)
+	cancel()
+	_, err := Collect(s)
+
+	if !errors.Is(err, context.Canceled) || !reflect.DeepEqual(c, []string{"3"}) {
+		t.Error(`Didn't CommandLines cancel`)
+	}
+}
@@ -0,0 +1,102 @@
+package streams
+
+import "time"
+
+// A Position is the position of an element in its source stream: its index,
+// counting from 1, along with a source specific offset, such as a byte
+// offset in a file or a queue offset, from which to resume after it.
+type Position struct {
+	Index  int64
+	Offset int64
+}
+
+// A Positioner is a stream that tells the position of the element it is
+// resolving, or last resolved.
+type Positioner interface {
+	Position() Position
+}
+
+// A Committer represents the stream of the elements of a given base stream,
+// which periodically commits the position of the last element successfully
+// handled downstream. A commit is due after a given number of elements, or
+// after a given time interval, since the last commit, whichever comes first.
+// The last position is committed at the end of stream, or on error.
+//
+// The position of an element is that told by the base stream once resolved,
+// if it is a Positioner, as the file and queue sources are, and just the
+// element's index otherwise.
+type Committer[T any] struct {
+	base     Stream[T]
+	commit   func(pos Position) error
+	every    int
+	interval time.Duration
+	pos      Position
+	pending  int
+	last     time.Time
+}
+
+func WithCommit[T any](s Stream[T], commit func(pos Position) error, every int, interval time.Duration) Stream[T] {
+	return &Committer[T]{base: s, commit: commit, every: every, interval: interval}
+}
+
+func (s *Committer[T]) flush() error {
+	if s.pending == 0 {
+		return nil
+	}
+
+	s.pending = 0
+	s.last = time.Now()
+
+	return handled(s.commit(s.pos))
+}
+
+func (s *Committer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if s.last.IsZero() {
+		s.last = time.Now()
+	}
+
+	n := 0
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		e := handled(h(v))
+		if e == nil {
+			n++
+		}
+
+		return e
+	})
+
+	s.base = nxs
+
+	if 0 < n {
+		// The position is told by the stream as left by the resolution
+		// of the element, as a source opened by it
+		if p, ok := nxs.(Positioner); ok {
+			s.pos = p.Position()
+		} else {
+			s.pos = Position{Index: s.pos.Index + int64(n)}
+		}
+		s.pending += n
+
+		if err == nil && ((0 < s.every && s.every <= s.pending) || (0 < s.interval && s.interval <= time.Since(s.last))) {
+			err = s.flush()
+		}
+	}
+
+	if eos || err != nil {
+		cerr := s.flush()
+		if err == nil {
+			err = cerr
+		}
+		s.base = nil
+	}
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
@@ -0,0 +1,123 @@
+package streams
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShouldWithCommitEvery(t *testing.T) {
+	var commits []int64
+	s := WithCommit(NewFromSlice([]int{3, 1, 4, 1, 5}), func(pos Position) error {
+		commits = append(commits, pos.Index)
+		return nil
+	}, 2, 0)
+
+	Collect(s)
+
+	if !reflect.DeepEqual(commits, []int64{2, 4, 5}) {
+		t.Error(`Didn't WithCommit every`)
+	}
+}
+
+func TestShouldWithCommitOnlyHandled(t *testing.T) {
+	var commits []int64
+	s := WithCommit(NewFromSlice([]int{3, 1, 4, 1, 5}), func(pos Position) error {
+		commits = append(commits, pos.Index)
+		return nil
+	}, 2, 0)
+	s = Map(s, failingAt4)
+
+	Collect(s)
+
+	if !reflect.DeepEqual(commits, []int64{2}) {
+		t.Error(`Didn't WithCommit only handled`)
+	}
+}
+
+func TestShouldWithCommitInterval(t *testing.T) {
+	var commits []int64
+	s := WithCommit(NewFromSlice([]int{3, 1, 4}), func(pos Position) error {
+		commits = append(commits, pos.Index)
+		return nil
+	}, 0, time.Nanosecond)
+	s = Map(s, func(v int) (int, error) {
+		time.Sleep(time.Millisecond)
+		return v, nil
+	})
+
+	Collect(s)
+
+	if !reflect.DeepEqual(commits, []int64{1, 2, 3}) {
+		t.Error(`Didn't WithCommit interval`)
+	}
+}
+
+func TestShouldWithCommitNonPositioner(t *testing.T) {
+	var commits []int64
+	s := WithCommit(Drop(NewFromSlice([]int{3, 1, 4}), 1), func(pos Position) error {
+		commits = append(commits, pos.Index)
+		return nil
+	}, 10, 0)
+
+	Collect(s)
+
+	if !reflect.DeepEqual(commits, []int64{2}) {
+		t.Error(`Didn't WithCommit non positioner`)
+	}
+}
+
+func TestShouldWithCommitFileOffsets(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "input")
+	os.WriteFile(filename, []byte("3\n1\n4\n"), 0o644)
+
+	var commits []Position
+	s := WithCommit(NewStreamOfFileInts(filename), func(pos Position) error {
+		commits = append(commits, pos)
+		return nil
+	}, 1, 0)
+
+	Collect(s)
+
+	if !reflect.DeepEqual(commits, []Position{{1, 2}, {2, 4}, {3, 6}}) {
+		t.Error(`Didn't WithCommit file offsets`, commits)
+	}
+}
+
+func TestShouldWithCommitJournalOffsets(t *testing.T) {
+	in := "MESSAGE=a\n\nMESSAGE=bc\n\n"
+
+	var commits []Position
+	s := WithCommit(ParseJournalExport(strings.NewReader(in)), func(pos Position) error {
+		commits = append(commits, pos)
+		return nil
+	}, 1, 0)
+
+	Collect(s)
+
+	if !reflect.DeepEqual(commits, []Position{{1, 11}, {2, 23}}) {
+		t.Error(`Didn't WithCommit journal offsets`, commits)
+	}
+}
+
+func TestShouldWithCommitQueueOffsets(t *testing.T) {
+	ack := &fakeAMQP{}
+	var commits []Position
+	s := WithCommit[AMQPDelivery](NewAMQPSource(context.Background(), amqpDeliveries(ack,
+		AMQPDelivery{Body: []byte("3")},
+		AMQPDelivery{Body: []byte("1")},
+	), AMQPOptions{}), func(pos Position) error {
+		commits = append(commits, pos)
+		return nil
+	}, 2, 0)
+
+	Collect(s)
+
+	if !reflect.DeepEqual(commits, []Position{{2, 2}}) {
+		t.Error(`Didn't WithCommit queue offsets`, commits)
+	}
+}
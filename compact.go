@@ -0,0 +1,17 @@
+package streams
+
+// Compact is the stream of the values pointed to by the non nil elements
+// of `s`.
+func Compact[T any](s Stream[*T]) Stream[T] {
+	s = Filter(s, func(v *T) bool { return v != nil })
+
+	return Map(s, func(v *T) (T, error) { return *v, nil })
+}
+
+// CompactZero is the stream of the elements of `s` other than the zero
+// value of T.
+func CompactZero[T comparable](s Stream[T]) Stream[T] {
+	var zero T
+
+	return Filter(s, func(v T) bool { return v != zero })
+}
@@ -0,0 +1,27 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShouldCompact(t *testing.T) {
+	a, b := 3, 1
+	s := NewFromSlice([]*int{&a, nil, &b, nil})
+
+	c, _ := Collect(Compact(s))
+
+	if !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't Compact`)
+	}
+}
+
+func TestShouldCompactZero(t *testing.T) {
+	s := NewFromSlice([]string{"3", "", "1", ""})
+
+	c, _ := Collect(CompactZero(s))
+
+	if !reflect.DeepEqual(c, []string{"3", "1"}) {
+		t.Error(`Didn't CompactZero`)
+	}
+}
@@ -0,0 +1,74 @@
+package streams
+
+import "golang.org/x/exp/constraints"
+
+// A Compare compares two values, being negative if `a` is less than `b`,
+// zero if they are equivalent, and positive if `a` is greater than `b`. It
+// is the convention of `cmp.Compare` and `slices.SortFunc`, so that such
+// functions are Compares. The ordered operations take a Compare, and those
+// for ordered types use the Compare of `Ordered`.
+type Compare[T any] func(a, b T) int
+
+// Ordered is the Compare of the natural order of T, as `cmp.Compare`: a NaN
+// is less than any other value, and equivalent to any NaN.
+func Ordered[T constraints.Ordered]() Compare[T] {
+	return func(a, b T) int {
+		aNaN, bNaN := a != a, b != b
+		switch {
+		case aNaN && bNaN:
+			return 0
+		case aNaN || a < b:
+			return -1
+		case bNaN || b < a:
+			return 1
+		}
+
+		return 0
+	}
+}
+
+// FromLess is the Compare of the strict order `less`.
+func FromLess[T any](less func(a, b T) bool) Compare[T] {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+
+		return 0
+	}
+}
+
+// CompareBy is the Compare of the natural order of the keys of the values.
+func CompareBy[T any, K constraints.Ordered](key func(v T) K) Compare[T] {
+	c := Ordered[K]()
+
+	return func(a, b T) int {
+		return c(key(a), key(b))
+	}
+}
+
+// Less is the strict order of the Compare.
+func (c Compare[T]) Less(a, b T) bool {
+	return c(a, b) < 0
+}
+
+// Reverse is the Compare of the reverse order.
+func (c Compare[T]) Reverse() Compare[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Then is the Compare that breaks the ties of `c` with `d`.
+func (c Compare[T]) Then(d Compare[T]) Compare[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+
+		return d(a, b)
+	}
+}
@@ -0,0 +1,58 @@
+package streams
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestShouldCompareOrdered(t *testing.T) {
+	c := Ordered[float64]()
+	nan := math.NaN()
+
+	if c(1, 2) >= 0 || c(2, 1) <= 0 || c(1, 1) != 0 || c(nan, 1) >= 0 || c(1, nan) <= 0 || c(nan, nan) != 0 {
+		t.Error(`Didn't Compare ordered`)
+	}
+}
+
+func TestShouldCompareFromLess(t *testing.T) {
+	c := FromLess(func(a, b string) bool { return len(a) < len(b) })
+
+	if c("a", "bb") >= 0 || c("bb", "a") <= 0 || c("a", "b") != 0 {
+		t.Error(`Didn't Compare from less`)
+	}
+}
+
+func TestShouldCompareThenReverse(t *testing.T) {
+	words := []string{"bb", "a", "ccc", "aa", "c"}
+	byLen := CompareBy(func(w string) int { return len(w) })
+	c := byLen.Reverse().Then(strings.Compare)
+
+	sort.Slice(words, func(i, j int) bool { return c.Less(words[i], words[j]) })
+
+	if strings.Join(words, " ") != "ccc aa bb a c" {
+		t.Error(`Didn't Compare then reverse`)
+	}
+}
+
+func TestShouldIsSortedFunc(t *testing.T) {
+	desc := Ordered[int]().Reverse()
+
+	sorted, _ := IsSortedFunc(NewFromSlice([]int{4, 3, 1, 1}), desc)
+	i, _ := FirstUnsortedFunc(NewFromSlice([]int{4, 1, 3}), desc)
+
+	if !sorted || i != 2 {
+		t.Error(`Didn't IsSortedFunc`)
+	}
+}
+
+func TestShouldEnforceMonotonicFunc(t *testing.T) {
+	s := EnforceMonotonicFunc(NewFromSlice([]int{4, 3, 5, 1}), Ordered[int]().Reverse(), MonotonicDrop)
+
+	c, _ := Collect(s)
+
+	if len(c) != 3 || c[0] != 4 || c[1] != 3 || c[2] != 1 {
+		t.Error(`Didn't EnforceMonotonicFunc`)
+	}
+}
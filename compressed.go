@@ -0,0 +1,127 @@
+package streams
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// decompressed is the content of `r`, decompressed if it is compressed with
+// gzip or zstd, as told by its magic bytes.
+func decompressed(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+
+	magic, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return gzip.NewReader(br)
+	case bytes.HasPrefix(magic, zstdMagic):
+		dec, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+
+		return dec.IOReadCloser(), nil
+	}
+
+	return io.NopCloser(br), nil
+}
+
+// A StreamOfCompressedFileLines represents the stream of the lines of a
+// given file, decompressed on the fly if it is compressed with gzip or zstd,
+// as told by its first bytes rather than its extension, so that log archives
+// and plain log files are read alike. The file is opened on the first
+// resolution, and closed at the end of stream, or on error.
+type StreamOfCompressedFileLines struct {
+	filename string
+	file     *os.File
+	r        io.ReadCloser
+	lines    Stream[string]
+}
+
+func NewCompressedFileLines(filename string) Stream[string] {
+	return &StreamOfCompressedFileLines{filename: filename}
+}
+
+func (s *StreamOfCompressedFileLines) open() error {
+	file, err := os.Open(s.filename)
+	if err != nil {
+		return err
+	}
+
+	r, err := decompressed(file)
+	if err != nil {
+		file.Close()
+
+		return err
+	}
+
+	s.file, s.r = file, r
+	s.lines = NewScannerStream(r, bufio.ScanLines, 0)
+
+	return nil
+}
+
+func (s *StreamOfCompressedFileLines) close() error {
+	err := s.r.Close()
+	cerr := s.file.Close()
+	if err == nil {
+		err = cerr
+	}
+
+	s.filename = ""
+	s.lines = nil
+
+	return err
+}
+
+// Close closes the file, if open, ending the stream.
+func (s *StreamOfCompressedFileLines) Close() error {
+	if s.lines == nil {
+		s.filename = ""
+
+		return nil
+	}
+
+	return s.close()
+}
+
+func (s *StreamOfCompressedFileLines) Resolve(h func(v string) error) (bool, Stream[string], error) {
+	if s == nil || (s.lines == nil && s.filename == "") {
+		return true, s, nil
+	}
+
+	if s.lines == nil {
+		err := s.open()
+		if err != nil {
+			s.filename = ""
+
+			return true, s, internal(err)
+		}
+	}
+
+	eos, nxs, err := s.lines.Resolve(h)
+	s.lines = nxs
+
+	if eos || err != nil {
+		cerr := s.close()
+		if err == nil {
+			err = cerr
+		}
+	}
+
+	if err != nil {
+		return true, s, internal(err)
+	}
+
+	return eos, s, nil
+}
@@ -0,0 +1,89 @@
+package streams
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func writeCompressed(t *testing.T, name string, compress func(w *bytes.Buffer) error) string {
+	var buf bytes.Buffer
+	err := compress(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	err = os.WriteFile(path, buf.Bytes(), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestShouldStreamGzipFileLines(t *testing.T) {
+	path := writeCompressed(t, "app.log.1", func(buf *bytes.Buffer) error {
+		w := gzip.NewWriter(buf)
+		w.Write([]byte("3\n1\n4\n"))
+		return w.Close()
+	})
+
+	c, err := Collect(NewCompressedFileLines(path))
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "1", "4"}) {
+		t.Error(`Didn't stream gzip file lines`, err)
+	}
+}
+
+func TestShouldStreamZstdFileLines(t *testing.T) {
+	path := writeCompressed(t, "app.log.zst", func(buf *bytes.Buffer) error {
+		w, err := zstd.NewWriter(buf)
+		if err != nil {
+			return err
+		}
+		w.Write([]byte("3\n1\n4\n"))
+		return w.Close()
+	})
+
+	c, err := Collect(NewCompressedFileLines(path))
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "1", "4"}) {
+		t.Error(`Didn't stream zstd file lines`, err)
+	}
+}
+
+func TestShouldStreamPlainFileLines(t *testing.T) {
+	path := writeCompressed(t, "app.log", func(buf *bytes.Buffer) error {
+		_, err := buf.WriteString("3\n1")
+		return err
+	})
+
+	c, err := Collect(NewCompressedFileLines(path))
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "1"}) {
+		t.Error(`Didn't stream plain file lines`, err)
+	}
+}
+
+func TestShouldStreamCompressedFileLinesErrorOnCorruption(t *testing.T) {
+	path := writeCompressed(t, "app.log.gz", func(buf *bytes.Buffer) error {
+		w := gzip.NewWriter(buf)
+		w.Write([]byte("3\n1\n4\n"))
+		w.Close()
+		buf.Truncate(buf.Len() - 4)
+		return nil
+	})
+
+	_, err := Collect(NewCompressedFileLines(path))
+	_, missing := Collect(NewCompressedFileLines(filepath.Join(t.TempDir(), "missing")))
+
+	if err == nil || missing == nil {
+		t.Error(`Didn't stream compressed file lines error on corruption`)
+	}
+}
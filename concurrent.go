@@ -0,0 +1,48 @@
+package streams
+
+// A resolution carries the outcome of resolving a stream in a goroutine:
+// either an element, or the end of stream condition along with any error.
+type resolution[T any] struct {
+	v   T
+	eos bool
+	err error
+}
+
+// pump resolves the stream `s` in a new goroutine, sending its elements on
+// the returned channel, which has a buffer of `n` elements. The last
+// resolution sent is the end of stream condition, after which the channel
+// is closed. Closing `done` stops the goroutine at the next element. The
+// stream as left by the goroutine is stored in `rest` before the channel is
+// closed.
+func pump[T any](s Stream[T], n int, done <-chan struct{}, rest *Stream[T]) <-chan resolution[T] {
+	out := make(chan resolution[T], n)
+
+	go func() {
+		defer func() {
+			*rest = s
+			close(out)
+		}()
+
+		for {
+			eos, nxs, err := s.Resolve(func(v T) error {
+				select {
+				case out <- resolution[T]{v: v}:
+					return nil
+				case <-done:
+					return ErrStop
+				}
+			})
+			s = nxs
+			if eos || err != nil {
+				select {
+				case out <- resolution[T]{eos: true, err: driverError(err)}:
+				case <-done:
+				}
+
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,86 @@
+package streams
+
+import "context"
+
+// A ContextResolver represents the stream of the elements of a given base
+// stream, until a given context is done, when the stream fails with the
+// error of the context.
+//
+// The base stream is resolved concurrently, in its own goroutine, so that a
+// resolution blocked on the base stream, such as on a network source, is
+// cancelled along with the context.
+type ContextResolver[T any] struct {
+	ctx  context.Context
+	base Stream[T]
+	in   <-chan resolution[T]
+	done chan struct{}
+	// The base stream as left by its resolution, once stopped
+	rest    Stream[T]
+	stopped bool
+}
+
+// WithContext is the stream `s`, cancelled when `ctx` is done.
+func WithContext[T any](ctx context.Context, s Stream[T]) Stream[T] {
+	return &ContextResolver[T]{ctx: ctx, base: s}
+}
+
+func (s *ContextResolver[T]) stop() {
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
+	}
+	s.stopped = true
+}
+
+// Close stops the resolution of the base stream, once the resolution in
+// progress, if any, is done, ending the stream.
+func (s *ContextResolver[T]) Close() error {
+	s.stop()
+	if s.in != nil {
+		for range s.in {
+		}
+		s.base, s.in = s.rest, nil
+	}
+
+	return nil
+}
+
+func (s *ContextResolver[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil || s.stopped {
+		return true, s, nil
+	}
+
+	err := s.ctx.Err()
+	if err != nil {
+		s.stop()
+
+		return true, s, internal(err)
+	}
+
+	if s.in == nil {
+		s.done = make(chan struct{})
+		s.in = pump(s.base, 0, s.done, &s.rest)
+	}
+
+	select {
+	case r := <-s.in:
+		if r.eos {
+			s.stop()
+
+			return true, s, r.err
+		}
+
+		err := handled(h(r.v))
+		if err != nil {
+			s.stop()
+
+			return true, s, err
+		}
+
+		return false, s, nil
+	case <-s.ctx.Done():
+		s.stop()
+
+		return true, s, internal(s.ctx.Err())
+	}
+}
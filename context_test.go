@@ -0,0 +1,72 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShouldWithContext(t *testing.T) {
+	c, err := Collect(WithContext(context.Background(), NewFromSlice([]int{3, 1, 4})))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't WithContext`)
+	}
+}
+
+func TestShouldWithContextCancelBlocked(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan int)
+	s := WithContext(ctx, FromChannel(ch))
+
+	go func() {
+		ch <- 3
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	c, err := Collect(s)
+
+	if !errors.Is(err, context.Canceled) || !reflect.DeepEqual(c, []int{3}) {
+		t.Error(`Didn't WithContext cancel blocked`)
+	}
+}
+
+func TestShouldWithContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c, err := Collect(WithContext(ctx, NewFromSlice([]int{3, 1, 4})))
+
+	if !errors.Is(err, context.Canceled) || len(c) != 0 {
+		t.Error(`Didn't WithContext done`)
+	}
+}
+
+func TestShouldWithContextEndAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := WithContext(ctx, NewFromSlice([]int{3, 1, 4}))
+
+	c, s, _ := CollectN(s, 1)
+	cancel()
+	_, s, err := CollectN(s, 1)
+	eos, _, rerr := s.Resolve(func(v int) error { return nil })
+
+	if !errors.Is(err, context.Canceled) || !reflect.DeepEqual(c, []int{3}) || !eos || rerr != nil {
+		t.Error(`Didn't WithContext end after cancel`)
+	}
+}
+
+func TestShouldCloseStreamWithContext(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n")
+	s := WithContext(context.Background(), NewStreamOfLinesOpen(r))
+
+	c, s, _ := CollectN(s, 1)
+	err := CloseStream(s)
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3"}) || r.closes != 1 {
+		t.Error(`Didn't CloseStream WithContext`)
+	}
+}
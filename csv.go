@@ -0,0 +1,287 @@
+package streams
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrCSVField is the error of a CSV field that cannot be decoded into the
+// struct field of its column.
+var ErrCSVField = errors.New("streams: bad csv field")
+
+// A csvConfig is the configuration of the CSV reader of a CSV stream.
+type csvConfig struct {
+	comma, comment   rune
+	fieldsPerRecord  int
+	lazyQuotes, trim bool
+	intern           *Interner
+}
+
+// A CSVOption configures a CSV stream.
+type CSVOption func(c *csvConfig)
+
+// CSVComma sets the field delimiter, a comma by default.
+func CSVComma(r rune) CSVOption {
+	return func(c *csvConfig) { c.comma = r }
+}
+
+// CSVComment sets the character starting comment lines, none by default.
+func CSVComment(r rune) CSVOption {
+	return func(c *csvConfig) { c.comment = r }
+}
+
+// CSVFieldsPerRecord sets the number of fields of each record, as for
+// `csv.Reader`: that of the first record if 0, and any if negative.
+func CSVFieldsPerRecord(n int) CSVOption {
+	return func(c *csvConfig) { c.fieldsPerRecord = n }
+}
+
+// CSVLazyQuotes allows quotes in unquoted fields, and unescaped quotes in
+// quoted fields.
+func CSVLazyQuotes() CSVOption {
+	return func(c *csvConfig) { c.lazyQuotes = true }
+}
+
+// CSVTrimLeadingSpace ignores the leading white space of fields.
+func CSVTrimLeadingSpace() CSVOption {
+	return func(c *csvConfig) { c.trim = true }
+}
+
+// CSVIntern interns the fields with `in`, such as for columns with few
+// distinct values.
+func CSVIntern(in *Interner) CSVOption {
+	return func(c *csvConfig) { c.intern = in }
+}
+
+func newCSVReader(r io.Reader, opts []CSVOption) (*csv.Reader, *Interner) {
+	c := csvConfig{comma: ','}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = c.comma
+	cr.Comment = c.comment
+	cr.FieldsPerRecord = c.fieldsPerRecord
+	cr.LazyQuotes = c.lazyQuotes
+	cr.TrimLeadingSpace = c.trim
+
+	return cr, c.intern
+}
+
+// A StreamOfCSV represents the stream of the records read from a given CSV
+// reader, one at a time. A malformed record fails the resolution of its own
+// record only: the stream may be resolved further, from the next record.
+type StreamOfCSV struct {
+	r      *csv.Reader
+	intern *Interner
+}
+
+func NewCSVStream(r io.Reader, opts ...CSVOption) Stream[[]string] {
+	cr, in := newCSVReader(r, opts)
+
+	return &StreamOfCSV{r: cr, intern: in}
+}
+
+// read reads the next record, or returns nil at the end of the input.
+func (s *StreamOfCSV) read() ([]string, error) {
+	record, err := s.r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+
+		return nil, err
+	}
+
+	if s.intern != nil {
+		for i, f := range record {
+			record[i] = s.intern.Intern(f)
+		}
+	}
+
+	return record, nil
+}
+
+func (s *StreamOfCSV) Resolve(h func(v []string) error) (bool, Stream[[]string], error) {
+	if s == nil || s.r == nil {
+		return true, s, nil
+	}
+
+	record, err := s.read()
+	if record == nil || err != nil {
+		// The reader goes on from the next record after a parse error
+		var perr *csv.ParseError
+		if !errors.As(err, &perr) {
+			s.r = nil
+		}
+
+		return true, s, internal(err)
+	}
+
+	err = handled(h(record))
+	if err != nil {
+		s.r = nil
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// A CSVDecoder represents the stream of the records of a CSV reader decoded
+// into structs of type T, by the column names of a header row. Columns are
+// matched with the fields of T by their `csv` tag or, without one, by their
+// name, regardless of case. Fields tagged "-", and columns without field, are
+// ignored. Fields may be strings, booleans, integers or floats.
+type CSVDecoder[T any] struct {
+	records *StreamOfCSV
+	// The index of the field of each column, or -1
+	fields []int
+}
+
+func DecodeCSV[T any](r io.Reader, opts ...CSVOption) Stream[T] {
+	cr, in := newCSVReader(r, opts)
+
+	return &CSVDecoder[T]{records: &StreamOfCSV{r: cr, intern: in}}
+}
+
+// csvFields is the index of the field of T of each column in `header`.
+func csvFields[T any](header []string) ([]int, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("streams: csv decoding into %v, not a struct", t)
+	}
+
+	fields := make([]int, len(header))
+	for i, col := range header {
+		fields[i] = -1
+		for j := 0; j < t.NumField(); j++ {
+			f := t.Field(j)
+			name := f.Tag.Get("csv")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+
+			if name == col || (name == "" && strings.EqualFold(f.Name, col)) {
+				fields[i] = j
+
+				break
+			}
+		}
+	}
+
+	return fields, nil
+}
+
+func setCSVField(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+
+	return nil
+}
+
+func (s *CSVDecoder[T]) decode(record []string) (T, error) {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	for i, f := range s.fields {
+		if f < 0 || len(record) <= i {
+			continue
+		}
+
+		err := setCSVField(rv.Field(f), record[i])
+		if err != nil {
+			line, _ := s.records.r.FieldPos(i)
+
+			return v, fmt.Errorf("%w: line %d, column %q: %v", ErrCSVField, line, rv.Type().Field(f).Name, err)
+		}
+	}
+
+	return v, nil
+}
+
+func (s *CSVDecoder[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.records == nil || s.records.r == nil {
+		return true, s, nil
+	}
+
+	if s.fields == nil {
+		header, err := s.records.read()
+		if header == nil || err != nil {
+			s.records.r = nil
+
+			return true, s, internal(err)
+		}
+
+		s.fields, err = csvFields[T](header)
+		if err != nil {
+			s.records.r = nil
+
+			return true, s, internal(err)
+		}
+	}
+
+	eos, _, err := s.records.Resolve(func(record []string) error {
+		v, e := s.decode(record)
+		if e != nil {
+			return e
+		}
+
+		return handled(h(v))
+	})
+
+	if err != nil {
+		return true, s, internal(err)
+	}
+
+	return eos, s, nil
+}
+
+// WriteCSV writes each element of the stream `s` to `w`, as a CSV record,
+// returning how many records were written. Writes are buffered, and flushed
+// before returning, even on error.
+func WriteCSV(s Stream[[]string], w io.Writer) (int, error) {
+	cw := csv.NewWriter(w)
+
+	n, err := SendAll[[]string](s, SinkFunc[[]string](cw.Write))
+
+	cw.Flush()
+	if err == nil {
+		err = cw.Error()
+	}
+
+	return n, err
+}
@@ -0,0 +1,94 @@
+package streams
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShouldStreamCSV(t *testing.T) {
+	c, err := Collect(NewCSVStream(strings.NewReader("a,\"b,c\"\n3,1\n")))
+
+	if err != nil || !reflect.DeepEqual(c, [][]string{{"a", "b,c"}, {"3", "1"}}) {
+		t.Error(`Didn't stream CSV`)
+	}
+}
+
+func TestShouldStreamCSVWithOptions(t *testing.T) {
+	in := NewInterner(0)
+	s := NewCSVStream(strings.NewReader("# comment\nx; y\nx;z;w\n"), CSVComma(';'), CSVComment('#'), CSVTrimLeadingSpace(), CSVFieldsPerRecord(-1), CSVIntern(in))
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, [][]string{{"x", "y"}, {"x", "z", "w"}}) || in.Len() != 4 {
+		t.Error(`Didn't stream CSV with options`)
+	}
+}
+
+func TestShouldStreamCSVError(t *testing.T) {
+	c, err := Collect(NewCSVStream(strings.NewReader("a,b\nc\n")))
+
+	if err == nil || len(c) != 1 {
+		t.Error(`Didn't stream CSV error`)
+	}
+}
+
+func TestShouldStreamCSVAfterParseError(t *testing.T) {
+	var errs []error
+	s := SkipErrors(NewCSVStream(strings.NewReader("a,b\nc,d\"e\nf,g\n")), func(err error) { errs = append(errs, err) })
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, [][]string{{"a", "b"}, {"f", "g"}}) || len(errs) != 1 {
+		t.Error(`Didn't stream CSV after parse error`)
+	}
+}
+
+type csvPoint struct {
+	Name  string
+	X     int     `csv:"x_coord"`
+	Y     float64 `csv:"y"`
+	Valid bool
+	Note  string `csv:"-"`
+}
+
+func TestShouldDecodeCSV(t *testing.T) {
+	r := strings.NewReader("name,x_coord,y,valid,note,extra\np,3,1.5,true,n,e\nq,-4,0,false,n,e\n")
+
+	c, err := Collect(DecodeCSV[csvPoint](r))
+
+	want := []csvPoint{{"p", 3, 1.5, true, ""}, {"q", -4, 0, false, ""}}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't decode CSV`, c, err)
+	}
+}
+
+func TestShouldDecodeCSVErrorOnBadField(t *testing.T) {
+	r := strings.NewReader("name,x_coord\np,3\nq,three\n")
+
+	c, err := Collect(DecodeCSV[csvPoint](r))
+
+	if !errors.Is(err, ErrCSVField) || len(c) != 1 || !strings.Contains(err.Error(), "line 3") {
+		t.Error(`Didn't decode CSV error on bad field`, err)
+	}
+}
+
+func TestShouldDecodeCSVEmpty(t *testing.T) {
+	c, err := Collect(DecodeCSV[csvPoint](strings.NewReader("")))
+
+	if err != nil || len(c) != 0 {
+		t.Error(`Didn't decode CSV empty`)
+	}
+}
+
+func TestShouldWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	n, err := WriteCSV(NewFromSlice([][]string{{"a", "b,c"}, {"3", "1"}}), &buf)
+
+	if err != nil || n != 2 || buf.String() != "a,\"b,c\"\n3,1\n" {
+		t.Error(`Didn't WriteCSV`)
+	}
+}
@@ -0,0 +1,79 @@
+package streams
+
+import "errors"
+
+// A StageError is an error of the named stage of a pipeline.
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return e.Stage + ": " + e.Err.Error()
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// InStage is the function `f` whose errors are those of the named stage.
+func InStage[T any, U any](stage string, f func(v T) (U, error)) func(v T) (U, error) {
+	return func(v T) (U, error) {
+		u, err := f(v)
+		if err != nil && !errors.Is(err, ErrStop) {
+			err = &StageError{Stage: stage, Err: err}
+		}
+
+		return u, err
+	}
+}
+
+// A FailedElement is an element whose processing failed, along with the
+// error, and the name of the failing stage, if the error is a StageError.
+type FailedElement[T any] struct {
+	Value T
+	Err   error
+	Stage string
+}
+
+// A DeadLetterer represents the stream of the elements of a given base
+// stream, whose failed downstream processing, rather than ending the stream,
+// sends the element to a dead-letter sink. Only failing to send to the
+// dead-letter sink ends the stream.
+type DeadLetterer[T any] struct {
+	base Stream[T]
+	dl   Sink[FailedElement[T]]
+}
+
+func WithDeadLetter[T any](s Stream[T], dl Sink[FailedElement[T]]) Stream[T] {
+	return &DeadLetterer[T]{base: s, dl: dl}
+}
+
+func (s *DeadLetterer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		err := handled(h(v))
+		if err == nil || errors.Is(err, ErrStop) {
+			return err
+		}
+
+		f := FailedElement[T]{Value: v, Err: err}
+		var se *StageError
+		if errors.As(err, &se) {
+			f.Stage = se.Stage
+		}
+
+		return handled(s.dl.Send(f))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
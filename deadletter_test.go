@@ -0,0 +1,85 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestShouldWithDeadLetter(t *testing.T) {
+	var failed []FailedElement[int]
+	dl := SinkFunc[FailedElement[int]](func(f FailedElement[int]) error {
+		failed = append(failed, f)
+		return nil
+	})
+
+	s := WithDeadLetter[int](NewFromSlice([]int{3, 1, 4, 1}), dl)
+	s = Map(s, InStage("odd", func(v int) (int, error) {
+		if v%2 == 0 {
+			return 0, errors.New("even")
+		}
+		return v, nil
+	}))
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 1}) {
+		t.Error(`Didn't WithDeadLetter`)
+	}
+	if len(failed) != 1 || failed[0].Value != 4 || failed[0].Stage != "odd" || failed[0].Err.Error() != "streams: Map at element 2: odd: even" {
+		t.Error(`Didn't WithDeadLetter failed element`)
+	}
+}
+
+func TestShouldWithDeadLetterErrorOnSendError(t *testing.T) {
+	dl := SinkFunc[FailedElement[int]](func(f FailedElement[int]) error {
+		return errors.New("full")
+	})
+
+	s := WithDeadLetter[int](NewFromSlice([]int{3, 1, 4, 1}), dl)
+	s = Map(s, func(v int) (int, error) {
+		if v%2 == 0 {
+			return 0, errors.New("even")
+		}
+		return v, nil
+	})
+
+	c, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't WithDeadLetter error on send error`)
+	}
+}
+
+func TestShouldWithDeadLetterStopOnWrappedStop(t *testing.T) {
+	var failed []FailedElement[int]
+	dl := SinkFunc[FailedElement[int]](func(f FailedElement[int]) error {
+		failed = append(failed, f)
+		return nil
+	})
+
+	s := WithDeadLetter[int](NewFromSlice([]int{3, 1, 4, 1}), dl)
+	s = Map(s, InStage("odd", func(v int) (int, error) {
+		if v%2 == 0 {
+			return 0, fmt.Errorf("done: %w", ErrStop)
+		}
+		return v, nil
+	}))
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1}) || len(failed) != 0 {
+		t.Error(`Didn't WithDeadLetter stop on wrapped stop`)
+	}
+}
+
+func TestShouldWithDeadLetterOnZeroValue(t *testing.T) {
+	s := &DeadLetterer[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't WithDeadLetter on zero value`)
+	}
+}
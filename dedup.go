@@ -0,0 +1,187 @@
+package streams
+
+import (
+	"bufio"
+	"os"
+	"sync"
+)
+
+// A DedupStore is a set of the keys of the elements seen so far, which may
+// persist across runs.
+type DedupStore interface {
+	Seen(key string) (bool, error)
+	Mark(key string) error
+}
+
+// A MemoryDedupStore is a DedupStore in memory, for a single run.
+type MemoryDedupStore struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+func NewMemoryDedupStore() *MemoryDedupStore {
+	return &MemoryDedupStore{keys: make(map[string]struct{})}
+}
+
+func (d *MemoryDedupStore) Seen(key string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, ok := d.keys[key]
+
+	return ok, nil
+}
+
+func (d *MemoryDedupStore) Mark(key string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.keys[key] = struct{}{}
+
+	return nil
+}
+
+// A FileDedupStore is a DedupStore persisting its keys in a file, one per
+// line, to which each marked key is appended. Keys must not contain line
+// breaks. The store must be closed once done.
+type FileDedupStore struct {
+	mem MemoryDedupStore
+	f   *os.File
+}
+
+// OpenFileDedupStore opens the store persisted in the file at `path`,
+// creating the file if needed.
+func OpenFileDedupStore(path string) (*FileDedupStore, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &FileDedupStore{mem: MemoryDedupStore{keys: make(map[string]struct{})}, f: f}
+
+	in := bufio.NewScanner(f)
+	for in.Scan() {
+		d.mem.keys[in.Text()] = struct{}{}
+	}
+	if err := in.Err(); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *FileDedupStore) Seen(key string) (bool, error) {
+	return d.mem.Seen(key)
+}
+
+func (d *FileDedupStore) Mark(key string) error {
+	d.mem.mu.Lock()
+	defer d.mem.mu.Unlock()
+
+	if _, ok := d.mem.keys[key]; ok {
+		return nil
+	}
+
+	_, err := d.f.WriteString(key + "\n")
+	if err != nil {
+		return err
+	}
+
+	d.mem.keys[key] = struct{}{}
+
+	return nil
+}
+
+func (d *FileDedupStore) Close() error {
+	return d.f.Close()
+}
+
+// A PersistentDeduper represents the stream of the elements of a given base
+// stream whose keys were not seen before, according to a DedupStore. A key
+// is marked as seen once its element is handled successfully, so that an
+// element whose handling failed is handled again on the next run.
+type PersistentDeduper[T any] struct {
+	base  Stream[T]
+	key   func(v T) string
+	store DedupStore
+}
+
+func DedupPersistent[T any](s Stream[T], key func(v T) string, store DedupStore) Stream[T] {
+	return &PersistentDeduper[T]{base: s, key: key, store: store}
+}
+
+func (s *PersistentDeduper[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		k := s.key(v)
+
+		seen, err := s.store.Seen(k)
+		if err != nil || seen {
+			return internal(err)
+		}
+
+		err = handled(h(v))
+		if err != nil {
+			return err
+		}
+
+		return internal(s.store.Mark(k))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+// A ConsecutiveDeduper represents the stream of the elements of a given base
+// stream that are not equal to their predecessor, so that each run of equal
+// elements is collapsed into its first element.
+type ConsecutiveDeduper[T any] struct {
+	base Stream[T]
+	eq   func(a, b T) bool
+	prev T
+	seen bool
+}
+
+func DedupeConsecutive[T comparable](s Stream[T]) Stream[T] {
+	return DedupeConsecutiveFunc(s, func(a, b T) bool { return a == b })
+}
+
+// DedupeConsecutiveFunc is as `DedupeConsecutive`, with equality as given by
+// `eq`.
+func DedupeConsecutiveFunc[T any](s Stream[T], eq func(a, b T) bool) Stream[T] {
+	return &ConsecutiveDeduper[T]{base: s, eq: eq}
+}
+
+func (s *ConsecutiveDeduper[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		dup := s.seen && s.eq(s.prev, v)
+		s.prev = v
+		s.seen = true
+		if dup {
+			return nil
+		}
+
+		return handled(h(v))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
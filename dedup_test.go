@@ -0,0 +1,95 @@
+package streams
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestShouldDedupPersistent(t *testing.T) {
+	s := DedupPersistent(NewFromSlice([]int{3, 1, 4, 1, 3}), strconv.Itoa, NewMemoryDedupStore())
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't DedupPersistent`)
+	}
+}
+
+func TestShouldDedupPersistentAcrossRuns(t *testing.T) {
+	path := t.TempDir() + "/seen"
+
+	store, err := OpenFileDedupStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c1, _ := Collect(DedupPersistent(NewFromSlice([]int{3, 1}), strconv.Itoa, store))
+	store.Close()
+
+	store, err = OpenFileDedupStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, _ := Collect(DedupPersistent(NewFromSlice([]int{1, 4, 3, 5}), strconv.Itoa, store))
+	store.Close()
+
+	if !reflect.DeepEqual(c1, []int{3, 1}) || !reflect.DeepEqual(c2, []int{4, 5}) {
+		t.Error(`Didn't DedupPersistent across runs`)
+	}
+}
+
+func TestShouldDedupPersistentNotMarkFailed(t *testing.T) {
+	store := NewMemoryDedupStore()
+	s := DedupPersistent(NewFromSlice([]int{3, 4}), strconv.Itoa, store)
+	s = Map(s, func(v int) (int, error) {
+		if v == 4 {
+			return 0, ErrStop
+		}
+		return v, nil
+	})
+	Collect(s)
+
+	seen3, _ := store.Seen("3")
+	seen4, _ := store.Seen("4")
+
+	if !seen3 || seen4 {
+		t.Error(`Didn't DedupPersistent not mark failed`)
+	}
+}
+
+func TestShouldDedupPersistentOnZeroValue(t *testing.T) {
+	s := &PersistentDeduper[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't DedupPersistent on zero value`)
+	}
+}
+
+func TestShouldDedupeConsecutive(t *testing.T) {
+	c, _ := Collect(DedupeConsecutive(NewFromSlice([]int{3, 3, 1, 3, 3, 3, 4, 4})))
+
+	if !reflect.DeepEqual(c, []int{3, 1, 3, 4}) {
+		t.Error(`Didn't DedupeConsecutive`)
+	}
+}
+
+func TestShouldDedupeConsecutiveFunc(t *testing.T) {
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.1 }
+
+	c, _ := Collect(DedupeConsecutiveFunc(NewFromSlice([]float64{1, 1.05, 1.12, 2, 2.01}), near))
+
+	if !reflect.DeepEqual(c, []float64{1, 2}) {
+		t.Error(`Didn't DedupeConsecutiveFunc`, c)
+	}
+}
+
+func TestShouldDedupeConsecutiveOnZeroValue(t *testing.T) {
+	eos, _, _ := (&ConsecutiveDeduper[int]{}).Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't DedupeConsecutive on zero value`)
+	}
+}
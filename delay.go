@@ -0,0 +1,207 @@
+package streams
+
+import "time"
+
+// A delayed is an element along with the time it came from the base stream.
+type delayed[T any] struct {
+	v  T
+	at time.Time
+}
+
+// A Delayer represents the stream of the elements of a given base stream,
+// each coming a given duration after it came from the base stream, so that
+// the pace of the base stream is kept. The elements that come in the
+// meantime are held.
+//
+// The base stream is resolved concurrently, in its own goroutine.
+type Delayer[T any] struct {
+	base  Stream[T]
+	d     time.Duration
+	clock Clock
+	in    <-chan resolution[T]
+	done  chan struct{}
+	// The base stream as left by its resolution, once stopped
+	rest    Stream[T]
+	timer   <-chan time.Time
+	pending []delayed[T]
+	eos     bool
+	acct    budgetShare
+}
+
+func Delay[T any](s Stream[T], d time.Duration, clock Clock) Stream[T] {
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	return &Delayer[T]{base: s, d: d, clock: clock}
+}
+
+func (s *Delayer[T]) stop() {
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
+	}
+}
+
+// release stops the resolution of the base stream, and releases the held
+// elements.
+func (s *Delayer[T]) release() {
+	s.stop()
+	s.pending = nil
+	s.acct.done()
+}
+
+// Close stops the resolution of the base stream, once the resolution in
+// progress, if any, is done, and releases the held elements, ending the
+// stream.
+func (s *Delayer[T]) Close() error {
+	s.release()
+	if s.in != nil {
+		for range s.in {
+		}
+		s.base, s.in = s.rest, nil
+	}
+	s.eos = true
+
+	return nil
+}
+
+// due reports whether the first held element is due by `now`.
+func (s *Delayer[T]) due(now time.Time) bool {
+	return len(s.pending) != 0 && !now.Before(s.pending[0].at.Add(s.d))
+}
+
+func (s *Delayer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if !s.due(s.clock.Now()) {
+		if s.eos && len(s.pending) == 0 {
+			return true, s, nil
+		}
+
+		if s.in == nil && !s.eos {
+			s.done = make(chan struct{})
+			s.in = pump(s.base, 0, s.done, &s.rest)
+		}
+
+		if s.timer == nil && len(s.pending) != 0 {
+			s.timer = s.clock.After(s.pending[0].at.Add(s.d).Sub(s.clock.Now()))
+		}
+
+		in := s.in
+		if s.eos {
+			in = nil
+		}
+
+		select {
+		case r := <-in:
+			if r.err != nil {
+				s.release()
+
+				return true, s, r.err
+			}
+
+			if r.eos {
+				s.eos = true
+				s.stop()
+			} else {
+				err := s.acct.reserve("Delay", sizeOf[delayed[T]](1))
+				if err != nil {
+					s.release()
+
+					return true, s, err
+				}
+
+				s.pending = append(s.pending, delayed[T]{v: r.v, at: s.clock.Now()})
+			}
+		case <-s.timer:
+			s.timer = nil
+		}
+
+		if !s.due(s.clock.Now()) {
+			return s.eos && len(s.pending) == 0, s, nil
+		}
+	}
+
+	v := s.pending[0].v
+	s.pending = s.pending[1:]
+	s.timer = nil
+	s.acct.release(sizeOf[delayed[T]](1))
+
+	err := handled(h(v))
+	if err != nil {
+		s.release()
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// A Lagger represents the stream of the elements of a given base stream,
+// shifted by a given number of positions, as when aligning a series with its
+// own history. The first elements are a given fill value, and the last
+// elements of the base stream are dropped, so that the length is kept.
+type Lagger[T any] struct {
+	base Stream[T]
+	n    int
+	fill T
+	ring []T
+	next int
+	acct budgetShare
+}
+
+func Lag[T any](s Stream[T], n int, fill T) Stream[T] {
+	return &Lagger[T]{base: s, n: n, fill: fill}
+}
+
+func (s *Lagger[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if s.n <= 0 {
+		eos, nxs, err := s.base.Resolve(h)
+		s.base = nxs
+		if err != nil {
+			return true, s, err
+		}
+
+		return eos, s, nil
+	}
+
+	if s.ring == nil {
+		err := s.acct.reserve("Lag", sizeOf[T](s.n))
+		if err != nil {
+			return true, s, err
+		}
+
+		s.ring = make([]T, s.n)
+		for i := range s.ring {
+			s.ring[i] = s.fill
+		}
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		u := s.ring[s.next]
+		s.ring[s.next] = v
+		s.next = (s.next + 1) % s.n
+
+		return handled(h(u))
+	})
+
+	s.base = nxs
+
+	if eos || err != nil {
+		s.ring = nil
+		s.acct.done()
+	}
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
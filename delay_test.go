@@ -0,0 +1,80 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShouldDelay(t *testing.T) {
+	clock := newManualClock(time.Unix(0, 0))
+	s := Delay(NewFromSlice([]int{1, 2, 3}), time.Minute, clock)
+
+	done := make(chan []int)
+	go func() {
+		c, _ := Collect(s)
+		done <- c
+	}()
+
+	select {
+	case <-done:
+		t.Error(`Didn't Delay`)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Minute)
+
+	if c := <-done; !reflect.DeepEqual(c, []int{1, 2, 3}) {
+		t.Error(`Didn't Delay`, c)
+	}
+}
+
+func TestShouldDelayInWallClockTime(t *testing.T) {
+	start := time.Now()
+
+	c, err := Collect(Delay(NewFromSlice([]int{1, 2}), 10*time.Millisecond, nil))
+
+	if err != nil || !reflect.DeepEqual(c, []int{1, 2}) || time.Since(start) < 10*time.Millisecond {
+		t.Error(`Didn't Delay in wall clock time`)
+	}
+}
+
+func TestShouldDelayErrorOnError(t *testing.T) {
+	s := Map(NewFromSlice([]int{1, 2}), func(v int) (int, error) {
+		if v == 2 {
+			return 0, errors.New("failed")
+		}
+		return v, nil
+	})
+
+	_, err := Collect(Delay(s, time.Millisecond, nil))
+
+	if err == nil {
+		t.Error(`Didn't Delay error on error`)
+	}
+}
+
+func TestShouldLag(t *testing.T) {
+	c, err := Collect(Lag(NewFromSlice([]int{1, 2, 3, 4, 5}), 2, 0))
+
+	if err != nil || !reflect.DeepEqual(c, []int{0, 0, 1, 2, 3}) {
+		t.Error(`Didn't Lag`, c)
+	}
+}
+
+func TestShouldLagShortStream(t *testing.T) {
+	c, err := Collect(Lag(NewFromSlice([]int{1}), 3, -1))
+
+	if err != nil || !reflect.DeepEqual(c, []int{-1}) {
+		t.Error(`Didn't Lag short stream`, c)
+	}
+}
+
+func TestShouldLagByNothing(t *testing.T) {
+	c, err := Collect(Lag(NewFromSlice([]int{1, 2}), 0, 0))
+
+	if err != nil || !reflect.DeepEqual(c, []int{1, 2}) {
+		t.Error(`Didn't Lag by nothing`, c)
+	}
+}
@@ -0,0 +1,240 @@
+package streams
+
+import (
+	"fmt"
+	"sync"
+)
+
+// defaultDemuxBuffer is the number of elements buffered for each branch of
+// a `Demux`.
+const defaultDemuxBuffer = 1024
+
+// A demux is the state shared by the branches of a demultiplexed stream:
+// the base stream, and the elements resolved from it that are yet to be
+// resolved from their branch.
+type demux[T any, K comparable] struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	base     Stream[T]
+	key      func(v T) K
+	queues   map[K][]T
+	buffer   int
+	overflow BufferPolicy
+	// Whether a branch is resolving the base stream
+	pulling bool
+	err     error
+	acct    budgetShare
+}
+
+// pull resolves the next element of the base stream into the queue of its
+// branch, with the lock held.
+func (d *demux[T, K]) pull() {
+	d.pulling = true
+	defer func() {
+		d.pulling = false
+		d.cond.Broadcast()
+	}()
+
+	eos, nxs, err := d.base.Resolve(func(v T) error {
+		k := d.key(v)
+		q, ok := d.queues[k]
+		if !ok {
+			return nil
+		}
+
+		for 0 < d.buffer && d.buffer <= len(q) {
+			switch d.overflow {
+			case BufferDropNewest:
+				return nil
+			case BufferDropOldest:
+				var zero T
+				q[0] = zero
+				q = q[1:]
+				d.acct.release(sizeOf[T](1))
+			case BufferError:
+				return internal(fmt.Errorf("%w: demux branch %v", ErrBufferOverflow, k))
+			default:
+				d.cond.Wait()
+				q = d.queues[k]
+			}
+		}
+
+		err := d.acct.reserve("Demux", sizeOf[T](1))
+		if err != nil {
+			return err
+		}
+
+		d.queues[k] = append(q, v)
+
+		return nil
+	})
+
+	d.base = nxs
+
+	if eos || err != nil {
+		d.base = nil
+		d.err = err
+	}
+}
+
+// A DemuxBranch represents the stream of the elements of a demultiplexed
+// stream with a given key. Each resolution of a branch resolves at most one
+// element of the base stream, which is buffered for its own branch, so that
+// branches may be resolved in turn, or concurrently. What a full buffer does
+// depends on its overflow policy, and waiting for room, with `BufferBlock`,
+// only makes sense for branches resolved concurrently.
+type DemuxBranch[T any, K comparable] struct {
+	d *demux[T, K]
+	k K
+}
+
+// Demux routes the elements of `s` into a stream for each of `keys`, by the
+// key of each element, as given by `key`. Elements with other keys are
+// dropped. Each branch buffers at most a fixed number of elements, past
+// which the branches fail with `ErrBufferOverflow`; see `DemuxBuffered`.
+func Demux[T any, K comparable](s Stream[T], key func(v T) K, keys []K) map[K]Stream[T] {
+	return DemuxBuffered(s, key, keys, defaultDemuxBuffer, BufferError)
+}
+
+// DemuxBuffered is as `Demux`, buffering at most `buffer` elements for each
+// branch, and handling further elements according to `overflow`.
+func DemuxBuffered[T any, K comparable](s Stream[T], key func(v T) K, keys []K, buffer int, overflow BufferPolicy) map[K]Stream[T] {
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	return demuxBranches(s, key, keys, buffer, overflow)
+}
+
+// demuxBranches demultiplexes `s` as `DemuxBuffered`, without bound on the
+// buffers if `buffer` is 0.
+func demuxBranches[T any, K comparable](s Stream[T], key func(v T) K, keys []K, buffer int, overflow BufferPolicy) map[K]Stream[T] {
+	d := &demux[T, K]{
+		base:     s,
+		key:      key,
+		queues:   make(map[K][]T, len(keys)),
+		buffer:   buffer,
+		overflow: overflow,
+	}
+	d.cond = sync.NewCond(&d.mu)
+
+	branches := make(map[K]Stream[T], len(keys))
+	for _, k := range keys {
+		d.queues[k] = nil
+		branches[k] = &DemuxBranch[T, K]{d: d, k: k}
+	}
+
+	return branches
+}
+
+func (s *DemuxBranch[T, K]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.d == nil {
+		return true, s, nil
+	}
+
+	d := s.d
+
+	d.mu.Lock()
+	for len(d.queues[s.k]) == 0 && d.base != nil && d.pulling {
+		d.cond.Wait()
+	}
+	if len(d.queues[s.k]) == 0 && d.base != nil {
+		d.pull()
+	}
+
+	q := d.queues[s.k]
+	if len(q) == 0 {
+		eos, err := d.base == nil, d.err
+		d.mu.Unlock()
+
+		if err != nil {
+			return true, s, err
+		}
+
+		return eos, s, nil
+	}
+
+	v := q[0]
+	var zero T
+	q[0] = zero
+	d.queues[s.k] = q[1:]
+	d.acct.release(sizeOf[T](1))
+	d.cond.Broadcast()
+	d.mu.Unlock()
+
+	err := handled(h(v))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// Partition splits the stream `s` into the streams of the elements that
+// satisfy `pred`, and of those that do not. Either stream may be resolved
+// independently of the other, so that the elements of the other are
+// buffered, without bound but that of the memory budget, if any, until
+// resolved.
+func Partition[T any](s Stream[T], pred func(v T) bool) (Stream[T], Stream[T]) {
+	branches := demuxBranches(s, pred, []bool{true, false}, 0, BufferError)
+
+	return branches[true], branches[false]
+}
+
+// A Tagged is an element along with the tag of the stream it comes from.
+type Tagged[K comparable, T any] struct {
+	Tag   K
+	Value T
+}
+
+// A Muxer represents the stream of the elements of given tagged streams,
+// resolved in turn, in the order of their tags, each element along with its
+// tag. It ends once all the streams have ended.
+type Muxer[T any, K comparable] struct {
+	tags []K
+	ss   []Stream[T]
+	next int
+}
+
+// Mux recombines the streams `ss`, such as the branches of a `Demux`, in the
+// order of `tags`. Streams without a tag are not resolved.
+func Mux[T any, K comparable](ss map[K]Stream[T], tags []K) Stream[Tagged[K, T]] {
+	m := &Muxer[T, K]{}
+	for _, k := range tags {
+		if s, ok := ss[k]; ok {
+			m.tags = append(m.tags, k)
+			m.ss = append(m.ss, s)
+		}
+	}
+
+	return m
+}
+
+func (s *Muxer[T, K]) Resolve(h func(v Tagged[K, T]) error) (bool, Stream[Tagged[K, T]], error) {
+	if s == nil || len(s.ss) == 0 {
+		return true, s, nil
+	}
+
+	i := s.next % len(s.ss)
+	k := s.tags[i]
+
+	eos, nxs, err := s.ss[i].Resolve(func(v T) error {
+		return handled(h(Tagged[K, T]{Tag: k, Value: v}))
+	})
+
+	s.ss[i] = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	if eos {
+		s.tags = append(s.tags[:i], s.tags[i+1:]...)
+		s.ss = append(s.ss[:i], s.ss[i+1:]...)
+		s.next = i
+	} else {
+		s.next = i + 1
+	}
+
+	return len(s.ss) == 0, s, nil
+}
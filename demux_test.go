@@ -0,0 +1,153 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func level(l string) string {
+	return l[:strings.Index(l, ":")]
+}
+
+func TestShouldDemux(t *testing.T) {
+	lines := []string{"info:a", "error:b", "info:c", "debug:d", "error:e"}
+	branches := Demux(NewFromSlice(lines), level, []string{"info", "error"})
+
+	errs, err := Collect(branches["error"])
+	if err != nil || !reflect.DeepEqual(errs, []string{"error:b", "error:e"}) {
+		t.Error(`Didn't Demux`, errs)
+	}
+
+	infos, err := Collect(branches["info"])
+	if err != nil || !reflect.DeepEqual(infos, []string{"info:a", "info:c"}) {
+		t.Error(`Didn't Demux`, infos)
+	}
+}
+
+func TestShouldDemuxConcurrently(t *testing.T) {
+	var lines []string
+	for i := 0; i < 1000; i++ {
+		lines = append(lines, []string{"info:x", "error:y"}[i%2])
+	}
+	branches := DemuxBuffered(NewFromSlice(lines), level, []string{"info", "error"}, 16, BufferBlock)
+
+	var wg sync.WaitGroup
+	counts := make(map[string]int)
+	var mu sync.Mutex
+	for k, s := range branches {
+		wg.Add(1)
+		go func(k string, s Stream[string]) {
+			defer wg.Done()
+			n, err := Count(s)
+			if err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			counts[k] = n
+			mu.Unlock()
+		}(k, s)
+	}
+	wg.Wait()
+
+	if counts["info"] != 500 || counts["error"] != 500 {
+		t.Error(`Didn't Demux concurrently`, counts)
+	}
+}
+
+func TestShouldDemuxErrorOnOverflow(t *testing.T) {
+	lines := []string{"error:a", "error:b", "error:c", "info:d"}
+	branches := DemuxBuffered(NewFromSlice(lines), level, []string{"info", "error"}, 2, BufferError)
+
+	_, err := Collect(branches["info"])
+
+	if !errors.Is(err, ErrBufferOverflow) {
+		t.Error(`Didn't Demux error on overflow`, err)
+	}
+}
+
+func TestShouldMuxDemuxed(t *testing.T) {
+	lines := []string{"info:a", "error:b", "info:c", "info:d", "error:e"}
+	tags := []string{"info", "error"}
+	branches := Demux(NewFromSlice(lines), level, tags)
+	branches["info"] = Map(branches["info"], func(l string) (string, error) { return strings.ToUpper(l), nil })
+
+	c, err := Collect(Mux(branches, tags))
+
+	want := []Tagged[string, string]{
+		{"info", "INFO:A"}, {"error", "error:b"}, {"info", "INFO:C"}, {"info", "INFO:D"}, {"error", "error:e"},
+	}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't Mux demuxed`, c)
+	}
+}
+
+func TestShouldMuxInTurn(t *testing.T) {
+	ss := map[int]Stream[int]{
+		1: NewFromSlice([]int{10, 11, 12}),
+		2: NewFromSlice([]int{20}),
+		3: NewFromSlice([]int{30, 31}),
+	}
+
+	c, err := Collect(Mux(ss, []int{1, 2, 3}))
+
+	var vs []int
+	for _, v := range c {
+		vs = append(vs, v.Value)
+	}
+	if err != nil || !reflect.DeepEqual(vs, []int{10, 20, 30, 11, 31, 12}) {
+		t.Error(`Didn't Mux in turn`, vs)
+	}
+}
+
+func TestShouldDemuxDropOldest(t *testing.T) {
+	lines := []string{"error:a", "error:b", "error:c", "info:d"}
+	branches := DemuxBuffered(NewFromSlice(lines), level, []string{"info", "error"}, 2, BufferDropOldest)
+
+	infos, _ := Collect(branches["info"])
+	errs, err := Collect(branches["error"])
+
+	if err != nil || !reflect.DeepEqual(infos, []string{"info:d"}) || !reflect.DeepEqual(errs, []string{"error:b", "error:c"}) {
+		t.Error(`Didn't Demux drop oldest`, infos, errs)
+	}
+}
+
+func TestShouldPartition(t *testing.T) {
+	var vs []int
+	for i := 0; i < 5000; i++ {
+		vs = append(vs, i)
+	}
+	even, odd := Partition(NewFromSlice(vs), func(v int) bool { return v%2 == 0 })
+
+	evens, err := Collect(even)
+	if err != nil || len(evens) != 2500 || evens[1] != 2 {
+		t.Error(`Didn't Partition`)
+	}
+
+	odds, err := Collect(odd)
+	if err != nil || len(odds) != 2500 || odds[1] != 3 {
+		t.Error(`Didn't Partition`)
+	}
+}
+
+func TestShouldPartitionErrorOnBothSides(t *testing.T) {
+	s := Map(NewFromSlice([]int{1, 2, 3}), func(v int) (int, error) {
+		if v == 3 {
+			return 0, errors.New("failed")
+		}
+		return v, nil
+	})
+	even, odd := Partition(s, func(v int) bool { return v%2 == 0 })
+
+	evens, err := Collect(even)
+	if err == nil || !reflect.DeepEqual(evens, []int{2}) {
+		t.Error(`Didn't Partition error on both sides`)
+	}
+
+	odds, err := Collect(odd)
+	if err == nil || !reflect.DeepEqual(odds, []int{1}) {
+		t.Error(`Didn't Partition error on both sides`)
+	}
+}
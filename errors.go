@@ -0,0 +1,94 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+)
+
+// An InternalError is an error that originates in the stream library, such
+// as a source failing to read its input, or an operator failing to hold its
+// elements, rather than in the processing of the elements.
+type InternalError struct {
+	Err error
+}
+
+func (e *InternalError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InternalError) Unwrap() error {
+	return e.Err
+}
+
+// A HandlerError is an error that originates in the processing of the
+// elements of a stream, by code given to the stream library: a handler, or
+// a function given to an operator or a source, such as the function of a
+// `Map`.
+type HandlerError struct {
+	Err error
+}
+
+func (e *HandlerError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// classified reports whether the error `err` needs no classification: it is
+// nil, `ErrStop`, or already an InternalError or a HandlerError.
+func classified(err error) bool {
+	if err == nil || errors.Is(err, ErrStop) {
+		return true
+	}
+
+	var ie *InternalError
+	var he *HandlerError
+
+	return errors.As(err, &ie) || errors.As(err, &he)
+}
+
+// internal is the error `err`, as an InternalError, unless classified.
+func internal(err error) error {
+	if classified(err) {
+		return err
+	}
+
+	return &InternalError{Err: err}
+}
+
+// handled is the error `err`, as a HandlerError, unless classified.
+func handled(err error) error {
+	if classified(err) {
+		return err
+	}
+
+	return &HandlerError{Err: err}
+}
+
+// A PositionError is the error of the named operator, such as "Map", on the
+// element at Index, numbered from 0, as on a malformed line of a large file.
+type PositionError struct {
+	Op    string
+	Index int
+	Err   error
+}
+
+func (e *PositionError) Error() string {
+	return fmt.Sprintf("streams: %s at element %d: %v", e.Op, e.Index, e.Err)
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// positioned is the error `err` of the operator `op` on the element at `i`,
+// as a PositionError, unless nil or `ErrStop`.
+func positioned(op string, i int, err error) error {
+	if err == nil || errors.Is(err, ErrStop) {
+		return err
+	}
+
+	return &PositionError{Op: op, Index: i, Err: err}
+}
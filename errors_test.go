@@ -0,0 +1,195 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestShouldClassifyHandlerError(t *testing.T) {
+	e := errors.New("error")
+	s := Filter(NewFromSlice([]int{3, 1, 4}), func(v int) bool { return true })
+
+	_, _, err := s.Resolve(func(v int) error { return e })
+
+	var he *HandlerError
+	if !errors.As(err, &he) || !errors.Is(err, e) || err.Error() != e.Error() {
+		t.Error(`Didn't classify handler error`)
+	}
+}
+
+func TestShouldClassifyMapError(t *testing.T) {
+	e := errors.New("error")
+	s := Map(NewFromSlice([]int{3, 1, 4}), func(v int) (int, error) {
+		if v == 4 {
+			return 0, e
+		}
+		return v, nil
+	})
+
+	c, err := Collect(Take(s, 3))
+
+	var he *HandlerError
+	var ie *InternalError
+	if !errors.As(err, &he) || errors.As(err, &ie) || !errors.Is(err, e) || len(c) != 2 {
+		t.Error(`Didn't classify Map error`)
+	}
+}
+
+func TestShouldClassifySourceError(t *testing.T) {
+	_, err := Collect(Map(NewStreamOfFileLines("testdata/missing"), func(v string) (string, error) { return v, nil }))
+
+	var ie *InternalError
+	var he *HandlerError
+	if !errors.As(err, &ie) || errors.As(err, &he) {
+		t.Error(`Didn't classify source error`)
+	}
+}
+
+func TestShouldClassifyOperatorError(t *testing.T) {
+	_, err := Collect(EnforceMonotonic(NewFromSlice([]int{3, 1}), MonotonicError))
+
+	var ie *InternalError
+	if !errors.As(err, &ie) || !errors.Is(err, ErrNotMonotonic) {
+		t.Error(`Didn't classify operator error`)
+	}
+}
+
+func TestShouldNotClassifyStop(t *testing.T) {
+	_, _, err := Map(NewFromSlice([]int{3}), func(v int) (int, error) { return v, nil }).Resolve(func(v int) error {
+		return ErrStop
+	})
+
+	if err != ErrStop {
+		t.Error(`Didn't not classify stop`)
+	}
+}
+
+func TestShouldNotClassifyTwice(t *testing.T) {
+	e := errors.New("error")
+	s := Filter(Map(Filter(NewFromSlice([]int{3}), func(v int) bool { return true }), func(v int) (int, error) { return v, nil }), func(v int) bool { return true })
+
+	_, _, err := s.Resolve(func(v int) error { return e })
+
+	he, ok := err.(*HandlerError)
+	if !ok || he.Err != e {
+		t.Error(`Didn't not classify twice`)
+	}
+}
+
+func TestShouldPositionMapError(t *testing.T) {
+	_, err := Collect(ParseInts(NewFromSlice([]string{"3", "1", "x", "4"})))
+
+	var pe *PositionError
+	var ie *InternalError
+	if !errors.As(err, &pe) || pe.Op != "Map" || pe.Index != 2 || !errors.As(err, &ie) {
+		t.Error(`Didn't position Map error`)
+	}
+	if !strings.HasPrefix(err.Error(), "streams: Map at element 2: ") {
+		t.Error(`Didn't position Map error message`)
+	}
+}
+
+func TestShouldPositionFusedMapError(t *testing.T) {
+	e := errors.New("error")
+	s := Fuse(Map(Filter(NewFromSlice([]int{3, 1, 4, 1}), func(v int) bool { return v != 1 }), func(v int) (int, error) {
+		if v == 4 {
+			return 0, e
+		}
+		return v, nil
+	}))
+
+	_, err := Collect(s)
+
+	var pe *PositionError
+	if !errors.As(err, &pe) || pe.Op != "Map" || pe.Index != 1 || !errors.Is(err, e) {
+		t.Error(`Didn't position fused Map error`)
+	}
+}
+
+func TestShouldPositionScanAndFoldErrors(t *testing.T) {
+	e := errors.New("error")
+	f := func(r, v int) (int, error) {
+		if v == 4 {
+			return r, e
+		}
+		return r + v, nil
+	}
+
+	_, serr := Collect(Scan(NewFromSlice([]int{3, 1, 4}), 0, f))
+	r, ferr := Fold(NewFromSlice([]int{3, 1, 4}), 0, f)
+
+	var spe, fpe *PositionError
+	if !errors.As(serr, &spe) || spe.Op != "Scan" || spe.Index != 2 {
+		t.Error(`Didn't position Scan error`)
+	}
+	if !errors.As(ferr, &fpe) || fpe.Op != "Fold" || fpe.Index != 2 || r != 4 {
+		t.Error(`Didn't position Fold error`)
+	}
+}
+
+func TestShouldNotPositionStop(t *testing.T) {
+	c, err := Collect(Map(NewFromSlice([]int{3, 1, 4}), func(v int) (int, error) {
+		if v == 4 {
+			return 0, ErrStop
+		}
+		return v, nil
+	}))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't not position stop`)
+	}
+}
+
+func TestShouldClassifySinkError(t *testing.T) {
+	e := errors.New("error")
+
+	_, err := SendAll[int](NewFromSlice([]int{3}), SinkFunc[int](func(v int) error { return e }))
+
+	var he *HandlerError
+	if !errors.As(err, &he) || !errors.Is(err, e) {
+		t.Error(`Didn't classify sink error`)
+	}
+}
+
+// A failingDedupStore fails to mark keys.
+type failingDedupStore struct {
+	MemoryDedupStore
+}
+
+func (d *failingDedupStore) Mark(key string) error {
+	return errors.New("error")
+}
+
+func TestShouldClassifyDedupStoreError(t *testing.T) {
+	f := &failingDedupStore{MemoryDedupStore: MemoryDedupStore{keys: map[string]struct{}{}}}
+
+	_, err := Collect(DedupPersistent(NewFromSlice([]string{"a"}), func(v string) string { return v }, f))
+
+	var ie *InternalError
+	if !errors.As(err, &ie) {
+		t.Error(`Didn't classify dedup store error`)
+	}
+}
+
+func TestShouldClassifyLogReadError(t *testing.T) {
+	_, err := Collect(ParseSyslog(iotest.ErrReader(errors.New("error"))))
+
+	var ie *InternalError
+	if !errors.As(err, &ie) {
+		t.Error(`Didn't classify log read error`)
+	}
+}
+
+func TestShouldNotPositionWrappedStop(t *testing.T) {
+	stop := fmt.Errorf("done: %w", ErrStop)
+
+	err := positioned("Map", 2, stop)
+
+	if err != stop {
+		t.Error(`Didn't not position wrapped stop`)
+	}
+}
@@ -0,0 +1,173 @@
+package streams
+
+// A fusedSource resolves the root stream of a chain of fused stages, with
+// the handler composed of the functions of the stages.
+type fusedSource interface {
+	resolve() (bool, error)
+	root() any
+}
+
+// A fuser is a stage that fuses into the handler of the stage downstream of
+// it, a handler composed once and for all.
+type fuser[T any] interface {
+	fuseInto(next func(v T) error) fusedSource
+}
+
+type fusedRoot[T any] struct {
+	s Stream[T]
+	h func(v T) error
+}
+
+func (r *fusedRoot[T]) resolve() (bool, error) {
+	if r.s == nil {
+		return true, nil
+	}
+
+	eos, nxs, err := r.s.Resolve(r.h)
+	r.s = nxs
+	if err != nil {
+		return true, err
+	}
+
+	return eos, nil
+}
+
+func (r *fusedRoot[T]) root() any {
+	return upstream(r.s)
+}
+
+// fuseInto fuses the stream `s` into the handler `h`, along with the stages
+// upstream of it.
+func fuseInto[T any](s Stream[T], h func(v T) error) fusedSource {
+	if f, ok := s.(fuser[T]); ok {
+		return f.fuseInto(h)
+	}
+
+	return &fusedRoot[T]{s: s, h: h}
+}
+
+func (s *Mapper[T, U]) fuseInto(next func(v U) error) fusedSource {
+	if s == nil {
+		return &fusedRoot[T]{}
+	}
+
+	f, i := s.f, s.i
+
+	return fuseInto(s.base, func(v T) error {
+		u, err := f(v)
+		i++
+		if err != nil {
+			return handled(positioned("Map", i-1, err))
+		}
+
+		return next(u)
+	})
+}
+
+func (s *IndexedMapper[T, U]) fuseInto(next func(v U) error) fusedSource {
+	if s == nil {
+		return &fusedRoot[T]{}
+	}
+
+	f, i := s.f, s.i
+
+	return fuseInto(s.base, func(v T) error {
+		u, err := f(i, v)
+		i++
+		if err != nil {
+			return handled(positioned("MapIndexed", i-1, err))
+		}
+
+		return next(u)
+	})
+}
+
+func (s *Filterer[T]) fuseInto(next func(v T) error) fusedSource {
+	if s == nil {
+		return &fusedRoot[T]{}
+	}
+
+	f := s.f
+
+	return fuseInto(s.base, func(v T) error {
+		if !f(v) {
+			return nil
+		}
+
+		return next(v)
+	})
+}
+
+func (s *IndexedFilterer[T]) fuseInto(next func(v T) error) fusedSource {
+	if s == nil {
+		return &fusedRoot[T]{}
+	}
+
+	f, i := s.f, s.i
+
+	return fuseInto(s.base, func(v T) error {
+		j := i
+		i++
+		if !f(j, v) {
+			return nil
+		}
+
+		return next(v)
+	})
+}
+
+func (s *Dropper[T]) fuseInto(next func(v T) error) fusedSource {
+	if s == nil {
+		return &fusedRoot[T]{}
+	}
+
+	n, c := s.n, s.c
+
+	return fuseInto(s.base, func(v T) error {
+		if c < n {
+			c++
+
+			return nil
+		}
+
+		return next(v)
+	})
+}
+
+// A Fused represents a chain of adjacent Map, MapIndexed, Filter,
+// FilterIndexed and Drop stages, fused into a single stage, whose handler
+// is composed of the functions of the stages. The fused chain resolves the
+// stream upstream of it directly, saving the resolution of each stage and
+// the allocation of its handler.
+type Fused[T any] struct {
+	src fusedSource
+	h   func(v T) error
+}
+
+// Fuse fuses the chain of fusable stages ending with the stream `s`, which
+// is returned as is if it is not such a stage. The fused stages are taken
+// over by the result, and must not be resolved anymore.
+func Fuse[T any](s Stream[T]) Stream[T] {
+	if _, ok := s.(fuser[T]); !ok {
+		return s
+	}
+
+	f := &Fused[T]{}
+	f.src = fuseInto(s, func(v T) error {
+		return handled(f.h(v))
+	})
+
+	return f
+}
+
+func (s *Fused[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.src == nil {
+		return true, s, nil
+	}
+
+	s.h = h
+	eos, err := s.src.resolve()
+	s.h = nil
+
+	return eos, s, err
+}
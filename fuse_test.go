@@ -0,0 +1,85 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func fusablePipeline() Stream[int] {
+	s := NewFromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6})
+	s = Drop(s, 1)
+	s = Filter(s, func(v int) bool { return v != 5 })
+	s = MapIndexed(s, func(i int, v int) (int, error) { return 10*i + v, nil })
+	s = FilterIndexed(s, func(i int, v int) bool { return i%2 == 0 })
+	return Map(s, func(v int) (int, error) { return v + 1, nil })
+}
+
+func TestShouldFuse(t *testing.T) {
+	want, _ := Collect(fusablePipeline())
+
+	s := Fuse(fusablePipeline())
+	c, _ := Collect(s)
+
+	if _, ok := s.(*Fused[int]); !ok || !reflect.DeepEqual(c, want) || !reflect.DeepEqual(c, []int{2, 22, 43}) {
+		t.Error(`Didn't Fuse`, c)
+	}
+}
+
+func TestShouldFuseUpToUnfusable(t *testing.T) {
+	ws := Windowed(NewFromSlice([]int{3, 1, 4, 1}), 2, 2)
+	s := Fuse(Map(ws, func(w Stream[int]) (int, error) { return Accumulate(w, 0, func(a, b int) int { return a + b }) }))
+
+	c, _ := Collect(s)
+
+	var stages []any
+	walk(s, func(u any) { stages = append(stages, u) })
+	if !reflect.DeepEqual(c, []int{4, 5, 5}) || len(stages) != 3 {
+		t.Error(`Didn't Fuse up to unfusable`)
+	}
+}
+
+func TestShouldFuseErrorOnError(t *testing.T) {
+	s := Map(NewFromSlice([]int{3, 1, 4}), func(v int) (int, error) {
+		if v == 4 {
+			return 0, errors.New("even")
+		}
+		return v, nil
+	})
+
+	c, err := Collect(Fuse(s))
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't Fuse error on error`)
+	}
+}
+
+func TestShouldFuseUnfusableAsIs(t *testing.T) {
+	s := NewFromSlice([]int{3})
+
+	if Fuse(s) != s {
+		t.Error(`Didn't Fuse unfusable as is`)
+	}
+}
+
+func BenchmarkFuse(b *testing.B) {
+	elems := make([]int, 1000)
+	pipeline := func() Stream[int] {
+		s := NewFromSlice(elems)
+		for i := 0; i < 8; i++ {
+			s = Map(s, func(v int) (int, error) { return v + 1, nil })
+		}
+		return s
+	}
+
+	b.Run("chained", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Count(pipeline())
+		}
+	})
+	b.Run("fused", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Count(Fuse(pipeline()))
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package streams
+
+import "golang.org/x/exp/constraints"
+
+// An Iterator represents the infinite stream of the successive applications
+// of a given function, starting from a given seed: the seed, then the
+// function applied to the seed, and so on.
+type Iterator[T any] struct {
+	v    T
+	next func(T) T
+	init bool
+}
+
+func Iterate[T any](seed T, next func(T) T) Stream[T] {
+	return &Iterator[T]{v: seed, next: next}
+}
+
+func (s *Iterator[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.next == nil {
+		return true, s, nil
+	}
+
+	if s.init {
+		s.v = s.next(s.v)
+	}
+	s.init = true
+
+	err := handled(h(s.v))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// An Unfolder represents the stream of the elements given by the successive
+// steps of a given function from a given initial state. Each step gives an
+// element along with the next state, until it tells that there are no more
+// elements, or fails.
+type Unfolder[S, T any] struct {
+	state S
+	step  func(S) (T, S, bool, error)
+}
+
+func Unfold[S, T any](state S, step func(S) (T, S, bool, error)) Stream[T] {
+	return &Unfolder[S, T]{state: state, step: step}
+}
+
+func (s *Unfolder[S, T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.step == nil {
+		return true, s, nil
+	}
+
+	v, state, ok, err := s.step(s.state)
+	if err != nil || !ok {
+		s.step = nil
+
+		return true, s, handled(err)
+	}
+
+	s.state = state
+
+	err = handled(h(v))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// A Repeater represents the stream of a given value, repeated a given number
+// of times, or forever.
+type Repeater[T any] struct {
+	v       T
+	n       int
+	bounded bool
+}
+
+// Repeat is the infinite stream of `v`.
+func Repeat[T any](v T) Stream[T] {
+	return &Repeater[T]{v: v}
+}
+
+// RepeatN is the stream of `v`, `n` times.
+func RepeatN[T any](v T, n int) Stream[T] {
+	return &Repeater[T]{v: v, n: n, bounded: true}
+}
+
+func (s *Repeater[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || (s.bounded && s.n <= 0) {
+		return true, s, nil
+	}
+
+	if s.bounded {
+		s.n--
+	}
+
+	err := handled(h(s.v))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// A Cycler represents the infinite stream of the elements of a given slice,
+// over and over. It is the empty stream if the slice is empty.
+type Cycler[T any] struct {
+	elems []T
+	next  int
+}
+
+func Cycle[T any](elems []T) Stream[T] {
+	return &Cycler[T]{elems: elems}
+}
+
+func (s *Cycler[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || len(s.elems) == 0 {
+		return true, s, nil
+	}
+
+	v := s.elems[s.next]
+	s.next = (s.next + 1) % len(s.elems)
+
+	err := handled(h(v))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// A Ranger represents the stream of the integers from a given start,
+// inclusive, to a given end, exclusive, by a given step, which may be
+// negative for a decreasing sequence. It is the empty stream if the step is
+// zero.
+type Ranger[T constraints.Integer] struct {
+	v, to, step T
+	done        bool
+}
+
+func Range[T constraints.Integer](from, to, step T) Stream[T] {
+	return &Ranger[T]{v: from, to: to, step: step}
+}
+
+func (s *Ranger[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.done || s.step == 0 || (0 < s.step && s.to <= s.v) || (s.step < 0 && s.v <= s.to) {
+		return true, s, nil
+	}
+
+	v := s.v
+	s.v += s.step
+	// Past the range of T, the step wraps around
+	if (0 < s.step && s.v < v) || (s.step < 0 && v < s.v) {
+		s.done = true
+	}
+
+	err := handled(h(v))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,140 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestShouldIterate(t *testing.T) {
+	c, _ := Collect(Take(Iterate(1, func(v int) int { return 2 * v }), 5))
+
+	if !reflect.DeepEqual(c, []int{1, 2, 4, 8, 16}) {
+		t.Error(`Didn't Iterate`)
+	}
+}
+
+func TestShouldIterateOnZeroValueAsEmptyStream(t *testing.T) {
+	eos, _, _ := (&Iterator[int]{}).Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't Iterate on zero value`)
+	}
+}
+
+func TestShouldUnfold(t *testing.T) {
+	fib := Unfold([2]int{0, 1}, func(s [2]int) (int, [2]int, bool, error) {
+		return s[0], [2]int{s[1], s[0] + s[1]}, s[0] < 20, nil
+	})
+
+	c, _ := Collect(fib)
+
+	if !reflect.DeepEqual(c, []int{0, 1, 1, 2, 3, 5, 8, 13}) {
+		t.Error(`Didn't Unfold`)
+	}
+}
+
+func TestShouldUnfoldErrorOnError(t *testing.T) {
+	s := Unfold(0, func(i int) (int, int, bool, error) {
+		if i == 2 {
+			return 0, 0, false, errors.New("failed")
+		}
+		return i, i + 1, true, nil
+	})
+
+	c, err := Collect(s)
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if err == nil || !reflect.DeepEqual(c, []int{0, 1}) || !eos {
+		t.Error(`Didn't Unfold error on error`)
+	}
+}
+
+func TestShouldRepeat(t *testing.T) {
+	c, _ := Collect(Take(Repeat("x"), 3))
+
+	if !reflect.DeepEqual(c, []string{"x", "x", "x"}) {
+		t.Error(`Didn't Repeat`)
+	}
+}
+
+func TestShouldRepeatN(t *testing.T) {
+	c, _ := Collect(RepeatN(7, 2))
+
+	if !reflect.DeepEqual(c, []int{7, 7}) {
+		t.Error(`Didn't RepeatN`)
+	}
+}
+
+func TestShouldRepeatNone(t *testing.T) {
+	c, _ := Collect(RepeatN(7, 0))
+
+	if len(c) != 0 {
+		t.Error(`Didn't RepeatN none`)
+	}
+}
+
+func TestShouldCycle(t *testing.T) {
+	c, _ := Collect(Take(Cycle([]int{3, 1, 4}), 7))
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4, 3, 1, 4, 3}) {
+		t.Error(`Didn't Cycle`)
+	}
+}
+
+func TestShouldCycleEmpty(t *testing.T) {
+	c, _ := Collect(Cycle([]int{}))
+
+	if len(c) != 0 {
+		t.Error(`Didn't Cycle empty`)
+	}
+}
+
+func TestShouldRange(t *testing.T) {
+	c, _ := Collect(Range(0, 10, 3))
+
+	if !reflect.DeepEqual(c, []int{0, 3, 6, 9}) {
+		t.Error(`Didn't Range`)
+	}
+}
+
+func TestShouldRangeDecreasing(t *testing.T) {
+	c, _ := Collect(Range(5, 0, -2))
+
+	if !reflect.DeepEqual(c, []int{5, 3, 1}) {
+		t.Error(`Didn't Range decreasing`)
+	}
+}
+
+func TestShouldRangeWithoutWrappingAround(t *testing.T) {
+	c, _ := Collect(Range[uint8](250, 255, 4))
+	d, _ := Collect(Range[int8](-120, -128, -5))
+
+	if !reflect.DeepEqual(c, []uint8{250, 254}) || !reflect.DeepEqual(d, []int8{-120, -125}) {
+		t.Error(`Didn't Range without wrapping around`, c, d)
+	}
+}
+
+func TestShouldRangeNoneOnZeroStep(t *testing.T) {
+	c, _ := Collect(Range(0, 10, 0))
+
+	if len(c) != 0 {
+		t.Error(`Didn't Range none on zero step`)
+	}
+}
+
+func TestShouldRangeWithoutAllocating(t *testing.T) {
+	s := &Ranger[int]{}
+	h := func(v int) error { return nil }
+
+	allocs := testing.AllocsPerRun(10, func() {
+		*s = Ranger[int]{v: 0, to: 1000, step: 1}
+		for eos := false; !eos; {
+			eos, _, _ = s.Resolve(h)
+		}
+	})
+
+	if allocs != 0 {
+		t.Error(`Didn't Range without allocating`)
+	}
+}
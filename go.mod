@@ -2,4 +2,14 @@ module github.com/jbelo/go-streams
 
 go 1.18
 
-require golang.org/x/exp v0.0.0-20220823124025-807a23277127
+require (
+	github.com/fsnotify/fsnotify v1.6.0
+	github.com/klauspost/compress v1.15.15
+	github.com/vmihailenco/msgpack/v5 v5.3.5
+	golang.org/x/exp v0.0.0-20220823124025-807a23277127
+)
+
+require (
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	golang.org/x/sys v0.0.0-20220908164124-27713097b956 // indirect
+)
@@ -0,0 +1,198 @@
+package streams
+
+// A RunGrouper represents the stream of the maximal runs of consecutive
+// elements of a given base stream that share the same key.
+type RunGrouper[T any, K comparable] struct {
+	base Stream[T]
+	key  func(v T) K
+	run  []T
+	k    K
+	acct budgetShare
+}
+
+func GroupRuns[T comparable](s Stream[T]) Stream[[]T] {
+	return GroupRunsBy(s, func(v T) T { return v })
+}
+
+func GroupRunsBy[T any, K comparable](s Stream[T], key func(v T) K) Stream[[]T] {
+	return &RunGrouper[T, K]{base: s, key: key}
+}
+
+func (s *RunGrouper[T, K]) Resolve(h func(v []T) error) (bool, Stream[[]T], error) {
+	if s == nil {
+		return true, nil, nil
+	}
+
+	if s.base == nil {
+		// The base stream is exhausted, only the last run remains
+		if len(s.run) == 0 {
+			return true, s, nil
+		}
+
+		run := s.run
+		s.run = nil
+		s.acct.done()
+
+		err := handled(h(run))
+		if err != nil {
+			return true, s, err
+		}
+
+		return false, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		k := s.key(v)
+		if len(s.run) != 0 && k != s.k {
+			run := s.run
+			s.run = []T{v}
+			s.k = k
+			s.acct.release(sizeOf[T](len(run) - 1))
+
+			return handled(h(run))
+		}
+
+		e := s.acct.reserve("GroupRuns", sizeOf[T](1))
+		if e != nil {
+			return e
+		}
+
+		s.run = append(s.run, v)
+		s.k = k
+
+		return nil
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		s.acct.done()
+
+		return true, s, err
+	}
+
+	if eos {
+		s.base = nil
+
+		return len(s.run) == 0, s, nil
+	}
+
+	return false, s, nil
+}
+
+// A Chunker represents the stream of the consecutive chunks of a given
+// number of elements of a given base stream. The last chunk may have fewer
+// elements, but is never empty. Chunks are taken at once from a base stream
+// backed by a slice, though still copied, so that each chunk may be kept.
+type Chunker[T any] struct {
+	base  Stream[T]
+	n     int
+	chunk []T
+	acct  budgetShare
+}
+
+func Chunk[T any](s Stream[T], n int) Stream[[]T] {
+	if n < 1 {
+		n = 1
+	}
+
+	return &Chunker[T]{base: s, n: n}
+}
+
+// chunkBacked takes the next chunk at once, if the base stream is backed by
+// a slice.
+func (s *Chunker[T]) chunkBacked() ([]T, bool) {
+	elems, b, ok := backingOf(s.base)
+	if !ok {
+		return nil, false
+	}
+
+	if len(elems) < s.n {
+		s.base = nil
+	} else {
+		elems = elems[:s.n]
+	}
+
+	chunk := make([]T, len(elems))
+	copy(chunk, elems)
+	b.advance(len(elems))
+
+	return chunk, true
+}
+
+func (s *Chunker[T]) Resolve(h func(v []T) error) (bool, Stream[[]T], error) {
+	if s == nil {
+		return true, s, nil
+	}
+
+	if s.base == nil {
+		// The base stream is exhausted, only the last chunk remains
+		if len(s.chunk) == 0 {
+			return true, s, nil
+		}
+
+		chunk := s.chunk
+		s.chunk = nil
+		s.acct.done()
+
+		err := handled(h(chunk))
+		if err != nil {
+			return true, s, err
+		}
+
+		return false, s, nil
+	}
+
+	if len(s.chunk) == 0 {
+		if chunk, ok := s.chunkBacked(); ok {
+			if len(chunk) == 0 {
+				return true, s, nil
+			}
+
+			err := handled(h(chunk))
+			if err != nil {
+				return true, s, err
+			}
+
+			return false, s, nil
+		}
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		e := s.acct.reserve("Chunk", sizeOf[T](1))
+		if e != nil {
+			return e
+		}
+
+		if s.chunk == nil {
+			s.chunk = make([]T, 0, s.n)
+		}
+
+		s.chunk = append(s.chunk, v)
+		if len(s.chunk) < s.n {
+			return nil
+		}
+
+		chunk := s.chunk
+		s.chunk = nil
+		s.acct.release(sizeOf[T](len(chunk)))
+
+		return handled(h(chunk))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		s.acct.done()
+
+		return true, s, err
+	}
+
+	if eos {
+		s.base = nil
+
+		return len(s.chunk) == 0, s, nil
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,89 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShouldGroupRuns(t *testing.T) {
+	s := NewFromSlice([]int{3, 3, 1, 4, 4, 4, 3})
+	ss := GroupRuns(s)
+
+	c, _ := Collect(ss)
+
+	if !reflect.DeepEqual(c, [][]int{{3, 3}, {1}, {4, 4, 4}, {3}}) {
+		t.Error(`Didn't GroupRuns`)
+	}
+}
+
+func TestShouldGroupRunsBy(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 6, 5})
+	ss := GroupRunsBy(s, func(v int) bool { return v%2 == 0 })
+
+	c, _ := Collect(ss)
+
+	if !reflect.DeepEqual(c, [][]int{{3, 1}, {4, 6}, {5}}) {
+		t.Error(`Didn't GroupRunsBy`)
+	}
+}
+
+func TestShouldGroupRunsOnEmpty(t *testing.T) {
+	s := NewFromSlice([]int{})
+	ss := GroupRuns(s)
+
+	c, _ := Collect(ss)
+
+	if len(c) != 0 {
+		t.Error(`Didn't GroupRuns on empty`)
+	}
+}
+
+func TestShouldGroupRunsOnZeroValue(t *testing.T) {
+	s := &RunGrouper[int, int]{}
+
+	eos, _, _ := s.Resolve(func(v []int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't GroupRuns on zero value`)
+	}
+}
+
+func TestShouldChunk(t *testing.T) {
+	s := Map(NewFromSlice([]int{3, 1, 4, 1, 5}), func(v int) (int, error) { return v, nil })
+
+	c, err := Collect(Chunk(s, 2))
+
+	if err != nil || !reflect.DeepEqual(c, [][]int{{3, 1}, {4, 1}, {5}}) {
+		t.Error(`Didn't Chunk`)
+	}
+}
+
+func TestShouldChunkSliceBacked(t *testing.T) {
+	elems := []int{3, 1, 4, 1, 5, 9}
+
+	c, err := Collect(Chunk(Drop(NewFromSlice(elems), 1), 3))
+	c[0][0] = 0
+
+	if err != nil || !reflect.DeepEqual(c, [][]int{{0, 4, 1}, {5, 9}}) || elems[1] != 1 {
+		t.Error(`Didn't Chunk slice backed`)
+	}
+}
+
+func TestShouldChunkExactly(t *testing.T) {
+	c, err := Collect(Chunk(NewFromSlice([]int{3, 1, 4, 1}), 2))
+
+	if err != nil || !reflect.DeepEqual(c, [][]int{{3, 1}, {4, 1}}) {
+		t.Error(`Didn't Chunk exactly`)
+	}
+}
+
+func TestShouldChunkWithinBudget(t *testing.T) {
+	budget := NewMemoryBudget(sizeOf[int](2))
+	s := Map(NewFromSlice([]int{3, 1, 4, 1, 5}), func(v int) (int, error) { return v, nil })
+
+	_, err := Collect(WithMemoryBudget(Chunk(s, 3), budget))
+
+	if err == nil || budget.Used() != 0 {
+		t.Error(`Didn't Chunk within budget`)
+	}
+}
@@ -0,0 +1,100 @@
+package streams
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrBufferOverflow is the error of a full buffer with the BufferError
+// policy.
+var ErrBufferOverflow = errors.New("streams: buffer overflow")
+
+// A BufferPolicy determines what a full buffer does with further elements.
+type BufferPolicy int
+
+const (
+	// BufferBlock waits for room in the buffer.
+	BufferBlock BufferPolicy = iota
+	// BufferDropNewest drops the element.
+	BufferDropNewest
+	// BufferDropOldest drops the oldest buffered element to make room.
+	BufferDropOldest
+	// BufferError fails with `ErrBufferOverflow`.
+	BufferError
+)
+
+// An inbox buffers the elements pushed by a producer, such as the callback
+// of a client library, until they are received from the channel `c`.
+type inbox[T any] struct {
+	c        chan T
+	policy   BufferPolicy
+	mu       sync.Mutex
+	dropped  int64
+	overflow chan struct{}
+	once     sync.Once
+	// Closed once the consumer is gone, which unblocks the producers
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func newInbox[T any](n int, policy BufferPolicy) *inbox[T] {
+	if n < 1 {
+		n = 1
+	}
+
+	return &inbox[T]{
+		c:        make(chan T, n),
+		policy:   policy,
+		overflow: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (b *inbox[T]) push(v T) {
+	switch b.policy {
+	case BufferDropNewest:
+		select {
+		case b.c <- v:
+		default:
+			atomic.AddInt64(&b.dropped, 1)
+		}
+	case BufferDropOldest:
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		for {
+			select {
+			case b.c <- v:
+				return
+			default:
+			}
+
+			select {
+			case <-b.c:
+				atomic.AddInt64(&b.dropped, 1)
+			default:
+			}
+		}
+	case BufferError:
+		select {
+		case b.c <- v:
+		default:
+			b.once.Do(func() { close(b.overflow) })
+		}
+	default:
+		select {
+		case b.c <- v:
+		case <-b.done:
+		}
+	}
+}
+
+// Dropped is the number of elements dropped so far.
+func (b *inbox[T]) Dropped() int64 {
+	return atomic.LoadInt64(&b.dropped)
+}
+
+func (b *inbox[T]) close() {
+	b.doneOnce.Do(func() { close(b.done) })
+}
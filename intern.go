@@ -0,0 +1,68 @@
+package streams
+
+import "sync"
+
+// An Interner deduplicates strings through a table of the distinct strings
+// seen, so that equal strings share their memory. Once the table has a
+// given maximum number of strings, further strings are not interned. An
+// Interner may be shared by concurrent sources.
+type Interner struct {
+	mu  sync.Mutex
+	m   map[string]string
+	max int
+}
+
+// NewInterner is an Interner of at most `max` strings, or without maximum
+// if `max` is 0.
+func NewInterner(max int) *Interner {
+	return &Interner{m: make(map[string]string), max: max}
+}
+
+func (in *Interner) Intern(s string) string {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if i, ok := in.m[s]; ok {
+		return i
+	}
+
+	if in.max == 0 || len(in.m) < in.max {
+		in.m[s] = s
+	}
+
+	return s
+}
+
+// InternBytes is the interned string of `b`, which is only copied if not
+// interned already.
+func (in *Interner) InternBytes(b []byte) string {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if i, ok := in.m[string(b)]; ok {
+		return i
+	}
+
+	s := string(b)
+	if in.max == 0 || len(in.m) < in.max {
+		in.m[s] = s
+	}
+
+	return s
+}
+
+// Len is the number of strings interned.
+func (in *Interner) Len() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	return len(in.m)
+}
+
+// InternStrings is the stream of the strings of the stream `s`, interned by
+// `in`.
+func InternStrings(s Stream[string], in *Interner) Stream[string] {
+	return Map(s, func(v string) (string, error) {
+		return in.Intern(v), nil
+	})
+}
@@ -0,0 +1,46 @@
+package streams
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+func stringData(s string) uintptr {
+	return (*[2]uintptr)(unsafe.Pointer(&s))[0]
+}
+
+func TestShouldInternStrings(t *testing.T) {
+	in := NewInterner(0)
+	a := string([]byte("info"))
+	b := string([]byte("info"))
+
+	c, _ := Collect(InternStrings(NewFromSlice([]string{a, "warn", b}), in))
+
+	if !reflect.DeepEqual(c, []string{"info", "warn", "info"}) || stringData(c[0]) != stringData(c[2]) || in.Len() != 2 {
+		t.Error(`Didn't InternStrings`)
+	}
+}
+
+func TestShouldInternUpToMax(t *testing.T) {
+	in := NewInterner(1)
+	in.Intern("info")
+	in.InternBytes([]byte("warn"))
+
+	if in.Len() != 1 || in.InternBytes([]byte("warn")) != "warn" {
+		t.Error(`Didn't Intern up to max`)
+	}
+}
+
+func TestShouldInternFileLines(t *testing.T) {
+	filename := t.TempDir() + "/input"
+	os.WriteFile(filename, []byte("info\nwarn\ninfo\n"), 0o644)
+
+	in := NewInterner(0)
+	c, _ := Collect(NewStreamOfFileLinesInterned(filename, in))
+
+	if !reflect.DeepEqual(c, []string{"info", "warn", "info"}) || stringData(c[0]) != stringData(c[2]) {
+		t.Error(`Didn't Intern file lines`)
+	}
+}
@@ -0,0 +1,166 @@
+package streams
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ErrJournalFormat is the error of malformed journal export data.
+var ErrJournalFormat = errors.New("streams: malformed journal export")
+
+// A JournalField is a field of a journal entry. Values may be binary.
+type JournalField struct {
+	Name  string
+	Value []byte
+}
+
+// A JournalEntry is an entry of the systemd journal, with its fields in the
+// order of the export. A field name may occur more than once.
+type JournalEntry struct {
+	Fields []JournalField
+}
+
+// Get is the first value of the named field, and whether there is one.
+func (e JournalEntry) Get(name string) (string, bool) {
+	for _, f := range e.Fields {
+		if f.Name == name {
+			return string(f.Value), true
+		}
+	}
+
+	return "", false
+}
+
+// Message is the MESSAGE field.
+func (e JournalEntry) Message() string {
+	m, _ := e.Get("MESSAGE")
+
+	return m
+}
+
+// Cursor is the __CURSOR field.
+func (e JournalEntry) Cursor() string {
+	c, _ := e.Get("__CURSOR")
+
+	return c
+}
+
+// RealTime is the time of the __REALTIME_TIMESTAMP field, or the zero time.
+func (e JournalEntry) RealTime() time.Time {
+	ts, ok := e.Get("__REALTIME_TIMESTAMP")
+	if !ok {
+		return time.Time{}
+	}
+
+	us, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.UnixMicro(us)
+}
+
+// A JournalReader represents the stream of the journal entries read from
+// the journal export format, as of `journalctl -o export`.
+type JournalReader struct {
+	in *bufio.Reader
+	// The number of entries read, and of bytes consumed by them
+	index, offset int64
+}
+
+func ParseJournalExport(r io.Reader) Stream[JournalEntry] {
+	return &JournalReader{in: bufio.NewReader(r)}
+}
+
+// readField reads the next field, or returns a nil field at the blank line
+// ending an entry.
+func (s *JournalReader) readField() (*JournalField, error) {
+	line, err := s.in.ReadBytes('\n')
+	s.offset += int64(len(line))
+	if err == io.EOF && len(line) != 0 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	if len(line) == 0 {
+		return nil, nil
+	}
+
+	if i := bytes.IndexByte(line, '='); i >= 0 {
+		return &JournalField{Name: string(line[:i]), Value: line[i+1:]}, nil
+	}
+
+	// A binary field, of a little endian 64 bit size, the data and a newline
+	var size [8]byte
+	n8, err := io.ReadFull(s.in, size[:])
+	s.offset += int64(n8)
+	if err != nil {
+		return nil, fmt.Errorf("%w: truncated field %s", ErrJournalFormat, line)
+	}
+
+	n := binary.LittleEndian.Uint64(size[:])
+	if 1<<30 < n {
+		return nil, fmt.Errorf("%w: field %s too large", ErrJournalFormat, line)
+	}
+
+	value := make([]byte, n+1)
+	nv, err := io.ReadFull(s.in, value)
+	s.offset += int64(nv)
+	if err != nil || value[n] != '\n' {
+		return nil, fmt.Errorf("%w: truncated field %s", ErrJournalFormat, line)
+	}
+
+	return &JournalField{Name: string(line), Value: value[:n]}, nil
+}
+
+func (s *JournalReader) Resolve(h func(v JournalEntry) error) (bool, Stream[JournalEntry], error) {
+	if s == nil || s.in == nil {
+		return true, s, nil
+	}
+
+	var e JournalEntry
+	for {
+		f, err := s.readField()
+		if err == io.EOF {
+			if len(e.Fields) == 0 {
+				return true, s, nil
+			}
+			break
+		}
+		if err != nil {
+			return true, s, internal(err)
+		}
+		if f == nil {
+			if len(e.Fields) == 0 {
+				continue
+			}
+			break
+		}
+
+		e.Fields = append(e.Fields, *f)
+	}
+
+	s.index++
+
+	err := handled(h(e))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// Position is that of the last entry read, with the offset of the export
+// data after it.
+func (s *JournalReader) Position() Position {
+	return Position{Index: s.index, Offset: s.offset}
+}
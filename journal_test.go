@@ -0,0 +1,63 @@
+package streams
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func binaryJournalField(name, value string) string {
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(value)))
+	return name + "\n" + string(size[:]) + value + "\n"
+}
+
+func TestShouldParseJournalExport(t *testing.T) {
+	in := "__CURSOR=s=1\n__REALTIME_TIMESTAMP=1000000\nMESSAGE=started\n\n" +
+		"__CURSOR=s=2\n" + binaryJournalField("MESSAGE", "two\nlines") + "_PID=1\n"
+
+	c, err := Collect(ParseJournalExport(strings.NewReader(in)))
+
+	want := []JournalEntry{
+		{Fields: []JournalField{
+			{"__CURSOR", []byte("s=1")},
+			{"__REALTIME_TIMESTAMP", []byte("1000000")},
+			{"MESSAGE", []byte("started")},
+		}},
+		{Fields: []JournalField{
+			{"__CURSOR", []byte("s=2")},
+			{"MESSAGE", []byte("two\nlines")},
+			{"_PID", []byte("1")},
+		}},
+	}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't ParseJournalExport`)
+	}
+	if c[1].Message() != "two\nlines" || c[1].Cursor() != "s=2" || !c[0].RealTime().Equal(time.Unix(1, 0)) {
+		t.Error(`Didn't ParseJournalExport fields`)
+	}
+}
+
+func TestShouldParseJournalExportErrorOnTruncated(t *testing.T) {
+	in := "MESSAGE=ok\n\n" + binaryJournalField("MESSAGE", "cut")[:12]
+
+	c, err := Collect(ParseJournalExport(strings.NewReader(in)))
+
+	if !errors.Is(err, ErrJournalFormat) || len(c) != 1 {
+		t.Error(`Didn't ParseJournalExport error on truncated`)
+	}
+}
+
+func TestShouldParseJournalExportOnZeroValue(t *testing.T) {
+	var s *JournalReader
+
+	eos, _, err := s.Resolve(func(v JournalEntry) error { return nil })
+	zeos, _, zerr := (&JournalReader{}).Resolve(func(v JournalEntry) error { return nil })
+
+	if !eos || err != nil || !zeos || zerr != nil {
+		t.Error(`Didn't ParseJournalExport on zero value`)
+	}
+}
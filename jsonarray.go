@@ -0,0 +1,101 @@
+package streams
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A JSONArrayDecoder represents the stream of the elements of the top level
+// JSON array read from a given reader, each decoded into a value of type T,
+// one at a time, so that the array is never held in memory. An empty input
+// is the empty stream.
+type JSONArrayDecoder[T any] struct {
+	dec    *json.Decoder
+	opened bool
+}
+
+func NewJSONArrayStream[T any](r io.Reader) Stream[T] {
+	return &JSONArrayDecoder[T]{dec: json.NewDecoder(r)}
+}
+
+// open reads the opening bracket of the array.
+func (s *JSONArrayDecoder[T]) open() error {
+	tok, err := s.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("streams: json array: %v at offset %d, not an array", tok, s.dec.InputOffset())
+	}
+
+	s.opened = true
+
+	return nil
+}
+
+// close reads the closing bracket of the array, which must end the input.
+func (s *JSONArrayDecoder[T]) close() error {
+	_, err := s.dec.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return err
+	}
+
+	_, err = s.dec.Token()
+	if !errors.Is(err, io.EOF) {
+		return fmt.Errorf("streams: json array: data after the array at offset %d", s.dec.InputOffset())
+	}
+
+	return nil
+}
+
+func (s *JSONArrayDecoder[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.dec == nil {
+		return true, s, nil
+	}
+
+	if !s.opened {
+		err := s.open()
+		if err != nil {
+			s.dec = nil
+			if errors.Is(err, io.EOF) {
+				return true, s, nil
+			}
+
+			return true, s, internal(err)
+		}
+	}
+
+	if !s.dec.More() {
+		err := s.close()
+		s.dec = nil
+
+		return true, s, internal(err)
+	}
+
+	var v T
+	err := s.dec.Decode(&v)
+	if err != nil {
+		s.dec = nil
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return true, s, internal(err)
+	}
+
+	err = handled(h(v))
+	if err != nil {
+		s.dec = nil
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
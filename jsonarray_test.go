@@ -0,0 +1,46 @@
+package streams
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShouldStreamJSONArray(t *testing.T) {
+	r := strings.NewReader(` [ {"level":"info","n":3}, {"level":"error","n":1} ] `)
+
+	c, err := Collect(NewJSONArrayStream[jsonEvent](r))
+
+	if err != nil || !reflect.DeepEqual(c, []jsonEvent{{"info", 3}, {"error", 1}}) {
+		t.Error(`Didn't stream JSON array`, err)
+	}
+}
+
+func TestShouldStreamJSONArrayEmpty(t *testing.T) {
+	c, err := Collect(NewJSONArrayStream[int](strings.NewReader("[]")))
+	d, err2 := Collect(NewJSONArrayStream[int](strings.NewReader("")))
+
+	if err != nil || len(c) != 0 || err2 != nil || len(d) != 0 {
+		t.Error(`Didn't stream JSON array empty`)
+	}
+}
+
+func TestShouldStreamJSONArrayLazily(t *testing.T) {
+	r := strings.NewReader(`[3, 1, "x"]`)
+
+	c, _, err := CollectN(NewJSONArrayStream[int](r), 2)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't stream JSON array lazily`)
+	}
+}
+
+func TestShouldStreamJSONArrayErrorOnBadInput(t *testing.T) {
+	for _, in := range []string{`{"n":3}`, `[3, 1`, `[3, "x"]`, `[3] [4]`} {
+		_, err := Collect(NewJSONArrayStream[int](strings.NewReader(in)))
+
+		if err == nil {
+			t.Error(`Didn't stream JSON array error on bad input`, in)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package streams
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A JSONLinesDecoder represents the stream of the JSON values of the lines
+// read from a given reader, as in JSON Lines, or NDJSON, each decoded into a
+// value of type T. Blank lines are skipped, and lines may be of any length.
+// A malformed line fails the resolution of its own line only: the stream
+// may be resolved further, from the next line.
+type JSONLinesDecoder[T any] struct {
+	r    *bufio.Reader
+	line int
+}
+
+func NewJSONLinesStream[T any](r io.Reader) Stream[T] {
+	return &JSONLinesDecoder[T]{r: bufio.NewReader(r)}
+}
+
+func (s *JSONLinesDecoder[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.r == nil {
+		return true, s, nil
+	}
+
+	line, err := s.r.ReadBytes('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && len(line) != 0) {
+		s.r = nil
+		if errors.Is(err, io.EOF) {
+			return true, s, nil
+		}
+
+		return true, s, internal(err)
+	}
+	s.line++
+
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return false, s, nil
+	}
+
+	var v T
+	err = json.Unmarshal(line, &v)
+	if err != nil {
+		return true, s, internal(fmt.Errorf("streams: json line %d: %w", s.line, err))
+	}
+
+	err = handled(h(v))
+	if err != nil {
+		s.r = nil
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// WriteJSONLines writes each element of the stream `s` to `w`, as a line of
+// JSON. Writes are buffered, and flushed before returning, even on error.
+func WriteJSONLines[T any](s Stream[T], w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	_, err := SendAll[T](s, SinkFunc[T](func(v T) error { return enc.Encode(v) }))
+
+	ferr := bw.Flush()
+	if err == nil {
+		err = ferr
+	}
+
+	return err
+}
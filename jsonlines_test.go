@@ -0,0 +1,59 @@
+package streams
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type jsonEvent struct {
+	Level string `json:"level"`
+	N     int    `json:"n"`
+}
+
+func TestShouldStreamJSONLines(t *testing.T) {
+	r := strings.NewReader("{\"level\":\"info\",\"n\":3}\n\n  {\"level\":\"error\",\"n\":1}\r\n{\"n\":4}")
+
+	c, err := Collect(NewJSONLinesStream[jsonEvent](r))
+
+	want := []jsonEvent{{"info", 3}, {"error", 1}, {"", 4}}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't stream JSON lines`, c, err)
+	}
+}
+
+func TestShouldStreamJSONLinesErrorOnBadLine(t *testing.T) {
+	r := strings.NewReader("{\"n\":3}\n{\"n\":\"x\"}\n{\"n\":4}\n")
+
+	c, err := Collect(NewJSONLinesStream[jsonEvent](r))
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || len(c) != 1 || !strings.Contains(err.Error(), "line 2") {
+		t.Error(`Didn't stream JSON lines error on bad line`, err)
+	}
+}
+
+func TestShouldStreamJSONLinesAfterBadLine(t *testing.T) {
+	r := strings.NewReader("{\"n\":3}\nbad\n{\"n\":4}\n")
+	var errs []error
+
+	c, err := Collect(SkipErrors(NewJSONLinesStream[jsonEvent](r), func(err error) { errs = append(errs, err) }))
+
+	if err != nil || !reflect.DeepEqual(c, []jsonEvent{{N: 3}, {N: 4}}) || len(errs) != 1 {
+		t.Error(`Didn't stream JSON lines after bad line`)
+	}
+}
+
+func TestShouldWriteJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteJSONLines(NewFromSlice([]jsonEvent{{"info", 3}, {"error", 1}}), &buf)
+	c, _ := Collect(NewJSONLinesStream[jsonEvent](&buf))
+
+	if err != nil || !reflect.DeepEqual(c, []jsonEvent{{"info", 3}, {"error", 1}}) {
+		t.Error(`Didn't WriteJSONLines`)
+	}
+}
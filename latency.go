@@ -0,0 +1,216 @@
+package streams
+
+import (
+	"math/bits"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A LatencyRecorder records latencies for named stages of a pipeline.
+type LatencyRecorder interface {
+	Record(stage string, d time.Duration)
+}
+
+// A Timer represents the stream of the elements of a given base stream,
+// which records for each element the time taken by its handling downstream.
+// Since the handling of an element includes that of every stage downstream,
+// the cost of a stage is the difference between the latencies recorded
+// before and after it.
+type Timer[T any] struct {
+	base Stream[T]
+	name string
+	rec  LatencyRecorder
+}
+
+func Timed[T any](s Stream[T], name string, rec LatencyRecorder) Stream[T] {
+	return &Timer[T]{base: s, name: name, rec: rec}
+}
+
+func (s *Timer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		start := time.Now()
+		err := handled(h(v))
+		s.rec.Record(s.name, time.Since(start))
+
+		return err
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+// The buckets of a Histogram are exact below 2*histogramSub nanoseconds, and
+// each power of two above is split into histogramSub buckets, so that the
+// relative error of a bucket is below 1/histogramSub.
+const (
+	histogramSubBits = 5
+	histogramSub     = 1 << histogramSubBits
+)
+
+func histogramBucket(v uint64) int {
+	if v < 2*histogramSub {
+		return int(v)
+	}
+
+	shift := bits.Len64(v) - (histogramSubBits + 1)
+
+	return (shift+1)*histogramSub + int(v>>shift) - histogramSub
+}
+
+// histogramValue is the value at the middle of the given bucket.
+func histogramValue(i int) uint64 {
+	if i < 2*histogramSub {
+		return uint64(i)
+	}
+
+	shift := i/histogramSub - 1
+	m := uint64(i%histogramSub + histogramSub)
+
+	return m<<shift + (uint64(1)<<shift)/2
+}
+
+// A Histogram counts latencies in buckets of bounded relative error, in the
+// manner of HDR histograms.
+type Histogram struct {
+	counts   []int64
+	count    int64
+	sum      time.Duration
+	min, max time.Duration
+}
+
+func (g *Histogram) record(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+
+	i := histogramBucket(uint64(d))
+	if len(g.counts) <= i {
+		g.counts = append(g.counts, make([]int64, i+1-len(g.counts))...)
+	}
+	g.counts[i]++
+
+	if g.count == 0 || d < g.min {
+		g.min = d
+	}
+	if g.max < d {
+		g.max = d
+	}
+	g.count++
+	g.sum += d
+}
+
+func (g *Histogram) Count() int64 {
+	return g.count
+}
+
+func (g *Histogram) Min() time.Duration {
+	return g.min
+}
+
+func (g *Histogram) Max() time.Duration {
+	return g.max
+}
+
+func (g *Histogram) Mean() time.Duration {
+	if g.count == 0 {
+		return 0
+	}
+
+	return g.sum / time.Duration(g.count)
+}
+
+// Quantile is the latency below which the fraction `q` of the latencies
+// fall, within the relative error of the buckets.
+func (g *Histogram) Quantile(q float64) time.Duration {
+	if g.count == 0 {
+		return 0
+	}
+
+	rank := int64(q*float64(g.count) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+
+	var n int64
+	for i, c := range g.counts {
+		n += c
+		if rank <= n {
+			d := time.Duration(histogramValue(i))
+			if d < g.min {
+				return g.min
+			}
+			if g.max < d {
+				return g.max
+			}
+
+			return d
+		}
+	}
+
+	return g.max
+}
+
+// A LatencyHistograms is a LatencyRecorder keeping a Histogram per stage.
+// It may be shared by concurrent stages.
+type LatencyHistograms struct {
+	mu     sync.Mutex
+	stages map[string]*Histogram
+}
+
+func NewLatencyHistograms() *LatencyHistograms {
+	return &LatencyHistograms{stages: make(map[string]*Histogram)}
+}
+
+func (l *LatencyHistograms) Record(stage string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	g, ok := l.stages[stage]
+	if !ok {
+		g = &Histogram{}
+		l.stages[stage] = g
+	}
+
+	g.record(d)
+}
+
+// Stages are the names of the stages with latencies recorded, sorted.
+func (l *LatencyHistograms) Stages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	names := make([]string, 0, len(l.stages))
+	for name := range l.stages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Stage is a copy of the histogram of the given stage, which is empty if no
+// latencies were recorded for it.
+func (l *LatencyHistograms) Stage(name string) Histogram {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	g, ok := l.stages[name]
+	if !ok {
+		return Histogram{}
+	}
+
+	c := *g
+	c.counts = append([]int64(nil), g.counts...)
+
+	return c
+}
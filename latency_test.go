@@ -0,0 +1,55 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShouldTimed(t *testing.T) {
+	rec := NewLatencyHistograms()
+	s := Timed(NewFromSlice([]int{3, 1, 4}), "sleep", rec)
+	s = Map(s, func(v int) (int, error) {
+		time.Sleep(time.Duration(v) * time.Millisecond)
+		return v, nil
+	})
+
+	c, _ := Collect(s)
+
+	g := rec.Stage("sleep")
+	if !reflect.DeepEqual(c, []int{3, 1, 4}) || g.Count() != 3 || g.Min() < time.Millisecond || g.Max() < 4*time.Millisecond {
+		t.Error(`Didn't Timed`)
+	}
+	if !reflect.DeepEqual(rec.Stages(), []string{"sleep"}) {
+		t.Error(`Didn't Timed stage`)
+	}
+}
+
+func TestShouldHistogramQuantile(t *testing.T) {
+	rec := NewLatencyHistograms()
+	for i := 1; i <= 1000; i++ {
+		rec.Record("s", time.Duration(i)*time.Microsecond)
+	}
+
+	g := rec.Stage("s")
+	for _, q := range []float64{0.5, 0.9, 0.99} {
+		want := time.Duration(q*1000) * time.Microsecond
+		got := g.Quantile(q)
+		if got < want*31/32 || want*33/32 < got {
+			t.Errorf(`Didn't Histogram quantile %v: %v`, q, got)
+		}
+	}
+	if g.Mean() != 500500*time.Nanosecond {
+		t.Error(`Didn't Histogram mean`)
+	}
+}
+
+func TestShouldHistogramBuckets(t *testing.T) {
+	for _, v := range []uint64{0, 1, 63, 64, 65, 1000, 1 << 40, 1<<63 + 12345} {
+		i := histogramBucket(v)
+		m := histogramValue(i)
+		if histogramBucket(m) != i {
+			t.Errorf(`Didn't Histogram bucket of %d`, v)
+		}
+	}
+}
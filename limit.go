@@ -0,0 +1,112 @@
+package streams
+
+import "sync"
+
+// A ConcurrencyLimit bounds the number of elements in flight at once, in
+// total or per key, among the streams limited by it. An element is in flight
+// while it is handled downstream of the limiting stream.
+type ConcurrencyLimit struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	permits  int
+	inFlight map[string]int
+}
+
+// NewConcurrencyLimit is a ConcurrencyLimit of the given number of permits,
+// which is at least one.
+func NewConcurrencyLimit(permits int) *ConcurrencyLimit {
+	if permits < 1 {
+		permits = 1
+	}
+
+	l := &ConcurrencyLimit{permits: permits, inFlight: make(map[string]int)}
+	l.cond = sync.NewCond(&l.mu)
+
+	return l
+}
+
+func (l *ConcurrencyLimit) acquire(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for l.permits <= l.inFlight[key] {
+		l.cond.Wait()
+	}
+	l.inFlight[key]++
+}
+
+func (l *ConcurrencyLimit) release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight[key]--
+	if l.inFlight[key] == 0 {
+		delete(l.inFlight, key)
+	}
+	l.cond.Broadcast()
+}
+
+// InFlight is the number of elements of the given key in flight.
+func (l *ConcurrencyLimit) InFlight(key string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.inFlight[key]
+}
+
+// A ConcurrencyLimiter represents the stream of the elements of a given base
+// stream, whose handling waits for a permit of a ConcurrencyLimit. Elements
+// are limited per key, as given by a key function, or in total if it is nil.
+// Since a stream handles one element at a time, the limit is meaningful
+// among streams sharing it and being resolved concurrently.
+type ConcurrencyLimiter[T any] struct {
+	base  Stream[T]
+	limit *ConcurrencyLimit
+	keyed func(v T) string
+}
+
+// LimitConcurrency limits the stream `s` with a new ConcurrencyLimit of
+// `permits`. The limit is shared by the streams limited with it, as joined
+// with `LimitConcurrencyWith` and the limiter's `Limit`, and its scope is
+// that of the elements handled by these streams only.
+func LimitConcurrency[T any](s Stream[T], permits int, keyed func(v T) string) Stream[T] {
+	return LimitConcurrencyWith(s, NewConcurrencyLimit(permits), keyed)
+}
+
+// LimitConcurrencyWith limits the stream `s` with the given, possibly
+// shared, ConcurrencyLimit.
+func LimitConcurrencyWith[T any](s Stream[T], limit *ConcurrencyLimit, keyed func(v T) string) Stream[T] {
+	return &ConcurrencyLimiter[T]{base: s, limit: limit, keyed: keyed}
+}
+
+// Limit is the ConcurrencyLimit of the stream, for limiting other streams
+// along with it.
+func (s *ConcurrencyLimiter[T]) Limit() *ConcurrencyLimit {
+	return s.limit
+}
+
+func (s *ConcurrencyLimiter[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		var key string
+		if s.keyed != nil {
+			key = s.keyed(v)
+		}
+
+		s.limit.acquire(key)
+		defer s.limit.release(key)
+
+		return handled(h(v))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
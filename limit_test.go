@@ -0,0 +1,101 @@
+package streams
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShouldLimitConcurrency(t *testing.T) {
+	s := LimitConcurrency(NewFromSlice([]int{3, 1, 4}), 1, nil)
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't LimitConcurrency`)
+	}
+}
+
+func TestShouldLimitConcurrencyPerKey(t *testing.T) {
+	limit := NewConcurrencyLimit(2)
+	keyed := func(v int) string {
+		if v%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	}
+
+	var mu sync.Mutex
+	high := map[string]int{}
+	var total, highTotal int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		vs := []int{i, i + 1, i + 2}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := LimitConcurrencyWith(NewFromSlice(vs), limit, keyed)
+			s = Map(s, func(v int) (int, error) {
+				n := atomic.AddInt32(&total, 1)
+				mu.Lock()
+				if k := limit.InFlight(keyed(v)); high[keyed(v)] < k {
+					high[keyed(v)] = k
+				}
+				if highTotal < n {
+					highTotal = n
+				}
+				mu.Unlock()
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&total, -1)
+				return v, nil
+			})
+			Collect(s)
+		}()
+	}
+	wg.Wait()
+
+	if 2 < high["even"] || 2 < high["odd"] || 4 < highTotal {
+		t.Error(`Didn't LimitConcurrency per key`)
+	}
+}
+
+func TestShouldLimitConcurrencySharingLimit(t *testing.T) {
+	s := LimitConcurrency(NewFromSlice([]int{3, 1, 4}), 1, nil)
+	limit := s.(*ConcurrencyLimiter[int]).Limit()
+
+	var inFlight, high int32
+	var wg sync.WaitGroup
+	for _, u := range []Stream[int]{s, LimitConcurrencyWith(NewFromSlice([]int{1, 5, 9}), limit, nil)} {
+		u := u
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Collect(Map(u, func(v int) (int, error) {
+				if n := atomic.AddInt32(&inFlight, 1); atomic.LoadInt32(&high) < n {
+					atomic.StoreInt32(&high, n)
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+				return v, nil
+			}))
+		}()
+	}
+	wg.Wait()
+
+	if high != 1 {
+		t.Error(`Didn't LimitConcurrency sharing limit`)
+	}
+}
+
+func TestShouldLimitConcurrencyOnZeroValue(t *testing.T) {
+	s := &ConcurrencyLimiter[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't LimitConcurrency on zero value`)
+	}
+}
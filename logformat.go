@@ -0,0 +1,339 @@
+package streams
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrLogFormat is the error of lines not in the expected log format.
+var ErrLogFormat = errors.New("streams: malformed log line")
+
+// A LogParseError is the error of a line, numbered from 1, that failed to
+// parse.
+type LogParseError struct {
+	Line int
+	Text string
+	Err  error
+}
+
+func (e *LogParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LogParseError) Unwrap() error {
+	return e.Err
+}
+
+// A lineParser represents the stream of the parsed lines read from a
+// reader, skipping blank lines.
+type lineParser[T any] struct {
+	in    *bufio.Scanner
+	parse func(line string) (T, error)
+	n     int
+}
+
+func (s *lineParser[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.in == nil {
+		return true, s, nil
+	}
+
+	for {
+		if !s.in.Scan() {
+			return true, s, internal(s.in.Err())
+		}
+		s.n++
+
+		line := strings.TrimRight(s.in.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		v, err := s.parse(line)
+		if err != nil {
+			return true, s, internal(&LogParseError{Line: s.n, Text: line, Err: err})
+		}
+
+		err = handled(h(v))
+		if err != nil {
+			return true, s, err
+		}
+
+		return false, s, nil
+	}
+}
+
+// A SyslogRecord is a syslog message, as of RFC 5424, or of RFC 3164, whose
+// Version is 0. RFC 3164 timestamps have no year, so their year is 0.
+// Absent fields are empty.
+type SyslogRecord struct {
+	Facility       int
+	Severity       int
+	Version        int
+	Timestamp      time.Time
+	Hostname       string
+	AppName        string
+	ProcID         string
+	MsgID          string
+	StructuredData string
+	Message        string
+}
+
+// ParseSyslog is the stream of the syslog messages read from `r`, one per
+// line.
+func ParseSyslog(r io.Reader) Stream[SyslogRecord] {
+	return &lineParser[SyslogRecord]{in: bufio.NewScanner(r), parse: parseSyslog}
+}
+
+// nextField splits `s` at the first space.
+func nextField(s string) (string, string) {
+	i := strings.IndexByte(s, ' ')
+	if i < 0 {
+		return s, ""
+	}
+
+	return s[:i], s[i+1:]
+}
+
+func nilValue(s string) string {
+	if s == "-" {
+		return ""
+	}
+
+	return s
+}
+
+func parseSyslog(line string) (SyslogRecord, error) {
+	var rec SyslogRecord
+
+	end := strings.IndexByte(line, '>')
+	if !strings.HasPrefix(line, "<") || end < 2 {
+		return rec, fmt.Errorf("%w: no priority", ErrLogFormat)
+	}
+
+	pri, err := strconv.Atoi(line[1:end])
+	if err != nil || pri < 0 || 191 < pri {
+		return rec, fmt.Errorf("%w: bad priority %q", ErrLogFormat, line[1:end])
+	}
+	rec.Facility = pri / 8
+	rec.Severity = pri % 8
+
+	rest := line[end+1:]
+	version, after := nextField(rest)
+	if v, err := strconv.Atoi(version); err == nil && 0 < v && after != "" {
+		rec.Version = v
+		return parseSyslog5424(rec, after)
+	}
+
+	return parseSyslog3164(rec, rest)
+}
+
+func parseSyslog5424(rec SyslogRecord, rest string) (SyslogRecord, error) {
+	var ts string
+	fields := []*string{&ts, &rec.Hostname, &rec.AppName, &rec.ProcID, &rec.MsgID}
+	for _, f := range fields {
+		if rest == "" {
+			return rec, fmt.Errorf("%w: missing header fields", ErrLogFormat)
+		}
+		*f, rest = nextField(rest)
+		*f = nilValue(*f)
+	}
+
+	if ts != "" {
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return rec, fmt.Errorf("%w: bad timestamp %q", ErrLogFormat, ts)
+		}
+		rec.Timestamp = t
+	}
+
+	sd, msg, err := splitStructuredData(rest)
+	if err != nil {
+		return rec, err
+	}
+	rec.StructuredData = nilValue(sd)
+	rec.Message = strings.TrimPrefix(msg, "\ufeff")
+
+	return rec, nil
+}
+
+// splitStructuredData splits the structured data at the start of `s` from
+// the message after it.
+func splitStructuredData(s string) (string, string, error) {
+	if strings.HasPrefix(s, "-") {
+		return "-", strings.TrimPrefix(s[1:], " "), nil
+	}
+
+	i := 0
+	for i < len(s) && s[i] == '[' {
+		quoted := false
+		j := i + 1
+		for ; j < len(s); j++ {
+			c := s[j]
+			if c == '\\' && quoted {
+				j++
+			} else if c == '"' {
+				quoted = !quoted
+			} else if c == ']' && !quoted {
+				break
+			}
+		}
+		if len(s) <= j {
+			return "", "", fmt.Errorf("%w: unterminated structured data", ErrLogFormat)
+		}
+		i = j + 1
+	}
+	if i == 0 {
+		return "", "", fmt.Errorf("%w: bad structured data", ErrLogFormat)
+	}
+
+	return s[:i], strings.TrimPrefix(s[i:], " "), nil
+}
+
+func parseSyslog3164(rec SyslogRecord, rest string) (SyslogRecord, error) {
+	if len(rest) < len(time.Stamp) {
+		return rec, fmt.Errorf("%w: bad timestamp", ErrLogFormat)
+	}
+
+	t, err := time.Parse(time.Stamp, rest[:len(time.Stamp)])
+	if err != nil {
+		return rec, fmt.Errorf("%w: bad timestamp %q", ErrLogFormat, rest[:len(time.Stamp)])
+	}
+	rec.Timestamp = t
+
+	rest = strings.TrimPrefix(rest[len(time.Stamp):], " ")
+	rec.Hostname, rest = nextField(rest)
+
+	// The tag, up to a colon, possibly with the process id in brackets
+	if i := strings.IndexByte(rest, ':'); 0 < i && !strings.ContainsAny(rest[:i], " ") {
+		tag := rest[:i]
+		rest = strings.TrimPrefix(rest[i+1:], " ")
+
+		if j := strings.IndexByte(tag, '['); 0 < j && strings.HasSuffix(tag, "]") {
+			rec.ProcID = tag[j+1 : len(tag)-1]
+			tag = tag[:j]
+		}
+		rec.AppName = tag
+	}
+	rec.Message = rest
+
+	return rec, nil
+}
+
+// An AccessRecord is a request logged in the Common Log Format, or in the
+// Combined Log Format, which adds the Referer and UserAgent. Absent fields
+// are empty, and Bytes is -1 if absent.
+type AccessRecord struct {
+	RemoteHost string
+	Ident      string
+	User       string
+	Time       time.Time
+	Request    string
+	Method     string
+	Path       string
+	Protocol   string
+	Status     int
+	Bytes      int64
+	Referer    string
+	UserAgent  string
+}
+
+// CommonLogTime is the layout of the time of the Common Log Format.
+const CommonLogTime = "02/Jan/2006:15:04:05 -0700"
+
+// ParseCommonLog is the stream of the requests read from `r`, as logged by
+// Apache or Nginx, one per line.
+func ParseCommonLog(r io.Reader) Stream[AccessRecord] {
+	return &lineParser[AccessRecord]{in: bufio.NewScanner(r), parse: parseCommonLog}
+}
+
+// delimited splits `s`, after any spaces, at the end of a field delimited
+// by `open` and `close`, unescaping backslashes if quoted.
+func delimited(s string, open, close byte) (string, string, error) {
+	s = strings.TrimLeft(s, " ")
+	if s == "" || s[0] != open {
+		return "", "", fmt.Errorf("%w: expected %q", ErrLogFormat, open)
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && open == '"' && i+1 < len(s) {
+			i++
+			b.WriteByte(s[i])
+			continue
+		}
+		if c == close {
+			return b.String(), s[i+1:], nil
+		}
+		b.WriteByte(c)
+	}
+
+	return "", "", fmt.Errorf("%w: expected %q", ErrLogFormat, close)
+}
+
+func parseCommonLog(line string) (AccessRecord, error) {
+	var rec AccessRecord
+
+	rest := line
+	for _, f := range []*string{&rec.RemoteHost, &rec.Ident, &rec.User} {
+		*f, rest = nextField(strings.TrimLeft(rest, " "))
+		*f = nilValue(*f)
+	}
+
+	ts, rest, err := delimited(rest, '[', ']')
+	if err != nil {
+		return rec, err
+	}
+	rec.Time, err = time.Parse(CommonLogTime, ts)
+	if err != nil {
+		return rec, fmt.Errorf("%w: bad time %q", ErrLogFormat, ts)
+	}
+
+	rec.Request, rest, err = delimited(rest, '"', '"')
+	if err != nil {
+		return rec, err
+	}
+	parts := strings.Fields(rec.Request)
+	if len(parts) == 3 {
+		rec.Method, rec.Path, rec.Protocol = parts[0], parts[1], parts[2]
+	}
+
+	var status, size string
+	status, rest = nextField(strings.TrimLeft(rest, " "))
+	size, rest = nextField(strings.TrimLeft(rest, " "))
+
+	rec.Status, err = strconv.Atoi(status)
+	if err != nil {
+		return rec, fmt.Errorf("%w: bad status %q", ErrLogFormat, status)
+	}
+
+	rec.Bytes = -1
+	if size != "-" {
+		rec.Bytes, err = strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			return rec, fmt.Errorf("%w: bad size %q", ErrLogFormat, size)
+		}
+	}
+
+	if strings.TrimSpace(rest) == "" {
+		return rec, nil
+	}
+
+	rec.Referer, rest, err = delimited(rest, '"', '"')
+	if err != nil {
+		return rec, err
+	}
+	rec.UserAgent, _, err = delimited(rest, '"', '"')
+	if err != nil {
+		return rec, err
+	}
+	rec.Referer = nilValue(rec.Referer)
+	rec.UserAgent = nilValue(rec.UserAgent)
+
+	return rec, nil
+}
@@ -0,0 +1,130 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShouldParseSyslog5424(t *testing.T) {
+	in := `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3" eventID="1011" x="a\]b"] An application event
+<34>1 2003-10-11T22:14:15Z host su - - - 'su root' failed`
+
+	c, err := Collect(ParseSyslog(strings.NewReader(in)))
+
+	want := []SyslogRecord{
+		{
+			Facility: 20, Severity: 5, Version: 1,
+			Timestamp:      time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+			Hostname:       "mymachine.example.com",
+			AppName:        "evntslog",
+			MsgID:          "ID47",
+			StructuredData: `[exampleSDID@32473 iut="3" eventID="1011" x="a\]b"]`,
+			Message:        "An application event",
+		},
+		{
+			Facility: 4, Severity: 2, Version: 1,
+			Timestamp: time.Date(2003, 10, 11, 22, 14, 15, 0, time.UTC),
+			Hostname:  "host",
+			AppName:   "su",
+			Message:   "'su root' failed",
+		},
+	}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't ParseSyslog 5424`, c, err)
+	}
+}
+
+func TestShouldParseSyslog3164(t *testing.T) {
+	in := "<13>Oct 11 22:14:15 mymachine sshd[4721]: Accepted publickey\n\n<13>Feb  5 17:32:18 10.0.0.99 Use the BFG!\n"
+
+	c, err := Collect(ParseSyslog(strings.NewReader(in)))
+
+	want := []SyslogRecord{
+		{
+			Facility: 1, Severity: 5,
+			Timestamp: time.Date(0, 10, 11, 22, 14, 15, 0, time.UTC),
+			Hostname:  "mymachine",
+			AppName:   "sshd",
+			ProcID:    "4721",
+			Message:   "Accepted publickey",
+		},
+		{
+			Facility: 1, Severity: 5,
+			Timestamp: time.Date(0, 2, 5, 17, 32, 18, 0, time.UTC),
+			Hostname:  "10.0.0.99",
+			Message:   "Use the BFG!",
+		},
+	}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't ParseSyslog 3164`, c, err)
+	}
+}
+
+func TestShouldParseSyslogErrorOnMalformed(t *testing.T) {
+	in := "<13>Oct 11 22:14:15 host tag: ok\nno priority\n"
+
+	c, err := Collect(ParseSyslog(strings.NewReader(in)))
+
+	var perr *LogParseError
+	if !errors.Is(err, ErrLogFormat) || !errors.As(err, &perr) || perr.Line != 2 || len(c) != 1 {
+		t.Error(`Didn't ParseSyslog error on malformed`)
+	}
+}
+
+func TestShouldParseCommonLog(t *testing.T) {
+	in := `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326
+10.0.0.1 - - [10/Oct/2000:13:55:37 -0700] "POST /a HTTP/1.1" 304 - "http://example.com/\"x\"" "curl/7.0"`
+
+	c, err := Collect(ParseCommonLog(strings.NewReader(in)))
+
+	zone := time.FixedZone("", -7*60*60)
+	want := []AccessRecord{
+		{
+			RemoteHost: "127.0.0.1", User: "frank",
+			Time:    time.Date(2000, 10, 10, 13, 55, 36, 0, zone),
+			Request: "GET /apache_pb.gif HTTP/1.0", Method: "GET", Path: "/apache_pb.gif", Protocol: "HTTP/1.0",
+			Status: 200, Bytes: 2326,
+		},
+		{
+			RemoteHost: "10.0.0.1",
+			Time:       time.Date(2000, 10, 10, 13, 55, 37, 0, zone),
+			Request:    "POST /a HTTP/1.1", Method: "POST", Path: "/a", Protocol: "HTTP/1.1",
+			Status: 304, Bytes: -1,
+			Referer: `http://example.com/"x"`, UserAgent: "curl/7.0",
+		},
+	}
+	if err != nil || len(c) != 2 {
+		t.Fatal(`Didn't ParseCommonLog`, err)
+	}
+	for i := range c {
+		if !c[i].Time.Equal(want[i].Time) {
+			t.Error(`Didn't ParseCommonLog time`)
+		}
+		c[i].Time, want[i].Time = time.Time{}, time.Time{}
+	}
+	if !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't ParseCommonLog`, c)
+	}
+}
+
+func TestShouldParseCommonLogErrorOnMalformed(t *testing.T) {
+	_, err := Collect(ParseCommonLog(strings.NewReader(`127.0.0.1 - - 10/Oct/2000 "GET /" 200 1`)))
+
+	if !errors.Is(err, ErrLogFormat) {
+		t.Error(`Didn't ParseCommonLog error on malformed`)
+	}
+}
+
+func TestShouldParseLogOnZeroValue(t *testing.T) {
+	var s *lineParser[SyslogRecord]
+
+	eos, _, err := s.Resolve(func(v SyslogRecord) error { return nil })
+	zeos, _, zerr := (&lineParser[AccessRecord]{}).Resolve(func(v AccessRecord) error { return nil })
+
+	if !eos || err != nil || !zeos || zerr != nil {
+		t.Error(`Didn't parse log on zero value`)
+	}
+}
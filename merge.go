@@ -0,0 +1,288 @@
+package streams
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// A mergeHead is the head element of a merged stream, with the remainder of
+// the stream, and the position of the stream among the merged ones.
+type mergeHead[T any] struct {
+	v T
+	s Stream[T]
+	i int
+}
+
+// A mergeHeap is a heap of the heads of merged streams, the least first, and
+// of the least position among equivalent heads.
+type mergeHeap[T any] struct {
+	heads []mergeHead[T]
+	cmp   Compare[T]
+}
+
+func (m *mergeHeap[T]) Len() int {
+	return len(m.heads)
+}
+
+func (m *mergeHeap[T]) Less(i, j int) bool {
+	if c := m.cmp(m.heads[i].v, m.heads[j].v); c != 0 {
+		return c < 0
+	}
+
+	return m.heads[i].i < m.heads[j].i
+}
+
+func (m *mergeHeap[T]) Swap(i, j int) {
+	m.heads[i], m.heads[j] = m.heads[j], m.heads[i]
+}
+
+func (m *mergeHeap[T]) Push(x any) {
+	m.heads = append(m.heads, x.(mergeHead[T]))
+}
+
+func (m *mergeHeap[T]) Pop() any {
+	n := len(m.heads) - 1
+	h := m.heads[n]
+	m.heads = m.heads[:n]
+
+	return h
+}
+
+// A SortedMerger represents the stream that results from merging given
+// streams, each in the order of a given Compare, into a single stream in
+// that order. Equivalent elements come in the order of their streams. The
+// head of each stream is held in a heap.
+type SortedMerger[T any] struct {
+	ss     []Stream[T]
+	heap   mergeHeap[T]
+	primed bool
+}
+
+// MergeSortedFunc merges streams, each sorted in the order of `cmp`, into a
+// single sorted stream, holding only the head of each stream. Elements of
+// equal order come in the order of their streams.
+func MergeSortedFunc[T any](cmp Compare[T], ss ...Stream[T]) Stream[T] {
+	return &SortedMerger[T]{ss: ss, heap: mergeHeap[T]{cmp: cmp}}
+}
+
+// MergeSorted is as `MergeSortedFunc`, in the order of `less`.
+func MergeSorted[T any](less func(a, b T) bool, ss ...Stream[T]) Stream[T] {
+	return MergeSortedFunc(FromLess(less), ss...)
+}
+
+// MergeByTime merges streams, each in chronological order according to
+// `ts`, into a single stream in chronological order, as for interleaving log
+// files.
+func MergeByTime[T any](ts func(v T) time.Time, ss ...Stream[T]) Stream[T] {
+	return MergeSortedFunc(func(a, b T) int {
+		ta, tb := ts(a), ts(b)
+		switch {
+		case ta.Before(tb):
+			return -1
+		case tb.Before(ta):
+			return 1
+		}
+
+		return 0
+	}, ss...)
+}
+
+// pull resolves the stream `s` until its next head, which it pushes on the
+// heap, or its end.
+func (m *SortedMerger[T]) pull(s Stream[T], i int) error {
+	for s != nil {
+		var v T
+		got := false
+		eos, nxs, err := s.Resolve(func(u T) error {
+			v = u
+			got = true
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if got {
+			heap.Push(&m.heap, mergeHead[T]{v: v, s: nxs, i: i})
+
+			return nil
+		}
+		if eos {
+			return nil
+		}
+		s = nxs
+	}
+
+	return nil
+}
+
+func (m *SortedMerger[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if m == nil {
+		return true, m, nil
+	}
+
+	if !m.primed {
+		for i, s := range m.ss {
+			err := m.pull(s, i)
+			if err != nil {
+				m.ss = nil
+
+				return true, m, err
+			}
+			m.ss[i] = nil
+		}
+		m.ss = nil
+		m.primed = true
+	}
+
+	if m.heap.Len() == 0 {
+		return true, m, nil
+	}
+
+	head := heap.Pop(&m.heap).(mergeHead[T])
+
+	err := handled(h(head.v))
+	if err != nil {
+		return true, m, err
+	}
+
+	err = m.pull(head.s, head.i)
+	if err != nil {
+		return true, m, err
+	}
+
+	return false, m, nil
+}
+
+// A mergedResolution is a resolution of the stream at position i among
+// merged streams.
+type mergedResolution[T any] struct {
+	resolution[T]
+	i int
+}
+
+// A Merger represents the stream of the elements of given streams, each
+// resolved concurrently, in its own goroutine, interleaved in the order they
+// are resolved, as for tailing several log files at once. The stream ends
+// once all the streams have ended. The first error received from any of the
+// streams fails the stream, after the elements received before it, and the
+// other streams are then stopped.
+type Merger[T any] struct {
+	ss   []Stream[T]
+	in   chan mergedResolution[T]
+	done chan struct{}
+	// The number of streams yet to end
+	live int
+	// The streams as left by their resolution, once stopped
+	rest    []Stream[T]
+	stopped bool
+}
+
+func Merge[T any](ss ...Stream[T]) Stream[T] {
+	return &Merger[T]{ss: ss}
+}
+
+func (m *Merger[T]) produce(i int, s Stream[T]) {
+	for {
+		eos, nxs, err := s.Resolve(func(v T) error {
+			select {
+			case m.in <- mergedResolution[T]{resolution: resolution[T]{v: v}, i: i}:
+				return nil
+			case <-m.done:
+				return ErrStop
+			}
+		})
+		s = nxs
+		if eos || err != nil {
+			m.rest[i] = s
+
+			select {
+			case m.in <- mergedResolution[T]{resolution: resolution[T]{eos: true, err: driverError(err)}, i: i}:
+			case <-m.done:
+			}
+
+			return
+		}
+	}
+}
+
+func (m *Merger[T]) start() {
+	m.in = make(chan mergedResolution[T], len(m.ss))
+	m.done = make(chan struct{})
+	m.rest = make([]Stream[T], len(m.ss))
+	m.live = len(m.ss)
+
+	var wg sync.WaitGroup
+	wg.Add(len(m.ss))
+	for i, s := range m.ss {
+		go func(i int, s Stream[T]) {
+			defer wg.Done()
+			m.produce(i, s)
+		}(i, s)
+	}
+
+	go func() {
+		wg.Wait()
+		close(m.in)
+	}()
+}
+
+func (m *Merger[T]) stop() {
+	if !m.stopped && m.done != nil {
+		close(m.done)
+	}
+	m.stopped = true
+}
+
+// Close stops the resolution of the streams, once the resolutions in
+// progress, if any, are done, ending the stream.
+func (m *Merger[T]) Close() error {
+	m.stop()
+	if m.in != nil {
+		for range m.in {
+		}
+		m.ss, m.in = m.rest, nil
+	}
+
+	return nil
+}
+
+func (m *Merger[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if m == nil || m.stopped {
+		return true, m, nil
+	}
+
+	if m.in == nil {
+		m.start()
+	}
+
+	for {
+		if m.live == 0 {
+			m.stop()
+
+			return true, m, nil
+		}
+
+		r := <-m.in
+		if r.eos {
+			m.live--
+			if r.err != nil {
+				m.stop()
+
+				return true, m, r.err
+			}
+
+			continue
+		}
+
+		err := handled(h(r.v))
+		if err != nil {
+			m.stop()
+
+			return true, m, err
+		}
+
+		return false, m, nil
+	}
+}
@@ -0,0 +1,175 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+type logLine struct {
+	at  time.Time
+	msg string
+}
+
+func logLines(msgs ...string) Stream[logLine] {
+	var ls []logLine
+	for _, m := range msgs {
+		d, _ := time.ParseDuration(m[1:])
+		ls = append(ls, logLine{at: time.Unix(0, 0).Add(d), msg: m})
+	}
+	return NewFromSlice(ls)
+}
+
+func logMsgs(ls []logLine) []string {
+	var ms []string
+	for _, l := range ls {
+		ms = append(ms, l.msg)
+	}
+	return ms
+}
+
+func TestShouldMergeByTime(t *testing.T) {
+	at := func(l logLine) time.Time { return l.at }
+	s := MergeByTime(at,
+		logLines("a1s", "a4s", "a5s"),
+		logLines(),
+		logLines("b2s", "b4s"),
+		Filter(logLines("c1s", "c3s", "c9s"), func(l logLine) bool { return l.msg != "c1s" }),
+	)
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(logMsgs(c), []string{"a1s", "b2s", "c3s", "a4s", "b4s", "a5s", "c9s"}) {
+		t.Error(`Didn't MergeByTime`, logMsgs(c))
+	}
+}
+
+func TestShouldMergeByTimeNone(t *testing.T) {
+	c, err := Collect(MergeByTime(func(l logLine) time.Time { return l.at }))
+
+	if err != nil || len(c) != 0 {
+		t.Error(`Didn't MergeByTime none`)
+	}
+}
+
+func TestShouldMergeByTimeErrorOnError(t *testing.T) {
+	failing := Map(logLines("b2s", "b3s"), func(l logLine) (logLine, error) {
+		if l.msg == "b3s" {
+			return l, errors.New("failed")
+		}
+		return l, nil
+	})
+	s := MergeByTime(func(l logLine) time.Time { return l.at }, logLines("a1s", "a4s"), failing)
+
+	c, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(logMsgs(c), []string{"a1s", "b2s"}) {
+		t.Error(`Didn't MergeByTime error on error`, logMsgs(c))
+	}
+}
+
+func TestShouldMerge(t *testing.T) {
+	c, err := Collect(Merge(NewFromSlice([]int{3, 1, 4}), NewFromSlice([]int{1, 5}), NewFromSlice([]int{9})))
+	sort.Ints(c)
+
+	if err != nil || !reflect.DeepEqual(c, []int{1, 1, 3, 4, 5, 9}) {
+		t.Error(`Didn't Merge`)
+	}
+}
+
+func TestShouldMergeInOrderOfEachStream(t *testing.T) {
+	c, _ := Collect(Merge(NewFromSlice([]int{1, 2, 3}), NewFromSlice([]int{-1, -2, -3})))
+
+	var pos, neg []int
+	for _, v := range c {
+		if 0 < v {
+			pos = append(pos, v)
+		} else {
+			neg = append(neg, v)
+		}
+	}
+
+	if !reflect.DeepEqual(pos, []int{1, 2, 3}) || !reflect.DeepEqual(neg, []int{-1, -2, -3}) {
+		t.Error(`Didn't Merge in order of each stream`)
+	}
+}
+
+func TestShouldMergeNone(t *testing.T) {
+	c, err := Collect(Merge[int]())
+
+	if err != nil || len(c) != 0 {
+		t.Error(`Didn't Merge none`)
+	}
+}
+
+func TestShouldMergeAsAvailable(t *testing.T) {
+	stalled := FromChannel(make(chan int))
+
+	c, _, err := CollectN(Merge(stalled, NewFromSlice([]int{3, 1, 4})), 3)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't Merge as available`)
+	}
+}
+
+func TestShouldMergeErrorOnError(t *testing.T) {
+	failing := Map(NewFromSlice([]int{1, 2}), func(v int) (int, error) {
+		if v == 2 {
+			return 0, errors.New("failed")
+		}
+		return v, nil
+	})
+
+	c, s, err := CollectN(Merge(failing, FromChannel(make(chan int))), 3)
+	eos, _, rerr := s.Resolve(func(v int) error { return nil })
+
+	if err == nil || !reflect.DeepEqual(c, []int{1}) || !eos || rerr != nil {
+		t.Error(`Didn't Merge error on error`)
+	}
+}
+
+func TestShouldCloseStreamMerge(t *testing.T) {
+	r := newTrackedReader("3\n1\n4\n")
+	u := newTrackedReader("1\n5\n9\n")
+	s := Merge(NewStreamOfLinesOpen(r), NewStreamOfLinesOpen(u))
+
+	c, s, _ := CollectN(s, 1)
+	err := CloseStream(s)
+	rest, _ := Collect(s)
+
+	if err != nil || len(c) != 1 || len(rest) != 0 || r.closes != 1 || u.closes != 1 {
+		t.Error(`Didn't CloseStream Merge`)
+	}
+}
+
+func TestShouldMergeSorted(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	c, err := Collect(MergeSorted(less, NewFromSlice([]int{1, 4, 9}), NewFromSlice([]int{2, 3}), NewFromSlice([]int{}), NewFromSlice([]int{1, 10})))
+
+	if err != nil || !reflect.DeepEqual(c, []int{1, 1, 2, 3, 4, 9, 10}) {
+		t.Error(`Didn't MergeSorted`)
+	}
+}
+
+func TestShouldMergeSortedFunc(t *testing.T) {
+	desc := func(a, b int) int { return b - a }
+
+	c, err := Collect(MergeSortedFunc(desc, NewFromSlice([]int{9, 4, 1}), NewFromSlice([]int{3, 2})))
+
+	if err != nil || !reflect.DeepEqual(c, []int{9, 4, 3, 2, 1}) {
+		t.Error(`Didn't MergeSortedFunc`)
+	}
+}
+
+func TestShouldMergeSortedStable(t *testing.T) {
+	less := func(a, b logLine) bool { return a.at.Before(b.at) }
+
+	c, _ := Collect(MergeSorted(less, logLines("a1s", "a2s"), logLines("b1s", "b2s")))
+
+	if !reflect.DeepEqual(logMsgs(c), []string{"a1s", "b1s", "a2s", "b2s"}) {
+		t.Error(`Didn't MergeSorted stable`, logMsgs(c))
+	}
+}
@@ -0,0 +1,118 @@
+package streams
+
+import "context"
+
+// An MQTTMessage is a message received on an MQTT topic.
+type MQTTMessage struct {
+	Topic    string
+	Payload  []byte
+	QoS      byte
+	Retained bool
+	// Ack, if not nil, acknowledges the message to the broker
+	Ack func()
+}
+
+// An MQTTSubscriber subscribes to MQTT topics, calling back with each message
+// received. Any MQTT client can be adapted to it.
+type MQTTSubscriber interface {
+	Subscribe(topic string, qos byte, callback func(m MQTTMessage)) error
+	Unsubscribe(topics ...string) error
+}
+
+// MQTTOptions are the options of an MQTTSource.
+type MQTTOptions struct {
+	Topics []string
+	QoS    byte
+	// Buffer is the number of messages buffered, at least one
+	Buffer int
+	// Overflow is what to do with messages once the buffer is full
+	Overflow BufferPolicy
+}
+
+// An MQTTSource represents the stream of the messages received on some MQTT
+// topics. The topics are subscribed to on the first resolution, and
+// unsubscribed from at the end of stream, which is when the context is
+// done. Messages are acknowledged, if needed, once handled successfully.
+type MQTTSource struct {
+	ctx        context.Context
+	client     MQTTSubscriber
+	opts       MQTTOptions
+	in         *inbox[MQTTMessage]
+	subscribed bool
+}
+
+func NewMQTTSource(ctx context.Context, client MQTTSubscriber, opts MQTTOptions) *MQTTSource {
+	return &MQTTSource{
+		ctx:    ctx,
+		client: client,
+		opts:   opts,
+		in:     newInbox[MQTTMessage](opts.Buffer, opts.Overflow),
+	}
+}
+
+// Dropped is the number of messages dropped because the buffer was full.
+func (s *MQTTSource) Dropped() int64 {
+	return s.in.Dropped()
+}
+
+func (s *MQTTSource) close() error {
+	s.in.close()
+	s.ctx = nil
+
+	if !s.subscribed {
+		return nil
+	}
+
+	return s.client.Unsubscribe(s.opts.Topics...)
+}
+
+// Close unsubscribes from the topics, ending the stream.
+func (s *MQTTSource) Close() error {
+	if s.ctx == nil {
+		return nil
+	}
+
+	return s.close()
+}
+
+func (s *MQTTSource) Resolve(h func(v MQTTMessage) error) (bool, Stream[MQTTMessage], error) {
+	if s == nil || s.ctx == nil {
+		return true, s, nil
+	}
+
+	if !s.subscribed {
+		for _, topic := range s.opts.Topics {
+			err := s.client.Subscribe(topic, s.opts.QoS, s.in.push)
+			if err != nil {
+				// Unsubscribe from the topics subscribed to so far
+				s.subscribed = true
+				s.close()
+
+				return true, s, internal(err)
+			}
+		}
+		s.subscribed = true
+	}
+
+	select {
+	case <-s.ctx.Done():
+		return true, s, internal(s.close())
+	case <-s.in.overflow:
+		s.close()
+
+		return true, s, internal(ErrBufferOverflow)
+	case m := <-s.in.c:
+		err := handled(h(m))
+		if err != nil {
+			s.close()
+
+			return true, s, err
+		}
+
+		if m.Ack != nil {
+			m.Ack()
+		}
+
+		return false, s, nil
+	}
+}
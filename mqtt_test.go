@@ -0,0 +1,133 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// A fakeMQTT is an MQTTSubscriber whose messages are published by the test,
+// once subscribed to.
+type fakeMQTT struct {
+	mu           sync.Mutex
+	cond         *sync.Cond
+	callbacks    map[string]func(m MQTTMessage)
+	unsubscribed []string
+}
+
+func newFakeMQTT() *fakeMQTT {
+	f := &fakeMQTT{callbacks: map[string]func(m MQTTMessage){}}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *fakeMQTT) Subscribe(topic string, qos byte, callback func(m MQTTMessage)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.callbacks[topic] = callback
+	f.cond.Broadcast()
+	return nil
+}
+
+func (f *fakeMQTT) Unsubscribe(topics ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.unsubscribed = append(f.unsubscribed, topics...)
+	return nil
+}
+
+func (f *fakeMQTT) publish(m MQTTMessage) {
+	f.mu.Lock()
+	for f.callbacks[m.Topic] == nil {
+		f.cond.Wait()
+	}
+	callback := f.callbacks[m.Topic]
+	f.mu.Unlock()
+
+	callback(m)
+}
+
+func (f *fakeMQTT) publishAll(topic string, payloads ...string) {
+	for _, p := range payloads {
+		f.publish(MQTTMessage{Topic: topic, Payload: []byte(p)})
+	}
+}
+
+func mqttPayloads(ms []MQTTMessage) []string {
+	var ps []string
+	for _, m := range ms {
+		ps = append(ps, m.Topic+":"+string(m.Payload))
+	}
+	return ps
+}
+
+func TestShouldMQTTSource(t *testing.T) {
+	client := newFakeMQTT()
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewMQTTSource(ctx, client, MQTTOptions{Topics: []string{"a", "b"}, Buffer: 4})
+
+	go func() {
+		client.publishAll("a", "3")
+		client.publishAll("b", "1")
+		client.publishAll("a", "4")
+	}()
+
+	c, rest, _ := CollectN[MQTTMessage](s, 3)
+	cancel()
+	more, err := Collect(rest)
+
+	if err != nil || len(more) != 0 || !reflect.DeepEqual(mqttPayloads(c), []string{"a:3", "b:1", "a:4"}) {
+		t.Error(`Didn't MQTTSource`)
+	}
+	if !reflect.DeepEqual(client.unsubscribed, []string{"a", "b"}) {
+		t.Error(`Didn't MQTTSource unsubscribe`)
+	}
+}
+
+func TestShouldMQTTSourceDropOldest(t *testing.T) {
+	client := newFakeMQTT()
+	s := NewMQTTSource(context.Background(), client, MQTTOptions{Topics: []string{"a"}, Buffer: 2, Overflow: BufferDropOldest})
+
+	go client.publishAll("a", "3")
+	first, rest, _ := CollectN[MQTTMessage](s, 1)
+	client.publishAll("a", "1", "4", "1")
+	c, _, _ := CollectN(rest, 2)
+
+	if !reflect.DeepEqual(mqttPayloads(first), []string{"a:3"}) || !reflect.DeepEqual(mqttPayloads(c), []string{"a:4", "a:1"}) || s.Dropped() != 1 {
+		t.Error(`Didn't MQTTSource drop oldest`)
+	}
+}
+
+func TestShouldMQTTSourceErrorOnOverflow(t *testing.T) {
+	client := newFakeMQTT()
+	s := NewMQTTSource(context.Background(), client, MQTTOptions{Topics: []string{"a"}, Buffer: 1, Overflow: BufferError})
+
+	go client.publishAll("a", "3")
+	_, rest, _ := CollectN[MQTTMessage](s, 1)
+	client.publishAll("a", "1", "4")
+	_, err := Collect(rest)
+
+	if !errors.Is(err, ErrBufferOverflow) {
+		t.Error(`Didn't MQTTSource error on overflow`)
+	}
+	if !reflect.DeepEqual(client.unsubscribed, []string{"a"}) {
+		t.Error(`Didn't MQTTSource unsubscribe on overflow`)
+	}
+}
+
+func TestShouldMQTTSourceAck(t *testing.T) {
+	client := newFakeMQTT()
+	s := NewMQTTSource(context.Background(), client, MQTTOptions{Topics: []string{"a"}, Buffer: 1})
+
+	acked := 0
+	go client.publish(MQTTMessage{Topic: "a", Ack: func() { acked++ }})
+	CollectN[MQTTMessage](s, 1)
+
+	if acked != 1 {
+		t.Error(`Didn't MQTTSource ack`)
+	}
+}
@@ -0,0 +1,74 @@
+package streams
+
+import (
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// A MsgpackDecoder decodes successive MessagePack values from a reader,
+// failing with `io.EOF` at its end.
+type MsgpackDecoder interface {
+	Decode(v any) error
+}
+
+// A MsgpackEncoder encodes successive MessagePack values to a writer.
+type MsgpackEncoder interface {
+	Encode(v any) error
+}
+
+// A MsgpackCodec makes MessagePack decoders and encoders, so that the
+// MessagePack implementation is pluggable.
+type MsgpackCodec interface {
+	NewDecoder(r io.Reader) MsgpackDecoder
+	NewEncoder(w io.Writer) MsgpackEncoder
+}
+
+type vmihailencoMsgpack struct{}
+
+func (vmihailencoMsgpack) NewDecoder(r io.Reader) MsgpackDecoder {
+	return msgpack.NewDecoder(r)
+}
+
+func (vmihailencoMsgpack) NewEncoder(w io.Writer) MsgpackEncoder {
+	return msgpack.NewEncoder(w)
+}
+
+// DefaultMsgpack is the MsgpackCodec of `github.com/vmihailenco/msgpack`.
+var DefaultMsgpack MsgpackCodec = vmihailencoMsgpack{}
+
+// DecodeMsgpack is the stream of the MessagePack values read from `r`, one
+// after the other, decoded with DefaultMsgpack.
+func DecodeMsgpack[T any](r io.Reader) Stream[T] {
+	return DecodeMsgpackCodec[T](r, DefaultMsgpack)
+}
+
+// DecodeMsgpackCodec is as `DecodeMsgpack`, decoding with `codec`.
+func DecodeMsgpackCodec[T any](r io.Reader, codec MsgpackCodec) Stream[T] {
+	dec := codec.NewDecoder(r)
+
+	return FromRecv(func() (T, error) {
+		var v T
+		err := dec.Decode(&v)
+
+		return v, internal(err)
+	})
+}
+
+// A MsgpackSink is a Sink writing each element as a MessagePack value, so
+// that `DecodeMsgpack` reads them back.
+type MsgpackSink[T any] struct {
+	enc MsgpackEncoder
+}
+
+func NewMsgpackSink[T any](w io.Writer) *MsgpackSink[T] {
+	return NewMsgpackSinkCodec[T](w, DefaultMsgpack)
+}
+
+func NewMsgpackSinkCodec[T any](w io.Writer, codec MsgpackCodec) *MsgpackSink[T] {
+	return &MsgpackSink[T]{enc: codec.NewEncoder(w)}
+}
+
+func (m *MsgpackSink[T]) Send(v T) error {
+	return m.enc.Encode(v)
+}
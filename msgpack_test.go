@@ -0,0 +1,48 @@
+package streams
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+type msgpackReading struct {
+	Sensor string
+	Value  float64
+}
+
+func TestShouldDecodeMsgpack(t *testing.T) {
+	rs := []msgpackReading{{"a", 3}, {"b", 1.5}, {"c", -4}}
+
+	var b bytes.Buffer
+	_, err := SendAll[msgpackReading](NewFromSlice(rs), NewMsgpackSink[msgpackReading](&b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Collect(DecodeMsgpack[msgpackReading](&b))
+
+	if err != nil || !reflect.DeepEqual(c, rs) {
+		t.Error(`Didn't DecodeMsgpack`)
+	}
+}
+
+func TestShouldDecodeMsgpackOnEmpty(t *testing.T) {
+	c, err := Collect(DecodeMsgpack[int](&bytes.Buffer{}))
+
+	if err != nil || len(c) != 0 {
+		t.Error(`Didn't DecodeMsgpack on empty`)
+	}
+}
+
+func TestShouldDecodeMsgpackErrorOnTruncated(t *testing.T) {
+	var b bytes.Buffer
+	SendAll[string](NewFromSlice([]string{"three", "one"}), NewMsgpackSink[string](&b))
+	b.Truncate(b.Len() - 1)
+
+	c, err := Collect(DecodeMsgpack[string](&b))
+
+	if err == nil || !reflect.DeepEqual(c, []string{"three"}) {
+		t.Error(`Didn't DecodeMsgpack error on truncated`)
+	}
+}
@@ -0,0 +1,30 @@
+package streams
+
+// must panics if `err` is not nil, and otherwise returns `v`.
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// MustCollect is as `Collect`, but panics on error.
+func MustCollect[T any](s Stream[T]) []T {
+	return must(Collect(s))
+}
+
+// MustCount is as `Count`, but panics on error.
+func MustCount[T any](s Stream[T]) int {
+	return must(Count(s))
+}
+
+// MustAccumulate is as `Accumulate`, but panics on error.
+func MustAccumulate[T any](s Stream[T], r T, f func(a, b T) T) T {
+	return must(Accumulate(s, r, f))
+}
+
+// MustFold is as `Fold`, but panics on error.
+func MustFold[T, R any](s Stream[T], init R, f func(R, T) (R, error)) R {
+	return must(Fold(s, init, f))
+}
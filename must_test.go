@@ -0,0 +1,87 @@
+package streams
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func failingAt4(v int) (int, error) {
+	if v == 4 {
+		return 0, fmt.Errorf("error")
+	}
+	return v, nil
+}
+
+func shouldPanic(t *testing.T, msg string) {
+	if recover() == nil {
+		t.Error(msg)
+	}
+}
+
+func TestShouldMustCollect(t *testing.T) {
+	c := MustCollect(NewFromSlice([]int{3, 1, 4}))
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't MustCollect`)
+	}
+}
+
+func TestMustCollectShouldPanicOnError(t *testing.T) {
+	defer shouldPanic(t, `Didn't MustCollect panic on error`)
+
+	MustCollect(Map(NewFromSlice([]int{3, 1, 4}), failingAt4))
+}
+
+func TestShouldMustCount(t *testing.T) {
+	if MustCount(NewFromSlice([]int{3, 1, 4})) != 3 {
+		t.Error(`Didn't MustCount`)
+	}
+}
+
+func TestMustCountShouldPanicOnError(t *testing.T) {
+	defer shouldPanic(t, `Didn't MustCount panic on error`)
+
+	MustCount(Map(NewFromSlice([]int{3, 1, 4}), failingAt4))
+}
+
+func TestShouldMustAccumulate(t *testing.T) {
+	a := MustAccumulate(NewFromSlice([]int{3, 1, 4}), 0, func(a, b int) int { return a + b })
+
+	if a != 8 {
+		t.Error(`Didn't MustAccumulate`)
+	}
+}
+
+func TestMustAccumulateShouldPanicOnError(t *testing.T) {
+	defer shouldPanic(t, `Didn't MustAccumulate panic on error`)
+
+	MustAccumulate(Map(NewFromSlice([]int{3, 1, 4}), failingAt4), 0, func(a, b int) int { return a + b })
+}
+
+func TestShouldMustFold(t *testing.T) {
+	r := MustFold(NewFromSlice([]int{3, 1, 4}), "", func(r string, v int) (string, error) { return fmt.Sprint(r, v), nil })
+
+	if r != "314" {
+		t.Error(`Didn't MustFold`)
+	}
+}
+
+func TestMustFoldShouldPanicOnError(t *testing.T) {
+	defer shouldPanic(t, `Didn't MustFold panic on error`)
+
+	MustFold(Map(NewFromSlice([]int{3, 1, 4}), failingAt4), 0, func(r, v int) (int, error) { return r + v, nil })
+}
+
+func TestMustCollectShouldNotPanicOnStop(t *testing.T) {
+	s := Map(NewFromSlice([]int{3, 1, 4}), func(v int) (int, error) {
+		if v == 4 {
+			return 0, ErrStop
+		}
+		return v, nil
+	})
+
+	if !reflect.DeepEqual(MustCollect(s), []int{3, 1}) {
+		t.Error(`Didn't MustCollect until stop`)
+	}
+}
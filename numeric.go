@@ -0,0 +1,138 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// A Number is any integer or floating point type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// An OverflowPolicy determines what a numeric conversion does with values
+// that are out of the range of the target type.
+type OverflowPolicy int
+
+const (
+	// OverflowError fails the conversion with `ErrOverflow`.
+	OverflowError OverflowPolicy = iota
+	// OverflowSaturate converts to the nearest value in range.
+	OverflowSaturate
+	// OverflowWrap converts as the Go conversion does.
+	OverflowWrap
+)
+
+// ErrOverflow is the error reported by a numeric conversion of a value out
+// of the range of the target type, under the `OverflowError` policy. It is
+// also reported, regardless of the policy, for conversions of NaN to an
+// integer type.
+var ErrOverflow = errors.New("streams: numeric overflow")
+
+// ConvertNumeric converts each element of `s` to the numeric type `U`,
+// failing on elements out of the range of `U`. Conversions from floating
+// point to integer types truncate towards zero, and conversions between
+// floating point types may lose precision, as in Go.
+func ConvertNumeric[T, U Number](s Stream[T]) Stream[U] {
+	return ConvertNumericPolicy[T, U](s, OverflowError)
+}
+
+// ConvertNumericPolicy is as `ConvertNumeric`, handling elements out of
+// range according to the given `policy`.
+func ConvertNumericPolicy[T, U Number](s Stream[T], policy OverflowPolicy) Stream[U] {
+	return Map(s, func(v T) (U, error) {
+		return convertNumber[T, U](v, policy)
+	})
+}
+
+func isFloat[T Number]() bool {
+	var one T = 1
+
+	return one/2 != 0
+}
+
+// numberRange returns the least and greatest values of type T.
+func numberRange[T Number]() (T, T) {
+	var z T
+	bits := unsafe.Sizeof(z) * 8
+
+	if isFloat[T]() {
+		hi := math.MaxFloat64
+		if bits == 32 {
+			hi = math.MaxFloat32
+		}
+
+		return T(-hi), T(hi)
+	}
+
+	z--
+	if 0 < z {
+		// Unsigned, all bits set
+		return 0, z
+	}
+
+	hi := int64(math.MaxInt64) >> (64 - bits)
+
+	return T(-hi - 1), T(hi)
+}
+
+func convertNumber[T, U Number](v T, policy OverflowPolicy) (U, error) {
+	u := U(v)
+
+	var overflow bool
+	if isFloat[T]() {
+		f := float64(v)
+		if math.IsNaN(f) && !isFloat[U]() {
+			return u, internal(fmt.Errorf("%w: %v", ErrOverflow, v))
+		}
+
+		if isFloat[U]() {
+			overflow = math.IsInf(float64(u), 0) && !math.IsInf(f, 0)
+		} else {
+			overflow = float64(u) != math.Trunc(f)
+		}
+	} else if !isFloat[U]() {
+		overflow = T(u) != v || (u < 0) != (v < 0)
+	}
+
+	if !overflow || policy == OverflowWrap {
+		return u, nil
+	}
+
+	if policy == OverflowSaturate {
+		lo, hi := numberRange[U]()
+		if v < 0 {
+			return lo, nil
+		}
+
+		return hi, nil
+	}
+
+	return u, internal(fmt.Errorf("%w: %v", ErrOverflow, v))
+}
+
+// ParseInts parses each element of `s`, with any surrounding white space
+// removed, as a decimal integer.
+func ParseInts(s Stream[string]) Stream[int] {
+	return Map(s, func(v string) (int, error) {
+		i, err := strconv.Atoi(strings.TrimSpace(v))
+
+		return i, internal(err)
+	})
+}
+
+// ParseFloats parses each element of `s`, with any surrounding white space
+// removed, as a floating point number.
+func ParseFloats(s Stream[string]) Stream[float64] {
+	return Map(s, func(v string) (float64, error) {
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+
+		return f, internal(err)
+	})
+}
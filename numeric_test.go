@@ -0,0 +1,104 @@
+package streams
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestShouldConvertNumeric(t *testing.T) {
+	s := NewFromSlice([]int{3, -1, 4})
+	ss := ConvertNumeric[int, int8](s)
+
+	c, _ := Collect(ss)
+
+	if !reflect.DeepEqual(c, []int8{3, -1, 4}) {
+		t.Error(`Didn't ConvertNumeric`)
+	}
+}
+
+func TestShouldConvertNumericErrorOnOverflow(t *testing.T) {
+	s := NewFromSlice([]int{3, 300, 4})
+	ss := ConvertNumeric[int, int8](s)
+
+	c, err := Collect(ss)
+
+	if !reflect.DeepEqual(c, []int8{3}) || !errors.Is(err, ErrOverflow) {
+		t.Error(`Didn't ConvertNumeric error on overflow`)
+	}
+}
+
+func TestShouldConvertNumericErrorOnNegativeToUnsigned(t *testing.T) {
+	s := NewFromSlice([]int{-1})
+	ss := ConvertNumeric[int, uint](s)
+
+	_, err := Collect(ss)
+
+	if !errors.Is(err, ErrOverflow) {
+		t.Error(`Didn't ConvertNumeric error on negative to unsigned`)
+	}
+}
+
+func TestShouldConvertNumericSaturating(t *testing.T) {
+	s := NewFromSlice([]float64{3.5, 1e10, -1e10, -1.5})
+	ss := ConvertNumericPolicy[float64, int16](s, OverflowSaturate)
+
+	c, _ := Collect(ss)
+
+	if !reflect.DeepEqual(c, []int16{3, math.MaxInt16, math.MinInt16, -1}) {
+		t.Error(`Didn't ConvertNumeric saturating`)
+	}
+}
+
+func TestShouldConvertNumericWrapping(t *testing.T) {
+	s := NewFromSlice([]int{257})
+	ss := ConvertNumericPolicy[int, uint8](s, OverflowWrap)
+
+	c, _ := Collect(ss)
+
+	if !reflect.DeepEqual(c, []uint8{1}) {
+		t.Error(`Didn't ConvertNumeric wrapping`)
+	}
+}
+
+func TestShouldConvertNumericErrorOnNaN(t *testing.T) {
+	s := NewFromSlice([]float64{math.NaN()})
+	ss := ConvertNumericPolicy[float64, int](s, OverflowSaturate)
+
+	_, err := Collect(ss)
+
+	if !errors.Is(err, ErrOverflow) {
+		t.Error(`Didn't ConvertNumeric error on NaN`)
+	}
+}
+
+func TestShouldParseInts(t *testing.T) {
+	s := NewFromSlice([]string{"3", " 1", "4\r"})
+
+	c, _ := Collect(ParseInts(s))
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't ParseInts`)
+	}
+}
+
+func TestShouldParseIntsErrorOnMalformed(t *testing.T) {
+	s := NewFromSlice([]string{"3", "x"})
+
+	_, err := Collect(ParseInts(s))
+
+	if err == nil {
+		t.Error(`Didn't ParseInts error on malformed`)
+	}
+}
+
+func TestShouldParseFloats(t *testing.T) {
+	s := NewFromSlice([]string{"3.1", "4e1"})
+
+	c, _ := Collect(ParseFloats(s))
+
+	if !reflect.DeepEqual(c, []float64{3.1, 40}) {
+		t.Error(`Didn't ParseFloats`)
+	}
+}
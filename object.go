@@ -0,0 +1,228 @@
+package streams
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// An ObjectInfo describes an object of a Bucket.
+type ObjectInfo struct {
+	Key  string
+	Size int64
+}
+
+// A Bucket is a minimal object storage, such as an S3 or GCS bucket, or a
+// directory as a DirBucket.
+type Bucket interface {
+	// List lists the objects whose keys start with `prefix`, sorted by key
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// Open reads `length` bytes of the object from `offset`, or up to its
+	// end, if `length` is negative
+	Open(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+}
+
+// A DirBucket is the Bucket of the regular files under the directory Dir,
+// keyed by their slash separated path relative to it.
+type DirBucket struct {
+	Dir string
+}
+
+func (b DirBucket) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objs []ObjectInfo
+
+	err := filepath.WalkDir(b.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.Dir, path)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		objs = append(objs, ObjectInfo{Key: key, Size: info.Size()})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objs, func(i, j int) bool { return objs[i].Key < objs[j].Key })
+
+	return objs, nil
+}
+
+type limitedFile struct {
+	io.Reader
+	*os.File
+}
+
+func (f limitedFile) Read(p []byte) (int, error) {
+	return f.Reader.Read(p)
+}
+
+func (b DirBucket) Open(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(b.Dir, filepath.FromSlash(key)))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = f.Seek(offset, io.SeekStart)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if length < 0 {
+		return f, nil
+	}
+
+	return limitedFile{Reader: io.LimitReader(f, length), File: f}, nil
+}
+
+// An ObjectRecord is a record read from an object, at a given offset.
+type ObjectRecord struct {
+	Key    string
+	Offset int64
+	Data   []byte
+}
+
+// An ObjectSource represents the stream of the records of the objects of a
+// Bucket whose keys start with a given prefix, object after object. The
+// objects are listed on the first resolution, and each is opened once its
+// records are reached. Records are split as by a `bufio.SplitFunc`.
+type ObjectSource struct {
+	ctx    context.Context
+	bucket Bucket
+	prefix string
+	split  bufio.SplitFunc
+	listed bool
+	objs   []ObjectInfo
+	// The object being read
+	r      io.ReadCloser
+	in     *bufio.Scanner
+	offset int64
+}
+
+func NewObjectSource(ctx context.Context, bucket Bucket, prefix string, split bufio.SplitFunc) *ObjectSource {
+	return &ObjectSource{ctx: ctx, bucket: bucket, prefix: prefix, split: split}
+}
+
+// ObjectLines is the stream of the lines of the objects of `bucket` whose
+// keys start with `prefix`.
+func ObjectLines(ctx context.Context, bucket Bucket, prefix string) Stream[ObjectRecord] {
+	return NewObjectSource(ctx, bucket, prefix, bufio.ScanLines)
+}
+
+func (s *ObjectSource) close() error {
+	if s.r == nil {
+		return nil
+	}
+
+	err := s.r.Close()
+	s.r = nil
+
+	return err
+}
+
+// Close closes the object being read, if any, ending the stream.
+func (s *ObjectSource) Close() error {
+	s.objs, s.listed = nil, true
+
+	return s.close()
+}
+
+func (s *ObjectSource) open() error {
+	r, err := s.bucket.Open(s.ctx, s.objs[0].Key, 0, -1)
+	if err != nil {
+		return err
+	}
+
+	s.r = r
+	s.offset = 0
+	s.in = bufio.NewScanner(r)
+	s.in.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := s.split(data, atEOF)
+		s.offset += int64(advance)
+		return advance, token, err
+	})
+
+	return nil
+}
+
+func (s *ObjectSource) Resolve(h func(v ObjectRecord) error) (bool, Stream[ObjectRecord], error) {
+	if s == nil || s.bucket == nil {
+		return true, s, nil
+	}
+
+	if !s.listed {
+		objs, err := s.bucket.List(s.ctx, s.prefix)
+		if err != nil {
+			return true, s, internal(err)
+		}
+
+		s.objs = objs
+		s.listed = true
+	}
+
+	for {
+		if err := s.ctx.Err(); err != nil {
+			s.close()
+			return true, s, internal(err)
+		}
+
+		if len(s.objs) == 0 {
+			return true, s, nil
+		}
+
+		if s.r == nil {
+			err := s.open()
+			if err != nil {
+				return true, s, internal(err)
+			}
+		}
+
+		start := s.offset
+		if s.in.Scan() {
+			data := append([]byte(nil), s.in.Bytes()...)
+
+			err := handled(h(ObjectRecord{Key: s.objs[0].Key, Offset: start, Data: data}))
+			if err != nil {
+				s.close()
+				return true, s, err
+			}
+
+			return false, s, nil
+		}
+
+		s.close()
+		if err := s.in.Err(); err != nil {
+			return true, s, internal(err)
+		}
+
+		s.objs = s.objs[1:]
+	}
+}
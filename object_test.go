@@ -0,0 +1,75 @@
+package streams
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeObjects(t *testing.T, objs map[string]string) string {
+	dir := t.TempDir()
+	for key, data := range objs {
+		path := filepath.Join(dir, filepath.FromSlash(key))
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		os.WriteFile(path, []byte(data), 0o644)
+	}
+	return dir
+}
+
+func TestShouldObjectLines(t *testing.T) {
+	dir := writeObjects(t, map[string]string{
+		"logs/b": "4\n",
+		"logs/a": "3\n1\n",
+		"other":  "1\n",
+	})
+
+	s := ObjectLines(context.Background(), DirBucket{Dir: dir}, "logs/")
+	c, err := Collect(s)
+
+	want := []ObjectRecord{
+		{Key: "logs/a", Offset: 0, Data: []byte("3")},
+		{Key: "logs/a", Offset: 2, Data: []byte("1")},
+		{Key: "logs/b", Offset: 0, Data: []byte("4")},
+	}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't ObjectLines`)
+	}
+}
+
+func TestShouldObjectLinesOnNoObjects(t *testing.T) {
+	s := ObjectLines(context.Background(), DirBucket{Dir: t.TempDir()}, "")
+	c, err := Collect(s)
+
+	if err != nil || len(c) != 0 {
+		t.Error(`Didn't ObjectLines on no objects`)
+	}
+}
+
+func TestShouldDirBucketOpenRange(t *testing.T) {
+	dir := writeObjects(t, map[string]string{"a": "3141"})
+
+	r, err := DirBucket{Dir: dir}.Open(context.Background(), "a", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(r)
+	r.Close()
+
+	if string(data) != "14" {
+		t.Error(`Didn't DirBucket open range`)
+	}
+}
+
+func TestShouldObjectLinesOnZeroValue(t *testing.T) {
+	var s *ObjectSource
+
+	eos, _, err := s.Resolve(func(v ObjectRecord) error { return nil })
+	zeos, _, zerr := (&ObjectSource{}).Resolve(func(v ObjectRecord) error { return nil })
+
+	if !eos || err != nil || !zeos || zerr != nil {
+		t.Error(`Didn't ObjectLines on zero value`)
+	}
+}
@@ -0,0 +1,154 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// firstViolation returns the position of the first element of `s` that is
+// not in order with its predecessor, according to `ordered`, or -1 if every
+// element is. It stops resolving `s` at the first violation.
+func firstViolation[T any](s Stream[T], ordered func(prev, v T) bool) (int, error) {
+	var prev T
+	i := 0
+	violation := -1
+	for {
+		eos, nxs, err := s.Resolve(func(v T) error {
+			if 0 < i && !ordered(prev, v) {
+				violation = i
+
+				return ErrStop
+			}
+
+			prev = v
+			i++
+
+			return nil
+		})
+		s = nxs
+		if eos || err != nil {
+			return violation, driverError(err)
+		}
+	}
+}
+
+// FirstUnsorted returns the position of the first element of `s` that is
+// less than its predecessor, or -1 if `s` is sorted.
+func FirstUnsorted[T constraints.Ordered](s Stream[T]) (int, error) {
+	return FirstUnsortedFunc(s, Ordered[T]())
+}
+
+// FirstUnsortedFunc is as `FirstUnsorted`, in the order of `cmp`.
+func FirstUnsortedFunc[T any](s Stream[T], cmp Compare[T]) (int, error) {
+	return firstViolation(s, func(prev, v T) bool { return cmp(prev, v) <= 0 })
+}
+
+// FirstNotIncreasing returns the position of the first element of `s` that
+// is not greater than its predecessor, or -1 if `s` is strictly increasing.
+func FirstNotIncreasing[T constraints.Ordered](s Stream[T]) (int, error) {
+	return FirstNotIncreasingFunc(s, Ordered[T]())
+}
+
+// FirstNotIncreasingFunc is as `FirstNotIncreasing`, in the order of `cmp`.
+func FirstNotIncreasingFunc[T any](s Stream[T], cmp Compare[T]) (int, error) {
+	return firstViolation(s, func(prev, v T) bool { return cmp(prev, v) < 0 })
+}
+
+// IsSorted reports whether the elements of `s` are in non decreasing order.
+// Use `FirstUnsorted` for the position of the first element out of order.
+func IsSorted[T constraints.Ordered](s Stream[T]) (bool, error) {
+	return IsSortedFunc(s, Ordered[T]())
+}
+
+// IsSortedFunc is as `IsSorted`, in the order of `cmp`.
+func IsSortedFunc[T any](s Stream[T], cmp Compare[T]) (bool, error) {
+	i, err := FirstUnsortedFunc(s, cmp)
+
+	return i < 0, err
+}
+
+// IsStrictlyIncreasing reports whether the elements of `s` are in strictly
+// increasing order.
+func IsStrictlyIncreasing[T constraints.Ordered](s Stream[T]) (bool, error) {
+	return IsStrictlyIncreasingFunc(s, Ordered[T]())
+}
+
+// IsStrictlyIncreasingFunc is as `IsStrictlyIncreasing`, in the order of
+// `cmp`.
+func IsStrictlyIncreasingFunc[T any](s Stream[T], cmp Compare[T]) (bool, error) {
+	i, err := FirstNotIncreasingFunc(s, cmp)
+
+	return i < 0, err
+}
+
+// A MonotonicPolicy determines what `EnforceMonotonic` does with elements
+// less than some preceding element.
+type MonotonicPolicy int
+
+const (
+	// MonotonicDrop drops the regressing elements.
+	MonotonicDrop MonotonicPolicy = iota
+	// MonotonicClamp replaces the regressing elements with the greatest
+	// preceding element.
+	MonotonicClamp
+	// MonotonicError fails with `ErrNotMonotonic` on a regressing element.
+	MonotonicError
+)
+
+// ErrNotMonotonic is the error reported by `EnforceMonotonic` on a
+// regressing element, under the `MonotonicError` policy.
+var ErrNotMonotonic = errors.New("streams: not monotonic")
+
+// A MonotonicEnforcer represents the non decreasing stream that results
+// from repairing the regressions of a given base stream, according to a
+// given policy.
+type MonotonicEnforcer[T any] struct {
+	base   Stream[T]
+	cmp    Compare[T]
+	policy MonotonicPolicy
+	high   T
+	seen   bool
+}
+
+func EnforceMonotonic[T constraints.Ordered](s Stream[T], policy MonotonicPolicy) Stream[T] {
+	return EnforceMonotonicFunc(s, Ordered[T](), policy)
+}
+
+// EnforceMonotonicFunc is as `EnforceMonotonic`, in the order of `cmp`.
+func EnforceMonotonicFunc[T any](s Stream[T], cmp Compare[T], policy MonotonicPolicy) Stream[T] {
+	return &MonotonicEnforcer[T]{base: s, cmp: cmp, policy: policy}
+}
+
+func (s *MonotonicEnforcer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		if !s.seen || s.cmp(s.high, v) <= 0 {
+			s.high = v
+			s.seen = true
+
+			return handled(h(v))
+		}
+
+		switch s.policy {
+		case MonotonicClamp:
+			return handled(h(s.high))
+		case MonotonicError:
+			return internal(fmt.Errorf("%w: %v after %v", ErrNotMonotonic, v, s.high))
+		}
+
+		return nil
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
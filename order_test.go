@@ -0,0 +1,113 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestShouldIsSorted(t *testing.T) {
+	ok, _ := IsSorted(NewFromSlice([]int{1, 1, 3, 4}))
+
+	if !ok {
+		t.Error(`Didn't IsSorted`)
+	}
+}
+
+func TestShouldIsSortedOnUnsorted(t *testing.T) {
+	ok, _ := IsSorted(NewFromSlice([]int{3, 1, 4}))
+
+	if ok {
+		t.Error(`Didn't IsSorted on unsorted`)
+	}
+}
+
+func TestShouldIsStrictlyIncreasing(t *testing.T) {
+	ok, _ := IsStrictlyIncreasing(NewFromSlice([]int{1, 3, 4}))
+	notOk, _ := IsStrictlyIncreasing(NewFromSlice([]int{1, 1, 3}))
+
+	if !ok || notOk {
+		t.Error(`Didn't IsStrictlyIncreasing`)
+	}
+}
+
+func TestShouldIsStrictlyIncreasingFunc(t *testing.T) {
+	desc := func(a, b int) int { return b - a }
+
+	ok, _ := IsStrictlyIncreasingFunc(NewFromSlice([]int{4, 3, 1}), desc)
+	notOk, _ := IsStrictlyIncreasingFunc(NewFromSlice([]int{3, 3, 1}), desc)
+
+	if !ok || notOk {
+		t.Error(`Didn't IsStrictlyIncreasingFunc`)
+	}
+}
+
+func TestShouldFirstUnsorted(t *testing.T) {
+	i, _ := FirstUnsorted(NewFromSlice([]int{1, 3, 4, 1, 5}))
+
+	if i != 3 {
+		t.Error(`Didn't FirstUnsorted`)
+	}
+}
+
+func TestShouldFirstUnsortedShortCircuit(t *testing.T) {
+	n := 0
+	s := Map(NewFromSlice([]int{3, 1, 4, 1, 5}), func(v int) (int, error) {
+		n++
+		return v, nil
+	})
+
+	FirstUnsorted(s)
+
+	if n != 2 {
+		t.Error(`Didn't FirstUnsorted short circuit`)
+	}
+}
+
+func TestShouldFirstNotIncreasingOnEmpty(t *testing.T) {
+	i, _ := FirstNotIncreasing(NewFromSlice([]int{}))
+
+	if i != -1 {
+		t.Error(`Didn't FirstNotIncreasing on empty`)
+	}
+}
+
+func TestShouldEnforceMonotonicDropping(t *testing.T) {
+	s := EnforceMonotonic(NewFromSlice([]int{3, 1, 4, 1, 5}), MonotonicDrop)
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 4, 5}) {
+		t.Error(`Didn't EnforceMonotonic dropping`)
+	}
+}
+
+func TestShouldEnforceMonotonicClamping(t *testing.T) {
+	s := EnforceMonotonic(NewFromSlice([]int{3, 1, 4, 1, 5}), MonotonicClamp)
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 3, 4, 4, 5}) {
+		t.Error(`Didn't EnforceMonotonic clamping`)
+	}
+}
+
+func TestShouldEnforceMonotonicFailing(t *testing.T) {
+	s := EnforceMonotonic(NewFromSlice([]int{3, 4, 1, 5}), MonotonicError)
+
+	c, err := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 4}) || !errors.Is(err, ErrNotMonotonic) {
+		t.Error(`Didn't EnforceMonotonic failing`)
+	}
+}
+
+func TestShouldEnforceMonotonicOnZeroValue(t *testing.T) {
+	s := &MonotonicEnforcer[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't EnforceMonotonic on zero value`)
+	}
+}
@@ -0,0 +1,116 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// A RetryAfterError is an error that tells how long to wait before retrying
+// the failed operation, as, e.g., a rate limited HTTP response would.
+type RetryAfterError interface {
+	error
+	RetryAfter() time.Duration
+}
+
+// A PageRetry decides, given the number of failed attempts so far and the
+// last error, whether to retry fetching a page, and after how long.
+type PageRetry func(attempt int, err error) (time.Duration, bool)
+
+// RetryRateLimited is a PageRetry that retries up to `max` times the fetches
+// failing with a RetryAfterError, after the delay it tells.
+func RetryRateLimited(max int) PageRetry {
+	return func(attempt int, err error) (time.Duration, bool) {
+		var r RetryAfterError
+		if attempt <= max && errors.As(err, &r) {
+			return r.RetryAfter(), true
+		}
+
+		return 0, false
+	}
+}
+
+// A Paginator represents the stream of the items of the pages fetched from
+// a cursor paginated source. The first page is fetched with the empty cursor,
+// and each page tells the cursor of the next, with the empty cursor after the
+// last page. Pages are fetched as the stream is resolved.
+type Paginator[T any] struct {
+	ctx    context.Context
+	fetch  func(ctx context.Context, cursor string) ([]T, string, error)
+	retry  PageRetry
+	cursor string
+	page   []T
+	last   bool
+}
+
+func Paginate[T any](ctx context.Context, fetch func(ctx context.Context, cursor string) (items []T, next string, err error)) Stream[T] {
+	return PaginateRetry(ctx, fetch, nil)
+}
+
+// PaginateRetry is as `Paginate`, but failed fetches are retried as decided
+// by `retry`.
+func PaginateRetry[T any](ctx context.Context, fetch func(ctx context.Context, cursor string) (items []T, next string, err error), retry PageRetry) Stream[T] {
+	return &Paginator[T]{ctx: ctx, fetch: fetch, retry: retry}
+}
+
+func (s *Paginator[T]) fetchPage() error {
+	for attempt := 1; ; attempt++ {
+		page, next, err := s.fetch(s.ctx, s.cursor)
+		if err == nil {
+			s.page = page
+			s.cursor = next
+			s.last = next == ""
+
+			return nil
+		}
+
+		if s.retry == nil {
+			return handled(err)
+		}
+
+		d, ok := s.retry(attempt, err)
+		if !ok {
+			return handled(err)
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return internal(s.ctx.Err())
+		case <-time.After(d):
+		}
+	}
+}
+
+func (s *Paginator[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.fetch == nil {
+		return true, s, nil
+	}
+
+	if len(s.page) == 0 {
+		if s.last {
+			return true, s, nil
+		}
+
+		err := s.fetchPage()
+		if err != nil {
+			s.fetch = nil
+
+			return true, s, err
+		}
+
+		// The page may be empty
+		return false, s, nil
+	}
+
+	head := s.page[0]
+	s.page = s.page[1:]
+
+	err := handled(h(head))
+	if err != nil {
+		s.fetch = nil
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
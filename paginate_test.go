@@ -0,0 +1,102 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// pages is a paginated source of the given pages, with the cursors being
+// the page numbers.
+func pages(pp [][]int) func(ctx context.Context, cursor string) ([]int, string, error) {
+	return func(ctx context.Context, cursor string) ([]int, string, error) {
+		i := 0
+		if cursor != "" {
+			i, _ = strconv.Atoi(cursor)
+		}
+
+		next := ""
+		if i+1 < len(pp) {
+			next = strconv.Itoa(i + 1)
+		}
+
+		return pp[i], next, nil
+	}
+}
+
+type rateLimited struct{}
+
+func (rateLimited) Error() string { return "rate limited" }
+
+func (rateLimited) RetryAfter() time.Duration { return time.Millisecond }
+
+func TestShouldPaginate(t *testing.T) {
+	s := Paginate(context.Background(), pages([][]int{{3, 1}, {}, {4}}))
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't Paginate`)
+	}
+}
+
+func TestShouldPaginateLazily(t *testing.T) {
+	fetches := 0
+	fetch := pages([][]int{{3, 1}, {4}})
+	s := Paginate(context.Background(), func(ctx context.Context, cursor string) ([]int, string, error) {
+		fetches++
+		return fetch(ctx, cursor)
+	})
+
+	CollectN(s, 2)
+
+	if fetches != 1 {
+		t.Error(`Didn't Paginate lazily`)
+	}
+}
+
+func TestShouldPaginateErrorOnFetchError(t *testing.T) {
+	e := errors.New("error")
+	s := Paginate(context.Background(), func(ctx context.Context, cursor string) ([]int, string, error) {
+		return nil, "", e
+	})
+
+	_, err := Collect(s)
+
+	if !errors.Is(err, e) {
+		t.Error(`Didn't Paginate error on fetch error`)
+	}
+}
+
+func TestShouldPaginateRetryRateLimited(t *testing.T) {
+	attempts := 0
+	fetch := pages([][]int{{3, 1}, {4}})
+	s := PaginateRetry(context.Background(), func(ctx context.Context, cursor string) ([]int, string, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, "", rateLimited{}
+		}
+		return fetch(ctx, cursor)
+	}, RetryRateLimited(2))
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't Paginate retry rate limited`)
+	}
+}
+
+func TestShouldPaginateRetryUpToMax(t *testing.T) {
+	s := PaginateRetry(context.Background(), func(ctx context.Context, cursor string) ([]int, string, error) {
+		return nil, "", rateLimited{}
+	}, RetryRateLimited(2))
+
+	_, err := Collect(s)
+
+	if !errors.Is(err, rateLimited{}) {
+		t.Error(`Didn't Paginate retry up to max`)
+	}
+}
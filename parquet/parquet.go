@@ -0,0 +1,148 @@
+// Package parquet streams the rows of Parquet files. It is a module of its
+// own, so that the Parquet dependency is only required by its users.
+package parquet
+
+import (
+	"errors"
+	"io"
+
+	parquetgo "github.com/parquet-go/parquet-go"
+
+	streams "github.com/jbelo/go-streams"
+)
+
+// ReadBatchSize is the number of rows read from a file at once.
+const ReadBatchSize = 64
+
+// A Reader represents the stream of the rows of a Parquet file, read into
+// values of type T, usually structs. Only the columns of the fields of T are
+// read, so that the struct type projects the columns of the file. The file
+// is opened on the first resolution.
+type Reader[T any] struct {
+	r    io.ReaderAt
+	size int64
+	rows *parquetgo.GenericReader[T]
+	buf  []T
+	next int
+	eof  bool
+}
+
+// ReadParquet is the stream of the rows of the Parquet file of `size` bytes
+// read from `r`.
+func ReadParquet[T any](r io.ReaderAt, size int64) streams.Stream[T] {
+	return &Reader[T]{r: r, size: size}
+}
+
+// classified reports whether the error `err` needs no classification, as in
+// the streams package.
+func classified(err error) bool {
+	if err == nil || errors.Is(err, streams.ErrStop) {
+		return true
+	}
+
+	var ie *streams.InternalError
+	var he *streams.HandlerError
+
+	return errors.As(err, &ie) || errors.As(err, &he)
+}
+
+// internal is the error `err` of reading the file, as an InternalError,
+// unless classified.
+func internal(err error) error {
+	if classified(err) {
+		return err
+	}
+
+	return &streams.InternalError{Err: err}
+}
+
+// handled is the error `err` of the handler, as a HandlerError, unless
+// classified.
+func handled(err error) error {
+	if classified(err) {
+		return err
+	}
+
+	return &streams.HandlerError{Err: err}
+}
+
+func (s *Reader[T]) open() error {
+	f, err := parquetgo.OpenFile(s.r, s.size)
+	if err != nil {
+		return err
+	}
+
+	s.rows = parquetgo.NewGenericReader[T](f)
+
+	return nil
+}
+
+// close closes the rows, ending the stream, which is not opened again.
+func (s *Reader[T]) close() error {
+	s.eof = true
+	s.buf = nil
+	s.r = nil
+
+	if s.rows == nil {
+		return nil
+	}
+
+	err := s.rows.Close()
+	s.rows = nil
+
+	return err
+}
+
+func (s *Reader[T]) fill() error {
+	if s.buf == nil {
+		s.buf = make([]T, ReadBatchSize)
+	}
+
+	n, err := s.rows.Read(s.buf[:cap(s.buf)])
+	s.buf = s.buf[:n]
+	s.next = 0
+
+	if errors.Is(err, io.EOF) {
+		s.eof = true
+		return nil
+	}
+
+	return err
+}
+
+func (s *Reader[T]) Resolve(h func(v T) error) (bool, streams.Stream[T], error) {
+	if s == nil || s.r == nil {
+		return true, s, nil
+	}
+
+	if s.rows == nil {
+		err := s.open()
+		if err != nil {
+			s.close()
+			return true, s, internal(err)
+		}
+	}
+
+	for s.next == len(s.buf) {
+		if s.eof {
+			return true, s, internal(s.close())
+		}
+
+		err := s.fill()
+		if err != nil {
+			s.close()
+			return true, s, internal(err)
+		}
+	}
+
+	v := s.buf[s.next]
+	s.next++
+
+	err := handled(h(v))
+	if err != nil {
+		s.close()
+		return true, s, err
+	}
+
+	return false, s, nil
+}
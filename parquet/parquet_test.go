@@ -0,0 +1,105 @@
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	parquetgo "github.com/parquet-go/parquet-go"
+
+	streams "github.com/jbelo/go-streams"
+)
+
+type reading struct {
+	Sensor string  `parquet:"sensor"`
+	Value  float64 `parquet:"value"`
+	Unit   string  `parquet:"unit"`
+}
+
+type value struct {
+	Value float64 `parquet:"value"`
+}
+
+func writeReadings(t *testing.T, n int) []byte {
+	rows := make([]reading, n)
+	for i := range rows {
+		rows[i] = reading{Sensor: "s", Value: float64(i), Unit: "C"}
+	}
+
+	var b bytes.Buffer
+	err := parquetgo.Write(&b, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b.Bytes()
+}
+
+func TestShouldReadParquet(t *testing.T) {
+	data := writeReadings(t, 3)
+
+	c, err := streams.Collect(ReadParquet[reading](bytes.NewReader(data), int64(len(data))))
+
+	want := []reading{{"s", 0, "C"}, {"s", 1, "C"}, {"s", 2, "C"}}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't ReadParquet`)
+	}
+}
+
+func TestShouldReadParquetProjected(t *testing.T) {
+	data := writeReadings(t, 2*ReadBatchSize+1)
+
+	c, err := streams.Collect(ReadParquet[value](bytes.NewReader(data), int64(len(data))))
+
+	if err != nil || len(c) != 2*ReadBatchSize+1 || c[ReadBatchSize] != (value{float64(ReadBatchSize)}) {
+		t.Error(`Didn't ReadParquet projected`)
+	}
+}
+
+func TestShouldReadParquetErrorOnInvalidFile(t *testing.T) {
+	data := []byte("not parquet")
+
+	_, err := streams.Collect(ReadParquet[value](bytes.NewReader(data), int64(len(data))))
+
+	if err == nil {
+		t.Error(`Didn't ReadParquet error on invalid file`)
+	}
+}
+
+func TestShouldReadParquetAfterEndOfStream(t *testing.T) {
+	data := writeReadings(t, 1)
+	var s streams.Stream[reading] = ReadParquet[reading](bytes.NewReader(data), int64(len(data)))
+
+	c, err := streams.Collect(s)
+	eos, _, rerr := s.Resolve(func(v reading) error { return nil })
+
+	if err != nil || len(c) != 1 || !eos || rerr != nil {
+		t.Error(`Didn't ReadParquet after end of stream`)
+	}
+}
+
+func TestShouldReadParquetOnZeroValue(t *testing.T) {
+	var s *Reader[reading]
+
+	eos, _, err := s.Resolve(func(v reading) error { return nil })
+	zeos, _, zerr := (&Reader[reading]{}).Resolve(func(v reading) error { return nil })
+
+	if !eos || err != nil || !zeos || zerr != nil {
+		t.Error(`Didn't ReadParquet on zero value`)
+	}
+}
+
+func TestShouldReadParquetClassifyErrors(t *testing.T) {
+	data := writeReadings(t, 2)
+	e := errors.New("error")
+
+	_, ierr := streams.Collect(ReadParquet[value](bytes.NewReader(data[:4]), 4))
+	_, _, herr := ReadParquet[value](bytes.NewReader(data), int64(len(data))).Resolve(func(v value) error { return e })
+
+	var ie *streams.InternalError
+	var he *streams.HandlerError
+	if !errors.As(ierr, &ie) || !errors.As(herr, &he) || !errors.Is(herr, e) {
+		t.Error(`Didn't ReadParquet classify errors`)
+	}
+}
@@ -0,0 +1,183 @@
+package streams
+
+import (
+	"bufio"
+	"container/list"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PartitionOptions are the options of a PartitionedSink.
+type PartitionOptions struct {
+	// MaxOpen is the maximum number of partition files open at once, or 0
+	// for no maximum. The least recently written partition is closed first.
+	MaxOpen int
+	// IdleTimeout is how long a partition file stays open without being
+	// written, or 0 to keep it open
+	IdleTimeout time.Duration
+	// Clock is the clock for the idle timeout, or nil for the system clock
+	Clock Clock
+}
+
+// A partitionFile is an open partition file.
+type partitionFile struct {
+	name    string
+	f       *os.File
+	w       *bufio.Writer
+	written time.Time
+}
+
+func (p *partitionFile) close() error {
+	err := p.w.Flush()
+	cerr := p.f.Close()
+	if err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+// A PartitionedSink is a Sink writing each element to the file of its
+// partition, named by a given partition function, relative to a given
+// directory. Partition files are appended to, so that a partition may be
+// closed and reopened as needed. The sink must be closed once done.
+type PartitionedSink[T any] struct {
+	dir       string
+	partition func(v T) string
+	encode    func(w io.Writer, v T) error
+	opts      PartitionOptions
+	// The open partitions, from the most to the least recently written
+	lru   *list.List
+	files map[string]*list.Element
+}
+
+func NewPartitionedSink[T any](dir string, partition func(v T) string, encode func(w io.Writer, v T) error, opts PartitionOptions) *PartitionedSink[T] {
+	if opts.Clock == nil {
+		opts.Clock = SystemClock
+	}
+
+	return &PartitionedSink[T]{
+		dir:       dir,
+		partition: partition,
+		encode:    encode,
+		opts:      opts,
+		lru:       list.New(),
+		files:     make(map[string]*list.Element),
+	}
+}
+
+func (p *PartitionedSink[T]) closeElement(e *list.Element) error {
+	f := p.lru.Remove(e).(*partitionFile)
+	delete(p.files, f.name)
+
+	return f.close()
+}
+
+// closeIdle closes the partitions not written since the idle timeout.
+func (p *PartitionedSink[T]) closeIdle(now time.Time) error {
+	if p.opts.IdleTimeout == 0 {
+		return nil
+	}
+
+	for e := p.lru.Back(); e != nil; e = p.lru.Back() {
+		if now.Sub(e.Value.(*partitionFile).written) < p.opts.IdleTimeout {
+			break
+		}
+
+		err := p.closeElement(e)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isLocalName is whether `name` is a relative path within its directory,
+// as `filepath.IsLocal`, which is not in Go 1.18.
+func isLocalName(name string) bool {
+	if name == "" || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return false
+	}
+
+	name = filepath.Clean(name)
+
+	return name != ".." && !strings.HasPrefix(name, ".."+string(filepath.Separator))
+}
+
+func (p *PartitionedSink[T]) open(name string) (*partitionFile, error) {
+	// A partition out of the directory, as of a name with "..", is rejected
+	if !isLocalName(name) {
+		return nil, fmt.Errorf("streams: partition %q out of the directory", name)
+	}
+
+	if e, ok := p.files[name]; ok {
+		p.lru.MoveToFront(e)
+
+		return e.Value.(*partitionFile), nil
+	}
+
+	if 0 < p.opts.MaxOpen && p.opts.MaxOpen <= p.lru.Len() {
+		err := p.closeElement(p.lru.Back())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	path := filepath.Join(p.dir, name)
+
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	pf := &partitionFile{name: name, f: f, w: bufio.NewWriter(f)}
+	p.files[name] = p.lru.PushFront(pf)
+
+	return pf, nil
+}
+
+func (p *PartitionedSink[T]) Send(v T) error {
+	now := p.opts.Clock.Now()
+
+	err := p.closeIdle(now)
+	if err != nil {
+		return err
+	}
+
+	name := p.partition(v)
+	if name == "" {
+		return fmt.Errorf("streams: empty partition for %v", v)
+	}
+
+	f, err := p.open(name)
+	if err != nil {
+		return err
+	}
+
+	f.written = now
+
+	return p.encode(f.w, v)
+}
+
+// Close closes all the open partitions.
+func (p *PartitionedSink[T]) Close() error {
+	var err error
+	for e := p.lru.Back(); e != nil; e = p.lru.Back() {
+		cerr := p.closeElement(e)
+		if err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
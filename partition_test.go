@@ -0,0 +1,100 @@
+package streams
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func writeInt(w io.Writer, v int) error {
+	_, err := fmt.Fprintln(w, v)
+	return err
+}
+
+func byParity(v int) string {
+	return filepath.Join("parity", strconv.Itoa(v%2))
+}
+
+func TestShouldPartitionedSink(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewPartitionedSink(dir, byParity, writeInt, PartitionOptions{})
+
+	SendAll[int](NewFromSlice([]int{3, 1, 4, 1, 5, 6}), sink)
+	sink.Close()
+
+	odd, _ := os.ReadFile(filepath.Join(dir, "parity", "1"))
+	even, _ := os.ReadFile(filepath.Join(dir, "parity", "0"))
+	if string(odd) != "3\n1\n1\n5\n" || string(even) != "4\n6\n" {
+		t.Error(`Didn't PartitionedSink`)
+	}
+}
+
+func TestShouldPartitionedSinkErrorOutOfDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "parts")
+	sink := NewPartitionedSink(dir, func(v int) string { return "../../x" }, writeInt, PartitionOptions{})
+
+	_, err := SendAll[int](NewFromSlice([]int{3}), sink)
+	sink.Close()
+
+	entries, _ := os.ReadDir(filepath.Dir(dir))
+	if err == nil || len(entries) != 0 {
+		t.Error(`Didn't PartitionedSink error out of dir`)
+	}
+}
+
+func TestShouldPartitionedSinkErrorOnAbsolutePath(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "parts")
+	abs := filepath.Join(t.TempDir(), "x")
+	sink := NewPartitionedSink(dir, func(v int) string { return abs }, writeInt, PartitionOptions{})
+
+	_, err := SendAll[int](NewFromSlice([]int{3}), sink)
+	sink.Close()
+
+	if _, serr := os.Stat(abs); err == nil || serr == nil {
+		t.Error(`Didn't PartitionedSink error on absolute path`)
+	}
+}
+
+func TestShouldPartitionedSinkMaxOpen(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewPartitionedSink(dir, byParity, writeInt, PartitionOptions{MaxOpen: 1})
+
+	SendAll[int](NewFromSlice([]int{3, 4, 1, 6}), sink)
+
+	if sink.lru.Len() != 1 {
+		t.Error(`Didn't PartitionedSink max open`)
+	}
+
+	sink.Close()
+	odd, _ := os.ReadFile(filepath.Join(dir, "parity", "1"))
+	even, _ := os.ReadFile(filepath.Join(dir, "parity", "0"))
+	if string(odd) != "3\n1\n" || string(even) != "4\n6\n" {
+		t.Error(`Didn't PartitionedSink reopen partitions`)
+	}
+}
+
+func TestShouldPartitionedSinkCloseIdle(t *testing.T) {
+	dir := t.TempDir()
+	clock := newManualClock(time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC))
+	sink := NewPartitionedSink(dir, byParity, writeInt, PartitionOptions{IdleTimeout: time.Minute, Clock: clock})
+
+	sink.Send(3)
+	clock.Advance(30 * time.Second)
+	sink.Send(4)
+	clock.Advance(40 * time.Second)
+	sink.Send(6)
+
+	if _, ok := sink.files[byParity(3)]; ok || sink.lru.Len() != 1 {
+		t.Error(`Didn't PartitionedSink close idle`)
+	}
+
+	odd, _ := os.ReadFile(filepath.Join(dir, "parity", "1"))
+	if string(odd) != "3\n" {
+		t.Error(`Didn't PartitionedSink flush idle`)
+	}
+	sink.Close()
+}
@@ -0,0 +1,142 @@
+package streams
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrPcapFormat is the error of malformed pcap files.
+var ErrPcapFormat = errors.New("streams: malformed pcap")
+
+// A Packet is a captured network packet. Data may be shorter than the
+// original Length of the packet, if the capture truncated it.
+type Packet struct {
+	Timestamp time.Time
+	Data      []byte
+	Length    int
+}
+
+// PacketStream is the stream of the packets returned by successive calls of
+// `next`, until it fails. Failing with `io.EOF` is the end of stream.
+func PacketStream(next func() (pkt []byte, ts time.Time, err error)) Stream[Packet] {
+	return FromRecv(func() (Packet, error) {
+		pkt, ts, err := next()
+
+		return Packet{Timestamp: ts, Data: pkt, Length: len(pkt)}, err
+	})
+}
+
+const (
+	pcapMagicMicros = 0xa1b2c3d4
+	pcapMagicNanos  = 0xa1b23c4d
+	// The largest packet accepted, as by libpcap
+	pcapMaxSnapLen = 256 * 1024
+)
+
+// A PcapReader reads the packets of a file in the classic libpcap format.
+// The file header is read along with the first packet.
+type PcapReader struct {
+	r        io.Reader
+	order    binary.ByteOrder
+	nanos    bool
+	linkType uint32
+	header   bool
+}
+
+func NewPcapReader(r io.Reader) *PcapReader {
+	return &PcapReader{r: r}
+}
+
+// LinkType is the link layer header type of the packets, once the file
+// header is read.
+func (p *PcapReader) LinkType() uint32 {
+	return p.linkType
+}
+
+func (p *PcapReader) readHeader() error {
+	var h [24]byte
+	_, err := io.ReadFull(p.r, h[:])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPcapFormat, err)
+	}
+
+	switch {
+	case binary.LittleEndian.Uint32(h[:]) == pcapMagicMicros:
+		p.order = binary.LittleEndian
+	case binary.LittleEndian.Uint32(h[:]) == pcapMagicNanos:
+		p.order, p.nanos = binary.LittleEndian, true
+	case binary.BigEndian.Uint32(h[:]) == pcapMagicMicros:
+		p.order = binary.BigEndian
+	case binary.BigEndian.Uint32(h[:]) == pcapMagicNanos:
+		p.order, p.nanos = binary.BigEndian, true
+	default:
+		return fmt.Errorf("%w: bad magic number", ErrPcapFormat)
+	}
+
+	p.linkType = p.order.Uint32(h[20:])
+	p.header = true
+
+	return nil
+}
+
+// ReadPacket reads the next packet, failing with `io.EOF` at the end of the
+// file.
+func (p *PcapReader) ReadPacket() (Packet, error) {
+	if !p.header {
+		err := p.readHeader()
+		if err != nil {
+			return Packet{}, err
+		}
+	}
+
+	var h [16]byte
+	n, err := io.ReadFull(p.r, h[:])
+	if n == 0 && err == io.EOF {
+		return Packet{}, io.EOF
+	}
+	if err != nil {
+		return Packet{}, fmt.Errorf("%w: truncated record header", ErrPcapFormat)
+	}
+
+	sec := int64(p.order.Uint32(h[0:]))
+	frac := int64(p.order.Uint32(h[4:]))
+	incl := p.order.Uint32(h[8:])
+	orig := p.order.Uint32(h[12:])
+
+	if pcapMaxSnapLen < incl {
+		return Packet{}, fmt.Errorf("%w: record of %d bytes", ErrPcapFormat, incl)
+	}
+
+	data := make([]byte, incl)
+	_, err = io.ReadFull(p.r, data)
+	if err != nil {
+		return Packet{}, fmt.Errorf("%w: truncated record", ErrPcapFormat)
+	}
+
+	if !p.nanos {
+		frac *= int64(time.Microsecond)
+	}
+
+	return Packet{Timestamp: time.Unix(sec, frac), Data: data, Length: int(orig)}, nil
+}
+
+// Next is as ReadPacket, in the form of the function of `PacketStream`.
+func (p *PcapReader) Next() ([]byte, time.Time, error) {
+	pkt, err := p.ReadPacket()
+
+	return pkt.Data, pkt.Timestamp, err
+}
+
+// ReadPcap is the stream of the packets of the pcap file read from `r`.
+func ReadPcap(r io.Reader) Stream[Packet] {
+	p := NewPcapReader(r)
+
+	return FromRecv(func() (Packet, error) {
+		pkt, err := p.ReadPacket()
+
+		return pkt, internal(err)
+	})
+}
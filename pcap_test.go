@@ -0,0 +1,71 @@
+package streams
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func pcapFile(order binary.ByteOrder, magic uint32, pkts ...Packet) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, order, []uint32{magic, 0x00040002, 0, 0, 65535, 1})
+	for _, p := range pkts {
+		frac := p.Timestamp.Nanosecond()
+		if magic == pcapMagicMicros {
+			frac /= 1000
+		}
+		binary.Write(&b, order, []uint32{uint32(p.Timestamp.Unix()), uint32(frac), uint32(len(p.Data)), uint32(p.Length)})
+		b.Write(p.Data)
+	}
+	return b.Bytes()
+}
+
+var pcapPackets = []Packet{
+	{Timestamp: time.Unix(3, 1000), Data: []byte{3, 1, 4}, Length: 3},
+	{Timestamp: time.Unix(5, 9000), Data: []byte{2, 6}, Length: 1500},
+}
+
+func TestShouldReadPcap(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		r := NewPcapReader(bytes.NewReader(pcapFile(order, pcapMagicMicros, pcapPackets...)))
+
+		c, err := Collect(FromRecv(r.ReadPacket))
+
+		if err != nil || !reflect.DeepEqual(c, pcapPackets) || r.LinkType() != 1 {
+			t.Error(`Didn't ReadPcap`, order)
+		}
+	}
+}
+
+func TestShouldReadPcapNanos(t *testing.T) {
+	pkts := []Packet{{Timestamp: time.Unix(3, 141592653), Data: []byte{1}, Length: 1}}
+
+	c, err := Collect(ReadPcap(bytes.NewReader(pcapFile(binary.LittleEndian, pcapMagicNanos, pkts...))))
+
+	if err != nil || !reflect.DeepEqual(c, pkts) {
+		t.Error(`Didn't ReadPcap nanos`)
+	}
+}
+
+func TestShouldReadPcapErrorOnTruncated(t *testing.T) {
+	data := pcapFile(binary.LittleEndian, pcapMagicMicros, pcapPackets...)
+
+	c, err := Collect(ReadPcap(bytes.NewReader(data[:len(data)-1])))
+
+	if !errors.Is(err, ErrPcapFormat) || len(c) != 1 {
+		t.Error(`Didn't ReadPcap error on truncated`)
+	}
+}
+
+func TestShouldPacketStream(t *testing.T) {
+	r := NewPcapReader(bytes.NewReader(pcapFile(binary.LittleEndian, pcapMagicMicros, pcapPackets...)))
+
+	c, err := Collect(PacketStream(r.Next))
+
+	if err != nil || len(c) != 2 || c[1].Length != 2 || !c[1].Timestamp.Equal(pcapPackets[1].Timestamp) {
+		t.Error(`Didn't PacketStream`)
+	}
+}
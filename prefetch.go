@@ -0,0 +1,208 @@
+package streams
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// PrefetchStats are the statistics of a prefetching stream, updated as it is
+// resolved. They tell on which side of the buffer the pipeline is waiting:
+// the consumer stalls on an empty buffer if the upstream is the bottleneck,
+// and the producer stalls on a full buffer if the downstream is.
+type PrefetchStats struct {
+	elements       int64
+	consumerStalls int64
+	consumerWait   int64
+	producerStalls int64
+	producerWait   int64
+	occupancy      int64
+	maxOccupancy   int64
+}
+
+// Elements counts the elements resolved from the buffer.
+func (p *PrefetchStats) Elements() int64 {
+	return atomic.LoadInt64(&p.elements)
+}
+
+// ConsumerStalls counts the resolutions that found the buffer empty.
+func (p *PrefetchStats) ConsumerStalls() int64 {
+	return atomic.LoadInt64(&p.consumerStalls)
+}
+
+// ConsumerWait is the time spent resolving on an empty buffer.
+func (p *PrefetchStats) ConsumerWait() time.Duration {
+	return time.Duration(atomic.LoadInt64(&p.consumerWait))
+}
+
+// ProducerStalls counts the elements that found the buffer full.
+func (p *PrefetchStats) ProducerStalls() int64 {
+	return atomic.LoadInt64(&p.producerStalls)
+}
+
+// ProducerWait is the time spent waiting to add elements to a full buffer.
+func (p *PrefetchStats) ProducerWait() time.Duration {
+	return time.Duration(atomic.LoadInt64(&p.producerWait))
+}
+
+// MeanOccupancy is the mean number of elements in the buffer, as found by
+// the resolutions.
+func (p *PrefetchStats) MeanOccupancy() float64 {
+	n := p.Elements()
+	if n == 0 {
+		return 0
+	}
+
+	return float64(atomic.LoadInt64(&p.occupancy)) / float64(n)
+}
+
+// MaxOccupancy is the greatest number of elements in the buffer, as found by
+// the resolutions.
+func (p *PrefetchStats) MaxOccupancy() int {
+	return int(atomic.LoadInt64(&p.maxOccupancy))
+}
+
+// A Prefetcher represents the stream of the elements of a given base stream,
+// which is resolved ahead, concurrently, into a buffer of a given depth.
+type Prefetcher[T any] struct {
+	base  Stream[T]
+	depth int
+	stats *PrefetchStats
+	in    chan resolution[T]
+	done  chan struct{}
+	// The base stream as left by its resolution, once stopped
+	rest    Stream[T]
+	stopped bool
+	acct    budgetShare
+}
+
+// Prefetch is the stream `s`, resolved ahead into a buffer of `depth`
+// elements, along with its statistics. A depth less than 1 is taken as 1, as
+// for `Buffered`.
+func Prefetch[T any](s Stream[T], depth int) (Stream[T], *PrefetchStats) {
+	if depth < 1 {
+		depth = 1
+	}
+
+	stats := &PrefetchStats{}
+
+	return &Prefetcher[T]{base: s, depth: depth, stats: stats}, stats
+}
+
+// send adds the resolution `r` to the buffer, unless done first.
+func (s *Prefetcher[T]) send(r resolution[T]) bool {
+	select {
+	case s.in <- r:
+		return true
+	default:
+	}
+
+	atomic.AddInt64(&s.stats.producerStalls, 1)
+	start := time.Now()
+	defer func() {
+		atomic.AddInt64(&s.stats.producerWait, int64(time.Since(start)))
+	}()
+
+	select {
+	case s.in <- r:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+func (s *Prefetcher[T]) produce(base Stream[T]) {
+	defer func() {
+		s.rest = base
+		close(s.in)
+	}()
+
+	for {
+		eos, nxs, err := base.Resolve(func(v T) error {
+			if !s.send(resolution[T]{v: v}) {
+				return ErrStop
+			}
+
+			return nil
+		})
+		base = nxs
+		if eos || err != nil {
+			s.send(resolution[T]{eos: true, err: driverError(err)})
+
+			return
+		}
+	}
+}
+
+func (s *Prefetcher[T]) stop() {
+	close(s.done)
+	s.stopped = true
+	s.acct.done()
+}
+
+// Close stops the resolution of the base stream, once the resolution in
+// progress, if any, is done, ending the stream.
+func (s *Prefetcher[T]) Close() error {
+	if s.in != nil {
+		if !s.stopped {
+			s.stop()
+		}
+		for range s.in {
+		}
+		s.base, s.in = s.rest, nil
+	}
+	s.stopped = true
+
+	return nil
+}
+
+func (s *Prefetcher[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil || s.stopped {
+		return true, s, nil
+	}
+
+	if s.in == nil {
+		err := s.acct.reserve("Prefetch", sizeOf[resolution[T]](s.depth))
+		if err != nil {
+			s.stopped = true
+
+			return true, s, err
+		}
+
+		s.in = make(chan resolution[T], s.depth)
+		s.done = make(chan struct{})
+		go s.produce(s.base)
+	}
+
+	occupancy := int64(len(s.in))
+
+	var r resolution[T]
+	select {
+	case r = <-s.in:
+	default:
+		atomic.AddInt64(&s.stats.consumerStalls, 1)
+		start := time.Now()
+		r = <-s.in
+		atomic.AddInt64(&s.stats.consumerWait, int64(time.Since(start)))
+	}
+
+	if r.eos {
+		s.stop()
+
+		return true, s, r.err
+	}
+
+	atomic.AddInt64(&s.stats.elements, 1)
+	atomic.AddInt64(&s.stats.occupancy, occupancy)
+	if atomic.LoadInt64(&s.stats.maxOccupancy) < occupancy {
+		atomic.StoreInt64(&s.stats.maxOccupancy, occupancy)
+	}
+
+	err := handled(h(r.v))
+	if err != nil {
+		s.stop()
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,75 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShouldPrefetch(t *testing.T) {
+	s, stats := Prefetch(NewFromSlice([]int{3, 1, 4, 1, 5}), 2)
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4, 1, 5}) || stats.Elements() != 5 {
+		t.Error(`Didn't Prefetch`)
+	}
+}
+
+func TestShouldPrefetchError(t *testing.T) {
+	s, _ := Prefetch(Map(NewFromSlice([]int{3, 1, 4, 1}), failingAt4), 2)
+
+	c, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't Prefetch error`)
+	}
+}
+
+func TestShouldPrefetchStatsProducerStalls(t *testing.T) {
+	s, stats := Prefetch(NewFromSlice([]int{3, 1, 4, 1, 5}), 1)
+	s = Map(s, func(v int) (int, error) {
+		time.Sleep(5 * time.Millisecond)
+		return v, nil
+	})
+
+	Collect(s)
+
+	if stats.ProducerStalls() == 0 || stats.ProducerWait() == 0 || stats.MaxOccupancy() != 1 {
+		t.Error(`Didn't Prefetch stats producer stalls`)
+	}
+}
+
+func TestShouldPrefetchStatsConsumerStalls(t *testing.T) {
+	base := Map(NewFromSlice([]int{3, 1, 4}), func(v int) (int, error) {
+		time.Sleep(5 * time.Millisecond)
+		return v, nil
+	})
+	s, stats := Prefetch(base, 4)
+
+	Collect(s)
+
+	if stats.ConsumerStalls() == 0 || stats.ConsumerWait() == 0 || stats.MeanOccupancy() > 1 {
+		t.Error(`Didn't Prefetch stats consumer stalls`)
+	}
+}
+
+func TestShouldPrefetchOnNegativeDepth(t *testing.T) {
+	s, _ := Prefetch(NewFromSlice([]int{3, 1, 4}), -1)
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't Prefetch on negative depth`)
+	}
+}
+
+func TestShouldPrefetchOnZeroValue(t *testing.T) {
+	s := &Prefetcher[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't Prefetch on zero value`)
+	}
+}
@@ -0,0 +1,172 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// A PubSubMessage is a message received from a Pub/Sub subscription.
+type PubSubMessage struct {
+	AckID           string
+	ID              string
+	Data            []byte
+	Attributes      map[string]string
+	PublishTime     time.Time
+	DeliveryAttempt int
+}
+
+// A PubSubReceiver receives messages from a Pub/Sub subscription, as a pull
+// client of a managed queue does. Messages not acknowledged before their
+// acknowledgment deadline are redelivered.
+type PubSubReceiver interface {
+	// Receive receives up to `max` messages, waiting for at least one
+	Receive(ctx context.Context, max int) ([]PubSubMessage, error)
+	Ack(ctx context.Context, ackIDs []string) error
+	Nack(ctx context.Context, ackIDs []string) error
+	// ExtendDeadline sets the deadline of the messages to `d` from now
+	ExtendDeadline(ctx context.Context, ackIDs []string, d time.Duration) error
+}
+
+// PubSubOptions are the options of a PubSubSource.
+type PubSubOptions struct {
+	// MaxOutstanding is the maximum number of messages received and not yet
+	// acknowledged, at least one
+	MaxOutstanding int
+	// AckDeadline is the acknowledgment deadline of the subscription. The
+	// deadline of the outstanding messages is extended by AckDeadline once
+	// half of it has passed, or never if 0.
+	AckDeadline time.Duration
+	// Clock is the clock for the deadlines, or nil for the system clock
+	Clock Clock
+}
+
+// A PubSubSource represents the stream of the messages received from a
+// Pub/Sub subscription. Each message is acknowledged once its handling
+// downstream succeeds, or negatively acknowledged if it fails, as are the
+// messages outstanding at the end of stream, which is when the context is
+// done.
+//
+// The deadlines of the outstanding messages are extended as the stream is
+// resolved, so that they are not redelivered while waiting to be handled.
+// An element whose handling outlasts the deadline may still be redelivered.
+type PubSubSource struct {
+	ctx      context.Context
+	receiver PubSubReceiver
+	opts     PubSubOptions
+	pending  []PubSubMessage
+	extended time.Time
+}
+
+func NewPubSubSource(ctx context.Context, receiver PubSubReceiver, opts PubSubOptions) *PubSubSource {
+	if opts.MaxOutstanding < 1 {
+		opts.MaxOutstanding = 1
+	}
+	if opts.Clock == nil {
+		opts.Clock = SystemClock
+	}
+
+	return &PubSubSource{ctx: ctx, receiver: receiver, opts: opts}
+}
+
+func ackIDs(ms []PubSubMessage) []string {
+	ids := make([]string, len(ms))
+	for i, m := range ms {
+		ids[i] = m.AckID
+	}
+
+	return ids
+}
+
+// close ends the stream, nacking the pending messages.
+func (s *PubSubSource) close() error {
+	var err error
+	if len(s.pending) != 0 {
+		err = s.receiver.Nack(context.Background(), ackIDs(s.pending))
+		s.pending = nil
+	}
+	s.receiver = nil
+
+	return err
+}
+
+func (s *PubSubSource) extend() error {
+	if s.opts.AckDeadline <= 0 || len(s.pending) == 0 {
+		return nil
+	}
+
+	now := s.opts.Clock.Now()
+	if now.Sub(s.extended) < s.opts.AckDeadline/2 {
+		return nil
+	}
+
+	s.extended = now
+
+	return s.receiver.ExtendDeadline(s.ctx, ackIDs(s.pending), s.opts.AckDeadline)
+}
+
+// Close nacks the pending messages, ending the stream.
+func (s *PubSubSource) Close() error {
+	if s.receiver == nil {
+		return nil
+	}
+
+	return s.close()
+}
+
+func (s *PubSubSource) Resolve(h func(v PubSubMessage) error) (bool, Stream[PubSubMessage], error) {
+	if s == nil || s.receiver == nil {
+		return true, s, nil
+	}
+
+	if s.ctx.Err() != nil {
+		return true, s, internal(s.close())
+	}
+
+	if len(s.pending) == 0 {
+		ms, err := s.receiver.Receive(s.ctx, s.opts.MaxOutstanding)
+		if err != nil {
+			if s.ctx.Err() != nil {
+				err = nil
+			}
+			s.close()
+
+			return true, s, internal(err)
+		}
+
+		s.pending = ms
+		s.extended = s.opts.Clock.Now()
+
+		return false, s, nil
+	}
+
+	err := s.extend()
+	if err != nil {
+		s.close()
+
+		return true, s, internal(err)
+	}
+
+	m := s.pending[0]
+	s.pending = s.pending[1:]
+
+	err = handled(h(m))
+	if err != nil {
+		nerr := s.receiver.Nack(s.ctx, []string{m.AckID})
+		if nerr != nil {
+			err = fmt.Errorf("%w (nack: %v)", err, nerr)
+		}
+		s.close()
+
+		return true, s, internal(err)
+	}
+
+	err = s.receiver.Ack(s.ctx, []string{m.AckID})
+	if err != nil {
+		s.close()
+
+		return true, s, internal(err)
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,121 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// A fakePubSub delivers its messages, recording their acknowledgment.
+type fakePubSub struct {
+	messages      []PubSubMessage
+	max           []int
+	acked, nacked []string
+	extended      [][]string
+}
+
+func (f *fakePubSub) Receive(ctx context.Context, max int) ([]PubSubMessage, error) {
+	f.max = append(f.max, max)
+	if len(f.messages) == 0 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	n := max
+	if len(f.messages) < n {
+		n = len(f.messages)
+	}
+	ms := f.messages[:n]
+	f.messages = f.messages[n:]
+	return ms, nil
+}
+
+func (f *fakePubSub) Ack(ctx context.Context, ids []string) error {
+	f.acked = append(f.acked, ids...)
+	return nil
+}
+
+func (f *fakePubSub) Nack(ctx context.Context, ids []string) error {
+	f.nacked = append(f.nacked, ids...)
+	return nil
+}
+
+func (f *fakePubSub) ExtendDeadline(ctx context.Context, ids []string, d time.Duration) error {
+	f.extended = append(f.extended, ids)
+	return nil
+}
+
+func pubSubMessages(ids ...string) []PubSubMessage {
+	var ms []PubSubMessage
+	for _, id := range ids {
+		ms = append(ms, PubSubMessage{AckID: id, Data: []byte(id)})
+	}
+	return ms
+}
+
+func TestShouldPubSubSource(t *testing.T) {
+	f := &fakePubSub{messages: pubSubMessages("3", "1", "4")}
+	s := NewPubSubSource(context.Background(), f, PubSubOptions{MaxOutstanding: 2})
+
+	c, _, err := CollectN[PubSubMessage](s, 3)
+
+	if err != nil || len(c) != 3 || !reflect.DeepEqual(f.acked, []string{"3", "1", "4"}) || !reflect.DeepEqual(f.max, []int{2, 2}) {
+		t.Error(`Didn't PubSubSource`)
+	}
+}
+
+func TestShouldPubSubSourceNackOnError(t *testing.T) {
+	f := &fakePubSub{messages: pubSubMessages("3", "4", "1")}
+	var s Stream[PubSubMessage] = NewPubSubSource(context.Background(), f, PubSubOptions{MaxOutstanding: 3})
+	s = Filter(s, func(m PubSubMessage) bool { return true })
+	s = Map(s, func(m PubSubMessage) (PubSubMessage, error) {
+		if m.AckID == "4" {
+			return m, errors.New("even")
+		}
+		return m, nil
+	})
+
+	_, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(f.acked, []string{"3"}) || !reflect.DeepEqual(f.nacked, []string{"4", "1"}) {
+		t.Error(`Didn't PubSubSource nack on error`)
+	}
+}
+
+func TestShouldPubSubSourceExtendDeadline(t *testing.T) {
+	clock := newManualClock(time.Unix(0, 0))
+	f := &fakePubSub{messages: pubSubMessages("3", "1", "4")}
+	var s Stream[PubSubMessage] = NewPubSubSource(context.Background(), f, PubSubOptions{
+		MaxOutstanding: 3,
+		AckDeadline:    10 * time.Second,
+		Clock:          clock,
+	})
+
+	h := func(m PubSubMessage) error {
+		clock.Advance(3 * time.Second)
+		return nil
+	}
+	for i := 0; i < 4; i++ {
+		_, s, _ = s.Resolve(h)
+	}
+
+	if !reflect.DeepEqual(f.extended, [][]string{{"4"}}) {
+		t.Error(`Didn't PubSubSource extend deadline`, f.extended)
+	}
+}
+
+func TestShouldPubSubSourceEndOnDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &fakePubSub{messages: pubSubMessages("3", "1")}
+	var s Stream[PubSubMessage] = NewPubSubSource(ctx, f, PubSubOptions{MaxOutstanding: 2})
+
+	c, s, _ := CollectN(s, 1)
+	cancel()
+	rest, err := Collect(s)
+
+	if err != nil || len(c) != 1 || len(rest) != 0 || !reflect.DeepEqual(f.nacked, []string{"1"}) {
+		t.Error(`Didn't PubSubSource end on done`)
+	}
+}
@@ -0,0 +1,53 @@
+package streams
+
+import (
+	"math/rand"
+	"time"
+)
+
+// A RandStream represents the infinite stream of the random values given by
+// a given function of a source of random numbers, as for test or simulation
+// data. A RandStream is not safe for concurrent use, as its source.
+type RandStream[T any] struct {
+	r   *rand.Rand
+	gen func(r *rand.Rand) T
+}
+
+func newRandStream[T any](r *rand.Rand, gen func(r *rand.Rand) T) Stream[T] {
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	return &RandStream[T]{r: r, gen: gen}
+}
+
+// NewRandStream is the stream of the values given by `gen` of a source seeded
+// with the current time.
+func NewRandStream[T any](gen func(r *rand.Rand) T) Stream[T] {
+	return newRandStream(nil, gen)
+}
+
+// NewRandIntStream is the stream of the random integers of `r` in [0, n), or
+// of a source seeded with the current time if `r` is nil.
+func NewRandIntStream(r *rand.Rand, n int) Stream[int] {
+	return newRandStream(r, func(r *rand.Rand) int { return r.Intn(n) })
+}
+
+// NewRandFloatStream is the stream of the random floats of `r` in [0, 1), or
+// of a source seeded with the current time if `r` is nil.
+func NewRandFloatStream(r *rand.Rand) Stream[float64] {
+	return newRandStream(r, (*rand.Rand).Float64)
+}
+
+func (s *RandStream[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.gen == nil {
+		return true, s, nil
+	}
+
+	err := handled(h(s.gen(s.r)))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
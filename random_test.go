@@ -0,0 +1,43 @@
+package streams
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestShouldStreamRandInts(t *testing.T) {
+	c, _ := Collect(Take(NewRandIntStream(rand.New(rand.NewSource(1)), 10), 100))
+	d, _ := Collect(Take(NewRandIntStream(rand.New(rand.NewSource(1)), 10), 100))
+
+	for _, v := range c {
+		if v < 0 || 10 <= v {
+			t.Error(`Didn't stream rand ints in range`)
+		}
+	}
+	if len(c) != 100 || !reflect.DeepEqual(c, d) {
+		t.Error(`Didn't stream rand ints`)
+	}
+}
+
+func TestShouldStreamRandFloats(t *testing.T) {
+	c, _ := Collect(Take(NewRandFloatStream(nil), 100))
+
+	for _, v := range c {
+		if v < 0 || 1 <= v {
+			t.Error(`Didn't stream rand floats`)
+		}
+	}
+}
+
+func TestShouldStreamRandValues(t *testing.T) {
+	coin := NewRandStream(func(r *rand.Rand) string { return []string{"heads", "tails"}[r.Intn(2)] })
+
+	c, _ := Collect(Take(coin, 50))
+
+	for _, v := range c {
+		if v != "heads" && v != "tails" {
+			t.Error(`Didn't stream rand values`)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package streams
+
+import (
+	"sync"
+	"time"
+)
+
+// A RateSample is the throughput of a stream from Start to End: the Count
+// of elements resolved in the meantime, and the resulting Rate, in elements
+// per second.
+type RateSample struct {
+	Start, End time.Time
+	Count      int64
+	Rate       float64
+}
+
+// rateWindow is the number of intervals for which the samples of a stream
+// are kept, for the stream of samples.
+const rateWindow = 10
+
+// rateSamples are the samples of the throughput of a stream, shared by the
+// measured stream and the stream of samples, as of the last window.
+type rateSamples struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	samples []RateSample
+	// The number of samples dropped, older than the window
+	dropped int
+	window  time.Duration
+	done    bool
+}
+
+func (r *rateSamples) add(start, end time.Time, count int64) {
+	sample := RateSample{Start: start, End: end, Count: count}
+	if d := end.Sub(start); 0 < d {
+		sample.Rate = float64(count) / d.Seconds()
+	}
+
+	r.mu.Lock()
+	r.samples = append(r.samples, sample)
+	i := 0
+	for i < len(r.samples)-1 && r.samples[i].End.Before(end.Add(-r.window)) {
+		i++
+	}
+	if 0 < i {
+		r.samples = append(r.samples[:0], r.samples[i:]...)
+		r.dropped += i
+	}
+	r.cond.Broadcast()
+	r.mu.Unlock()
+}
+
+func (r *rateSamples) end() {
+	r.mu.Lock()
+	r.done = true
+	r.cond.Broadcast()
+	r.mu.Unlock()
+}
+
+// A RateMeter represents the stream of the elements of a given base stream,
+// while measuring its throughput. A sample is taken at the first element
+// resolved after each interval, and at the end of stream. Samples older
+// than 10 intervals are dropped, and missed by a stream of samples resolved
+// later than that.
+type RateMeter[T any] struct {
+	base     Stream[T]
+	interval time.Duration
+	clock    Clock
+	samples  *rateSamples
+	start    time.Time
+	count    int64
+}
+
+func MeasureRate[T any](s Stream[T], interval time.Duration, clock Clock) (Stream[T], Stream[RateSample]) {
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	samples := &rateSamples{window: rateWindow * interval}
+	samples.cond = sync.NewCond(&samples.mu)
+
+	return &RateMeter[T]{base: s, interval: interval, clock: clock, samples: samples}, &RateSampleStream{samples: samples}
+}
+
+func (s *RateMeter[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if s.start.IsZero() {
+		s.start = s.clock.Now()
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		now := s.clock.Now()
+		if s.interval <= now.Sub(s.start) {
+			s.samples.add(s.start, now, s.count)
+			s.start = now
+			s.count = 0
+		}
+
+		s.count++
+
+		return handled(h(v))
+	})
+
+	s.base = nxs
+
+	if eos || err != nil {
+		s.samples.add(s.start, s.clock.Now(), s.count)
+		s.samples.end()
+	}
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+// A RateSampleStream represents the stream of the throughput samples of a
+// measured stream, as taken by `MeasureRate`. It ends once the measured
+// stream ends, and its resolution waits for the measured stream otherwise.
+type RateSampleStream struct {
+	samples *rateSamples
+	next    int
+}
+
+func (s *RateSampleStream) Resolve(h func(v RateSample) error) (bool, Stream[RateSample], error) {
+	if s == nil || s.samples == nil {
+		return true, s, nil
+	}
+
+	r := s.samples
+
+	r.mu.Lock()
+	for s.next == r.dropped+len(r.samples) && !r.done {
+		r.cond.Wait()
+	}
+	if s.next < r.dropped {
+		s.next = r.dropped
+	}
+	if s.next == r.dropped+len(r.samples) {
+		r.mu.Unlock()
+
+		return true, s, nil
+	}
+	sample := r.samples[s.next-r.dropped]
+	s.next++
+	r.mu.Unlock()
+
+	err := handled(h(sample))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
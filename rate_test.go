@@ -0,0 +1,69 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShouldMeasureRate(t *testing.T) {
+	clock := newManualClock(time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC))
+	base := Map(NewFromSlice([]int{3, 1, 4, 1, 5}), func(v int) (int, error) {
+		clock.Advance(500 * time.Millisecond)
+		return v, nil
+	})
+	s, rates := MeasureRate(base, time.Second, clock)
+
+	c, _ := Collect(s)
+	r, _ := Collect(rates)
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4, 1, 5}) {
+		t.Error(`Didn't MeasureRate pass through`)
+	}
+	if len(r) != 3 || r[0].Count != 1 || r[1].Count != 2 || r[1].Rate != 2 || r[2].Count != 2 {
+		t.Error(`Didn't MeasureRate`)
+	}
+}
+
+func TestShouldMeasureRateConcurrently(t *testing.T) {
+	s, rates := MeasureRate(NewFromSlice([]int{3, 1, 4}), time.Hour, SystemClock)
+
+	r := make(chan []RateSample)
+	go func() {
+		c, _ := Collect(rates)
+		r <- c
+	}()
+	Collect(s)
+
+	if c := <-r; len(c) != 1 || c[0].Count != 3 {
+		t.Error(`Didn't MeasureRate concurrently`)
+	}
+}
+
+func TestShouldMeasureRateDropOldSamples(t *testing.T) {
+	clock := newManualClock(time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC))
+	base := Map(NewFromSlice(make([]int, 100)), func(v int) (int, error) {
+		clock.Advance(time.Second)
+		return v, nil
+	})
+	s, rates := MeasureRate(base, time.Second, clock)
+
+	Collect(s)
+	held := len(s.(*RateMeter[int]).samples.samples)
+	r, _ := Collect(rates)
+
+	if 12 < held || len(r) != held || r[len(r)-1].End != clock.Now() {
+		t.Error(`Didn't MeasureRate drop old samples`)
+	}
+}
+
+func TestShouldMeasureRateOnNilClock(t *testing.T) {
+	s, rates := MeasureRate(NewFromSlice([]int{3, 1, 4}), time.Hour, nil)
+
+	c, _ := Collect(s)
+	r, _ := Collect(rates)
+
+	if len(c) != 3 || len(r) != 1 || r[0].Count != 3 {
+		t.Error(`Didn't MeasureRate on nil clock`)
+	}
+}
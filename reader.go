@@ -0,0 +1,122 @@
+package streams
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// defaultChunkSize is the size of the chunks of a StreamOfReaderChunks, if
+// not given.
+const defaultChunkSize = 32 * 1024
+
+// A StreamOfReaderChunks represents the stream of the chunks of bytes read
+// from a given reader, such as an HTTP response body, of at most a given
+// size each. Each chunk is newly allocated, so that it may be kept.
+type StreamOfReaderChunks struct {
+	r   io.Reader
+	buf []byte
+	err error
+}
+
+func NewFromReader(r io.Reader, bufSize int) Stream[[]byte] {
+	if bufSize < 1 {
+		bufSize = defaultChunkSize
+	}
+
+	return &StreamOfReaderChunks{r: r, buf: make([]byte, bufSize)}
+}
+
+func (s *StreamOfReaderChunks) Resolve(h func(v []byte) error) (bool, Stream[[]byte], error) {
+	if s == nil || s.r == nil {
+		return true, s, nil
+	}
+
+	if s.err != nil {
+		// The error that came along with the last chunk
+		err := s.err
+		s.r = nil
+		if errors.Is(err, io.EOF) {
+			return true, s, nil
+		}
+
+		return true, s, internal(err)
+	}
+
+	n, err := s.r.Read(s.buf)
+	s.err = err
+	if n == 0 {
+		return false, s, nil
+	}
+
+	chunk := make([]byte, n)
+	copy(chunk, s.buf[:n])
+
+	err = handled(h(chunk))
+	if err != nil {
+		s.r = nil
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// A StreamOfScanner represents the stream of the tokens scanned from a given
+// reader, such as lines or words, according to a given split function.
+type StreamOfScanner struct {
+	in     *bufio.Scanner
+	intern *Interner
+}
+
+// NewScannerStream is the stream of the tokens of `r`, as split by `split`,
+// of at most `maxTokenSize` bytes, or of the default maximum if 0. A longer
+// token fails the stream with `bufio.ErrTooLong`.
+func NewScannerStream(r io.Reader, split bufio.SplitFunc, maxTokenSize int) Stream[string] {
+	return NewScannerStreamInterned(r, split, maxTokenSize, nil)
+}
+
+// NewScannerStreamInterned is as `NewScannerStream`, with the tokens
+// interned by `in`.
+func NewScannerStreamInterned(r io.Reader, split bufio.SplitFunc, maxTokenSize int, in *Interner) Stream[string] {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(split)
+	if 0 < maxTokenSize {
+		size := 4096
+		if maxTokenSize < size {
+			size = maxTokenSize
+		}
+
+		scanner.Buffer(make([]byte, 0, size), maxTokenSize)
+	}
+
+	return &StreamOfScanner{in: scanner, intern: in}
+}
+
+// NewLinesFromReader is the stream of the lines of `r`, without their line
+// ending.
+func NewLinesFromReader(r io.Reader) Stream[string] {
+	return NewScannerStream(r, bufio.ScanLines, 0)
+}
+
+func (s *StreamOfScanner) Resolve(h func(v string) error) (bool, Stream[string], error) {
+	if s == nil || s.in == nil {
+		return true, s, nil
+	}
+
+	if !s.in.Scan() {
+		err := s.in.Err()
+		s.in = nil
+
+		return true, s, internal(err)
+	}
+
+	err := handled(h(scannedText(s.in, s.intern)))
+	if err != nil {
+		s.in = nil
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,84 @@
+package streams
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestShouldStreamFromReader(t *testing.T) {
+	c, err := Collect(NewFromReader(strings.NewReader("3.1415"), 4))
+
+	if err != nil || !reflect.DeepEqual(c, [][]byte{[]byte("3.14"), []byte("15")}) {
+		t.Error(`Didn't stream from reader`)
+	}
+}
+
+func TestShouldStreamFromReaderChunksToKeep(t *testing.T) {
+	c, _ := Collect(NewFromReader(iotest.OneByteReader(strings.NewReader("abc")), 0))
+
+	if !bytes.Equal(bytes.Join(c, nil), []byte("abc")) || len(c) != 3 {
+		t.Error(`Didn't stream from reader chunks to keep`)
+	}
+}
+
+func TestShouldStreamFromReaderError(t *testing.T) {
+	r := iotest.TimeoutReader(strings.NewReader("abc"))
+
+	c, err := Collect(NewFromReader(r, 2))
+
+	if !errors.Is(err, iotest.ErrTimeout) || !reflect.DeepEqual(c, [][]byte{[]byte("ab")}) {
+		t.Error(`Didn't stream from reader error`)
+	}
+}
+
+func TestShouldStreamFromReaderAlongWithError(t *testing.T) {
+	r := iotest.DataErrReader(strings.NewReader("abc"))
+
+	c, err := Collect(NewFromReader(r, 8))
+
+	if err != nil || !reflect.DeepEqual(c, [][]byte{[]byte("abc")}) {
+		t.Error(`Didn't stream from reader along with error`)
+	}
+}
+
+func TestShouldStreamLinesFromReader(t *testing.T) {
+	c, err := Collect(NewLinesFromReader(strings.NewReader("3\r\n1\n\n4")))
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "1", "", "4"}) {
+		t.Error(`Didn't stream lines from reader`)
+	}
+}
+
+func TestShouldStreamScannedWords(t *testing.T) {
+	c, err := Collect(NewScannerStream(strings.NewReader(" 3 1\n4  1 "), bufio.ScanWords, 0))
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "1", "4", "1"}) {
+		t.Error(`Didn't stream scanned words`)
+	}
+}
+
+func TestShouldStreamScannedLongLines(t *testing.T) {
+	long := strings.Repeat("x", 100000)
+
+	c, err := Collect(NewScannerStream(strings.NewReader(long+"\ny"), bufio.ScanLines, 200000))
+	_, short := Collect(NewScannerStream(strings.NewReader(long+"\ny"), bufio.ScanLines, 1000))
+
+	if err != nil || !reflect.DeepEqual(c, []string{long, "y"}) || !errors.Is(short, bufio.ErrTooLong) {
+		t.Error(`Didn't stream scanned long lines`)
+	}
+}
+
+func TestShouldStreamScannedInterned(t *testing.T) {
+	in := NewInterner(0)
+
+	c, _ := Collect(NewScannerStreamInterned(strings.NewReader("a b a"), bufio.ScanWords, 0, in))
+
+	if !reflect.DeepEqual(c, []string{"a", "b", "a"}) || in.Len() != 2 {
+		t.Error(`Didn't stream scanned interned`)
+	}
+}
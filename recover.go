@@ -0,0 +1,121 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+)
+
+// An ErrorResumer represents the stream of the elements of a given base
+// stream, which, on an error resolving the base stream, resumes with the
+// elements of the stream given for the error, such as a fallback source.
+// Errors of handling the elements downstream are not recovered from.
+type ErrorResumer[T any] struct {
+	base Stream[T]
+	next func(err error) Stream[T]
+}
+
+// OnErrorResume is the stream `s`, resumed on error with the stream that
+// `next` gives for the error, which may itself fail, and be resumed in turn.
+// The stream fails with the error if `next` gives a nil stream.
+func OnErrorResume[T any](s Stream[T], next func(err error) Stream[T]) Stream[T] {
+	return &ErrorResumer[T]{base: s, next: next}
+}
+
+func (s *ErrorResumer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	downstream := false
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		e := handled(h(v))
+		downstream = e != nil
+
+		return e
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		if downstream || errors.Is(err, ErrStop) {
+			return true, s, err
+		}
+
+		s.base = s.next(err)
+		if s.base == nil {
+			return true, s, err
+		}
+
+		return false, s, nil
+	}
+
+	return eos, s, nil
+}
+
+// An ErrorSkipper represents the stream of the elements of a given base
+// stream, which skips the errors resolving the base stream, reporting them,
+// and goes on resolving the base stream, as it was left by the failed
+// resolution, as when logging malformed lines. A stream that keeps failing,
+// such as a source that cannot be opened, is resolved until it stops
+// failing, unless it fails again with the same error, with no element in
+// between, when the stream fails with the error. Errors of handling the
+// elements downstream are not skipped.
+type ErrorSkipper[T any] struct {
+	base   Stream[T]
+	report func(err error)
+	// The stream that failed last, and its error, if no element since
+	failed Stream[T]
+	last   string
+}
+
+// SkipErrors is the stream `s`, with its errors passed to `report`, if not
+// nil, rather than ending the stream.
+func SkipErrors[T any](s Stream[T], report func(err error)) Stream[T] {
+	return &ErrorSkipper[T]{base: s, report: report}
+}
+
+func (s *ErrorSkipper[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	downstream := false
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		s.failed = nil
+		e := handled(h(v))
+		downstream = e != nil
+
+		return e
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		if downstream || errors.Is(err, ErrStop) {
+			return true, s, err
+		}
+
+		if s.failed != nil && sameStream(s.failed, nxs) && s.last == err.Error() {
+			return true, s, err
+		}
+		s.failed, s.last = nxs, err.Error()
+
+		if s.report != nil {
+			s.report(err)
+		}
+
+		return s.base == nil, s, nil
+	}
+
+	return eos, s, nil
+}
+
+// sameStream reports whether the streams `a` and `b` are the same stream
+// object.
+func sameStream[T any](a, b Stream[T]) bool {
+	if a == nil || b == nil || !reflect.TypeOf(a).Comparable() || reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+
+	return a == b
+}
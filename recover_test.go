@@ -0,0 +1,109 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func atois(lines ...string) Stream[int] {
+	return Map(NewFromSlice(lines), strconv.Atoi)
+}
+
+func TestShouldOnErrorResume(t *testing.T) {
+	var cause error
+	s := OnErrorResume(atois("3", "x", "4"), func(err error) Stream[int] {
+		cause = err
+
+		return NewFromSlice([]int{1, 5})
+	})
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 5}) || cause == nil {
+		t.Error(`Didn't OnErrorResume`)
+	}
+}
+
+func TestShouldOnErrorResumeFailing(t *testing.T) {
+	resumes := 0
+	s := OnErrorResume(atois("3", "x"), func(err error) Stream[int] {
+		resumes++
+		if 1 < resumes {
+			return nil
+		}
+
+		return atois("1", "y")
+	})
+
+	c, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 1}) || resumes != 2 {
+		t.Error(`Didn't OnErrorResume failing`)
+	}
+}
+
+func TestShouldOnErrorResumeNotHandlerErrors(t *testing.T) {
+	failure := errors.New("failed")
+	s := OnErrorResume(atois("3"), func(err error) Stream[int] { return NewFromSlice([]int{1}) })
+
+	_, err := SendAll[int](s, SinkFunc[int](func(v int) error { return failure }))
+
+	if !errors.Is(err, failure) {
+		t.Error(`Didn't OnErrorResume not handler errors`)
+	}
+}
+
+func TestShouldSkipErrors(t *testing.T) {
+	var errs []error
+	s := SkipErrors(atois("3", "x", "1", "y", "4"), func(err error) { errs = append(errs, err) })
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) || len(errs) != 2 {
+		t.Error(`Didn't SkipErrors`)
+	}
+}
+
+func TestShouldSkipErrorsUnreported(t *testing.T) {
+	c, err := Collect(SkipErrors(atois("x", "3"), nil))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3}) {
+		t.Error(`Didn't SkipErrors unreported`)
+	}
+}
+
+func TestShouldSkipErrorsNotHandlerErrors(t *testing.T) {
+	failure := errors.New("failed")
+
+	_, err := SendAll[int](SkipErrors(atois("3"), nil), SinkFunc[int](func(v int) error { return failure }))
+
+	if !errors.Is(err, failure) {
+		t.Error(`Didn't SkipErrors not handler errors`)
+	}
+}
+
+func TestShouldSkipErrorsEndOnRepeatedError(t *testing.T) {
+	skips := 0
+	s := SkipErrors(NewStreamOfFileLines("testdata/missing"), func(err error) { skips++ })
+
+	_, err := Collect(s)
+
+	if !errors.Is(err, fs.ErrNotExist) || skips != 1 {
+		t.Error(`Didn't SkipErrors end on repeated error`)
+	}
+}
+
+func TestShouldSkipErrorsStopOnWrappedStop(t *testing.T) {
+	skips := 0
+	s := SkipErrors[int](&flakyStream{elems: []int{3, 1}, failures: []int{0, 1}, err: fmt.Errorf("done: %w", ErrStop)}, func(err error) { skips++ })
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3}) || skips != 0 {
+		t.Error(`Didn't SkipErrors stop on wrapped stop`)
+	}
+}
@@ -0,0 +1,42 @@
+package streams
+
+import (
+	"errors"
+	"io"
+)
+
+// A Receiver represents the stream of the values received by successive
+// calls of a given function, until it fails. Failing with `io.EOF` is the
+// end of stream, as for the `Recv` method of a gRPC stream.
+type Receiver[T any] struct {
+	recv func() (T, error)
+}
+
+func FromRecv[T any](recv func() (T, error)) Stream[T] {
+	return &Receiver[T]{recv: recv}
+}
+
+func (s *Receiver[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.recv == nil {
+		return true, s, nil
+	}
+
+	v, err := s.recv()
+	if err != nil {
+		s.recv = nil
+		if errors.Is(err, io.EOF) {
+			return true, s, nil
+		}
+
+		return true, s, handled(err)
+	}
+
+	err = handled(h(v))
+	if err != nil {
+		s.recv = nil
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,47 @@
+package streams
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func recvFrom(elems []int, end error) func() (int, error) {
+	return func() (int, error) {
+		if len(elems) == 0 {
+			return 0, end
+		}
+		v := elems[0]
+		elems = elems[1:]
+		return v, nil
+	}
+}
+
+func TestShouldFromRecv(t *testing.T) {
+	c, err := Collect(FromRecv(recvFrom([]int{3, 1, 4}, io.EOF)))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't FromRecv`)
+	}
+}
+
+func TestShouldFromRecvErrorOnError(t *testing.T) {
+	e := errors.New("error")
+
+	c, err := Collect(FromRecv(recvFrom([]int{3, 1}, e)))
+
+	if !errors.Is(err, e) || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't FromRecv error on error`)
+	}
+}
+
+func TestShouldFromRecvOnZeroValue(t *testing.T) {
+	s := &Receiver[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't FromRecv on zero value`)
+	}
+}
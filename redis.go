@@ -0,0 +1,156 @@
+package streams
+
+import (
+	"context"
+	"time"
+)
+
+// A RedisEntry is an entry of a Redis stream.
+type RedisEntry struct {
+	ID     string
+	Values map[string]any
+}
+
+// RedisReadArgs are the arguments of a read from a Redis stream.
+type RedisReadArgs struct {
+	Stream string
+	// ID is the ID after which to read, or ">" when reading for a group
+	ID string
+	// Group and Consumer are empty, unless reading for a consumer group
+	Group, Consumer string
+	Count           int64
+	Block           time.Duration
+}
+
+// A RedisStreamClient is the Redis client used by a RedisStreamSource. It
+// is meant to be a thin adapter over any Redis library.
+type RedisStreamClient interface {
+	// XRead reads entries, as XREADGROUP if a group is given, and as XREAD
+	// otherwise. It returns no entries if the read timed out.
+	XRead(ctx context.Context, args RedisReadArgs) ([]RedisEntry, error)
+	// XAck acknowledges the processing of the entries with the given IDs
+	XAck(ctx context.Context, stream, group string, ids ...string) error
+}
+
+// RedisStreamOptions are the options of a RedisStreamSource.
+type RedisStreamOptions struct {
+	Stream string
+	// Group and Consumer are those of the consumer group to read for, if any
+	Group, Consumer string
+	// Start is the ID after which to start reading, when not reading for a
+	// consumer group, or empty for only new entries
+	Start string
+	// Count is the maximum number of entries per read, or 0 for 1
+	Count int64
+	// Block is how long a read waits for entries
+	Block time.Duration
+	// OnAck is called for each entry processed successfully downstream,
+	// once acknowledged when reading for a consumer group
+	OnAck func(e RedisEntry)
+	// OnNack is called for each entry which failed processing downstream.
+	// Such entries are not acknowledged, and so remain pending in the group
+	OnNack func(e RedisEntry, err error)
+}
+
+// A RedisStreamSource represents the stream of the entries read from a Redis
+// stream. When reading for a consumer group, each entry is acknowledged once
+// its handling downstream succeeds. The stream ends when the context is done.
+type RedisStreamSource struct {
+	ctx     context.Context
+	client  RedisStreamClient
+	opts    RedisStreamOptions
+	last    string
+	entries []RedisEntry
+}
+
+func NewRedisStreamSource(ctx context.Context, client RedisStreamClient, opts RedisStreamOptions) *RedisStreamSource {
+	last := opts.Start
+	if last == "" {
+		last = "$"
+	}
+
+	return &RedisStreamSource{ctx: ctx, client: client, opts: opts, last: last}
+}
+
+func (s *RedisStreamSource) read() error {
+	args := RedisReadArgs{
+		Stream:   s.opts.Stream,
+		ID:       s.last,
+		Group:    s.opts.Group,
+		Consumer: s.opts.Consumer,
+		Count:    s.opts.Count,
+		Block:    s.opts.Block,
+	}
+	if args.Group != "" {
+		args.ID = ">"
+	}
+	if args.Count == 0 {
+		args.Count = 1
+	}
+
+	entries, err := s.client.XRead(s.ctx, args)
+	if err != nil {
+		return err
+	}
+
+	s.entries = entries
+	if len(entries) != 0 {
+		s.last = entries[len(entries)-1].ID
+	}
+
+	return nil
+}
+
+func (s *RedisStreamSource) Resolve(h func(v RedisEntry) error) (bool, Stream[RedisEntry], error) {
+	if s == nil || s.client == nil {
+		return true, s, nil
+	}
+
+	if s.ctx.Err() != nil {
+		s.client = nil
+
+		return true, s, nil
+	}
+
+	if len(s.entries) == 0 {
+		err := s.read()
+		if err != nil {
+			s.client = nil
+			if s.ctx.Err() != nil {
+				return true, s, nil
+			}
+
+			return true, s, internal(err)
+		}
+
+		return false, s, nil
+	}
+
+	e := s.entries[0]
+	s.entries = s.entries[1:]
+
+	err := handled(h(e))
+	if err != nil {
+		if s.opts.OnNack != nil {
+			s.opts.OnNack(e, err)
+		}
+		s.client = nil
+
+		return true, s, internal(err)
+	}
+
+	if s.opts.Group != "" {
+		err = s.client.XAck(s.ctx, s.opts.Stream, s.opts.Group, e.ID)
+		if err != nil {
+			s.client = nil
+
+			return true, s, internal(err)
+		}
+	}
+
+	if s.opts.OnAck != nil {
+		s.opts.OnAck(e)
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,110 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// A fakeRedis is a RedisStreamClient over a single stream with the given
+// number of entries, with IDs 1 to n.
+type fakeRedis struct {
+	n     int
+	reads []RedisReadArgs
+	acked []string
+}
+
+func (r *fakeRedis) XRead(ctx context.Context, args RedisReadArgs) ([]RedisEntry, error) {
+	r.reads = append(r.reads, args)
+
+	from := 0
+	if args.ID == ">" {
+		from = len(r.reads) - 1
+	} else if args.ID != "$" {
+		from, _ = strconv.Atoi(args.ID)
+	}
+
+	var entries []RedisEntry
+	for i := from + 1; i <= r.n && len(entries) < int(args.Count); i++ {
+		entries = append(entries, RedisEntry{ID: strconv.Itoa(i), Values: map[string]any{"v": i}})
+	}
+
+	return entries, nil
+}
+
+func (r *fakeRedis) XAck(ctx context.Context, stream, group string, ids ...string) error {
+	r.acked = append(r.acked, ids...)
+	return nil
+}
+
+func entryIDs(s Stream[RedisEntry], n int) []string {
+	c, _, _ := CollectN(Map(s, func(e RedisEntry) (string, error) { return e.ID, nil }), n)
+	return c
+}
+
+func TestShouldRedisStreamSource(t *testing.T) {
+	r := &fakeRedis{n: 3}
+	s := NewRedisStreamSource(context.Background(), r, RedisStreamOptions{Stream: "s", Start: "0", Count: 2})
+
+	c := entryIDs(s, 3)
+
+	if !reflect.DeepEqual(c, []string{"1", "2", "3"}) || r.reads[1].ID != "2" {
+		t.Error(`Didn't RedisStreamSource`)
+	}
+	if len(r.acked) != 0 {
+		t.Error(`Didn't RedisStreamSource without acknowledging`)
+	}
+}
+
+func TestShouldRedisStreamSourceAcknowledge(t *testing.T) {
+	r := &fakeRedis{n: 3}
+	var acked []string
+	s := NewRedisStreamSource(context.Background(), r, RedisStreamOptions{
+		Stream: "s", Group: "g", Consumer: "c",
+		OnAck: func(e RedisEntry) { acked = append(acked, e.ID) },
+	})
+
+	c := entryIDs(s, 2)
+
+	if !reflect.DeepEqual(c, []string{"1", "2"}) || r.reads[0].ID != ">" || r.reads[0].Group != "g" {
+		t.Error(`Didn't RedisStreamSource for group`)
+	}
+	if !reflect.DeepEqual(r.acked, []string{"1", "2"}) || !reflect.DeepEqual(acked, r.acked) {
+		t.Error(`Didn't RedisStreamSource acknowledge`)
+	}
+}
+
+func TestShouldRedisStreamSourceNotAcknowledgeFailed(t *testing.T) {
+	r := &fakeRedis{n: 3}
+	var nacked []string
+	e := errors.New("error")
+	s := Map[RedisEntry](NewRedisStreamSource(context.Background(), r, RedisStreamOptions{
+		Stream: "s", Group: "g", Consumer: "c",
+		OnNack: func(e RedisEntry, err error) { nacked = append(nacked, e.ID) },
+	}), func(v RedisEntry) (RedisEntry, error) {
+		if v.ID == "2" {
+			return v, e
+		}
+		return v, nil
+	})
+
+	_, err := Collect(s)
+
+	if !errors.Is(err, e) || !reflect.DeepEqual(r.acked, []string{"1"}) || !reflect.DeepEqual(nacked, []string{"2"}) {
+		t.Error(`Didn't RedisStreamSource not acknowledge failed`)
+	}
+}
+
+func TestShouldRedisStreamSourceUntilDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := NewRedisStreamSource(ctx, &fakeRedis{n: 3}, RedisStreamOptions{Stream: "s"})
+
+	c, err := Collect[RedisEntry](s)
+
+	if len(c) != 0 || err != nil {
+		t.Error(`Didn't RedisStreamSource until done`)
+	}
+}
@@ -0,0 +1,109 @@
+package streams
+
+import (
+	"container/heap"
+	"time"
+)
+
+// A Reorderer represents the stream of the elements of a given nearly
+// sorted base stream, in time order. Each element is held until an element
+// later by at least a given maximum delay comes, or until the end of the base
+// stream, so that elements out of order by no more than the delay come in
+// order. Elements later than that still come, as soon as possible.
+type Reorderer[T any] struct {
+	base     Stream[T]
+	ts       func(v T) time.Time
+	maxDelay time.Duration
+	heap     mergeHeap[T]
+	seq      int
+	high     time.Time
+	acct     budgetShare
+}
+
+func Reorder[T any](s Stream[T], ts func(v T) time.Time, maxDelay time.Duration) Stream[T] {
+	cmp := func(a, b T) int {
+		ta, tb := ts(a), ts(b)
+		switch {
+		case ta.Before(tb):
+			return -1
+		case tb.Before(ta):
+			return 1
+		}
+
+		return 0
+	}
+
+	return &Reorderer[T]{base: s, ts: ts, maxDelay: maxDelay, heap: mergeHeap[T]{cmp: cmp}}
+}
+
+// ready reports whether the earliest held element is due.
+func (s *Reorderer[T]) ready() bool {
+	if s.heap.Len() == 0 {
+		return false
+	}
+
+	return s.base == nil || !s.high.Add(-s.maxDelay).Before(s.ts(s.heap.heads[0].v))
+}
+
+func (s *Reorderer[T]) pop(h func(v T) error) error {
+	head := heap.Pop(&s.heap).(mergeHead[T])
+	s.acct.release(sizeOf[T](1))
+
+	return handled(h(head.v))
+}
+
+func (s *Reorderer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil {
+		return true, s, nil
+	}
+
+	if s.ready() {
+		err := s.pop(h)
+		if err != nil {
+			s.acct.done()
+
+			return true, s, err
+		}
+
+		return false, s, nil
+	}
+
+	if s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		e := s.acct.reserve("Reorder", sizeOf[T](1))
+		if e != nil {
+			return e
+		}
+
+		if t := s.ts(v); t.After(s.high) {
+			s.high = t
+		}
+		heap.Push(&s.heap, mergeHead[T]{v: v, i: s.seq})
+		s.seq++
+
+		if s.ready() {
+			return s.pop(h)
+		}
+
+		return nil
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		s.acct.done()
+
+		return true, s, err
+	}
+
+	if eos {
+		s.base = nil
+
+		return s.heap.Len() == 0, s, nil
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,41 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShouldReorder(t *testing.T) {
+	at := func(l logLine) time.Time { return l.at }
+	s := Reorder(logLines("a1s", "b3s", "c2s", "d4s", "e9s", "f6s", "g8s", "h7s"), at, 2*time.Second)
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(logMsgs(c), []string{"a1s", "c2s", "b3s", "d4s", "f6s", "h7s", "g8s", "e9s"}) {
+		t.Error(`Didn't Reorder`, logMsgs(c))
+	}
+}
+
+func TestShouldReorderLateElementsAsap(t *testing.T) {
+	at := func(l logLine) time.Time { return l.at }
+	s := Reorder(logLines("a5s", "b8s", "c1s", "d9s"), at, time.Second)
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(logMsgs(c), []string{"a5s", "c1s", "b8s", "d9s"}) {
+		t.Error(`Didn't Reorder late elements asap`, logMsgs(c))
+	}
+}
+
+func TestShouldReorderWithinBudget(t *testing.T) {
+	at := func(l logLine) time.Time { return l.at }
+	budget := NewMemoryBudget(sizeOf[logLine](2))
+	s := WithMemoryBudget(Reorder(logLines("a1s", "b2s", "c3s", "d4s"), at, time.Hour), budget)
+
+	_, err := Collect(s)
+
+	if err == nil || budget.Used() != 0 {
+		t.Error(`Didn't Reorder within budget`)
+	}
+}
@@ -0,0 +1,73 @@
+package streams
+
+// A Result holds either a value of type T, or the error that prevented
+// producing it.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+func (r Result[T]) Get() (T, error) {
+	return r.Value, r.Err
+}
+
+// A Resulter represents the stream of the elements of a given base stream,
+// as results. An error resolving the base stream is not propagated, but
+// materialized as a last, failed, result instead.
+type Resulter[T any] struct {
+	base Stream[T]
+	done bool
+}
+
+func Results[T any](s Stream[T]) Stream[Result[T]] {
+	return &Resulter[T]{base: s}
+}
+
+func (s *Resulter[T]) Resolve(h func(v Result[T]) error) (bool, Stream[Result[T]], error) {
+	if s == nil || s.base == nil || s.done {
+		return true, s, nil
+	}
+
+	downstream := false
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		e := handled(h(Result[T]{Value: v}))
+		downstream = e != nil
+
+		return e
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		if downstream {
+			return true, s, err
+		}
+
+		s.done = true
+
+		err = handled(h(Result[T]{Err: err}))
+		if err != nil {
+			return true, s, err
+		}
+
+		return false, s, nil
+	}
+
+	return eos, s, nil
+}
+
+// TryMap is as `Map`, but materializes the errors of `f` as failed results,
+// rather than terminating the stream.
+func TryMap[T, U any](s Stream[T], f func(T) (U, error)) Stream[Result[U]] {
+	return Map(s, func(v T) (Result[U], error) {
+		u, err := f(v)
+
+		return Result[U]{Value: u, Err: err}, nil
+	})
+}
+
+// Unwrap is the stream of the values of the results in `s`, which fails on
+// the first failed result.
+func Unwrap[T any](s Stream[Result[T]]) Stream[T] {
+	return Map(s, Result[T].Get)
+}
@@ -0,0 +1,72 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestShouldResults(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4})
+
+	c, _ := Collect(Results(s))
+
+	if !reflect.DeepEqual(c, []Result[int]{{Value: 3}, {Value: 1}, {Value: 4}}) {
+		t.Error(`Didn't Results`)
+	}
+}
+
+func TestShouldResultsMaterializeError(t *testing.T) {
+	s := Map(NewFromSlice([]int{3, 1, 4, 1}), failingAt4)
+
+	c, err := Collect(Results(s))
+
+	if err != nil || len(c) != 3 || c[1].Value != 1 || c[2].Err == nil {
+		t.Error(`Didn't Results materialize error`)
+	}
+}
+
+func TestShouldResultsPropagateDownstreamError(t *testing.T) {
+	s := Results(NewFromSlice([]int{3, 1, 4}))
+	s = Map(s, func(r Result[int]) (Result[int], error) {
+		return r, fmt.Errorf("error")
+	})
+
+	c, err := Collect(s)
+
+	if err == nil || len(c) != 0 {
+		t.Error(`Didn't Results propagate downstream error`)
+	}
+}
+
+func TestShouldTryMap(t *testing.T) {
+	s := TryMap(NewFromSlice([]int{3, 4, 1}), failingAt4)
+
+	c, err := Collect(s)
+
+	if err != nil || len(c) != 3 || c[1].Err == nil || c[2].Value != 1 {
+		t.Error(`Didn't TryMap`)
+	}
+}
+
+func TestShouldUnwrap(t *testing.T) {
+	e := errors.New("error")
+	s := NewFromSlice([]Result[int]{{Value: 3}, {Value: 1}, {Err: e}, {Value: 4}})
+
+	c, err := Collect(Unwrap(s))
+
+	if !reflect.DeepEqual(c, []int{3, 1}) || !errors.Is(err, e) {
+		t.Error(`Didn't Unwrap`)
+	}
+}
+
+func TestShouldResultsOnZeroValue(t *testing.T) {
+	s := &Resulter[int]{}
+
+	eos, _, _ := s.Resolve(func(v Result[int]) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't Results on zero value`)
+	}
+}
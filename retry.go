@@ -0,0 +1,126 @@
+package streams
+
+import (
+	"errors"
+	"time"
+)
+
+// defaultRetryBackoff is the wait before the first retry of a `Retrier`,
+// unless told otherwise.
+const defaultRetryBackoff = 100 * time.Millisecond
+
+// A RetryPolicy determines which failures of a stream `Retry` retries, and
+// how long it waits before each retry.
+type RetryPolicy struct {
+	// MaxAttempts is the number of consecutive failed attempts after which
+	// a failure is final, or 0 for no limit
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry of a failure, or 0
+	// for 100ms
+	InitialBackoff time.Duration
+	// MaxBackoff is the longest wait, or 0 for no limit
+	MaxBackoff time.Duration
+	// Multiplier is the growth of the wait with each consecutive failure,
+	// or 0 for 2
+	Multiplier float64
+	// Retryable tells the transient errors, or nil for all errors but the
+	// errors of processing, a `HandlerError`, such as those of the function
+	// of a `Map` upstream
+	Retryable func(err error) bool
+	// Clock is the clock for the waits, or nil for the system clock
+	Clock Clock
+}
+
+// backoff is the wait before retrying the `attempt`th consecutive failure,
+// numbered from 1, with the error `err`. A RetryAfterError waits at least
+// as long as it tells.
+func (p *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < float64(p.MaxBackoff)); i++ {
+		d *= p.Multiplier
+	}
+
+	backoff := time.Duration(d)
+	if 0 < p.MaxBackoff && p.MaxBackoff < backoff {
+		backoff = p.MaxBackoff
+	}
+
+	var r RetryAfterError
+	if errors.As(err, &r) && backoff < r.RetryAfter() {
+		backoff = r.RetryAfter()
+	}
+
+	return backoff
+}
+
+// A Retrier represents the stream of the elements of a given base stream,
+// whose transient failures are retried, by resolving the base stream again
+// after a backoff, rather than ending the stream. The base stream resolved
+// again is the one its failed resolution left, so that an element consumed
+// by a failing operator, rather than a failing source, is not retried. The
+// errors of handling the elements downstream are never retried.
+type Retrier[T any] struct {
+	base   Stream[T]
+	policy RetryPolicy
+	// The consecutive failures so far
+	failures int
+}
+
+func Retry[T any](s Stream[T], policy RetryPolicy) Stream[T] {
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaultRetryBackoff
+	}
+	if policy.Multiplier <= 0 {
+		policy.Multiplier = 2
+	}
+	if policy.Clock == nil {
+		policy.Clock = SystemClock
+	}
+
+	return &Retrier[T]{base: s, policy: policy}
+}
+
+// retryable reports whether the failure `err` is to be retried.
+func (s *Retrier[T]) retryable(err error) bool {
+	if errors.Is(err, ErrStop) || (0 < s.policy.MaxAttempts && s.policy.MaxAttempts <= s.failures) {
+		return false
+	}
+
+	if s.policy.Retryable == nil {
+		var he *HandlerError
+
+		return !errors.As(err, &he)
+	}
+
+	return s.policy.Retryable(err)
+}
+
+func (s *Retrier[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	for {
+		var herr error
+		eos, nxs, err := s.base.Resolve(func(v T) error {
+			herr = handled(h(v))
+
+			return herr
+		})
+
+		s.base = nxs
+
+		if err == nil {
+			s.failures = 0
+
+			return eos, s, nil
+		}
+
+		s.failures++
+		if herr != nil || nxs == nil || !s.retryable(err) {
+			return true, s, err
+		}
+
+		<-s.policy.Clock.After(s.policy.backoff(s.failures, err))
+	}
+}
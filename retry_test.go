@@ -0,0 +1,164 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient")
+
+// A flakyStream is the stream of given elements, which fails before each
+// element as many times as told for it, with `err`, or else `errTransient`.
+type flakyStream struct {
+	elems    []int
+	failures []int
+	err      error
+}
+
+func (s *flakyStream) Resolve(h func(v int) error) (bool, Stream[int], error) {
+	if len(s.elems) == 0 {
+		return true, s, nil
+	}
+
+	if 0 < s.failures[0] {
+		s.failures[0]--
+		if s.err != nil {
+			return true, s, s.err
+		}
+
+		return true, s, errTransient
+	}
+
+	v := s.elems[0]
+	s.elems, s.failures = s.elems[1:], s.failures[1:]
+
+	err := h(v)
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// A waitRecordingClock is a clock whose waits are over at once, and
+// recorded.
+type waitRecordingClock struct {
+	waits []time.Duration
+}
+
+func (c *waitRecordingClock) Now() time.Time {
+	return time.Unix(0, 0)
+}
+
+func (c *waitRecordingClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	t := make(chan time.Time, 1)
+	t <- time.Unix(0, 0)
+
+	return t
+}
+
+type retryAfter time.Duration
+
+func (r retryAfter) Error() string {
+	return "rate limited"
+}
+
+func (r retryAfter) RetryAfter() time.Duration {
+	return time.Duration(r)
+}
+
+func TestShouldRetry(t *testing.T) {
+	clock := &waitRecordingClock{}
+	s := &flakyStream{elems: []int{3, 1, 4}, failures: []int{0, 3, 1}}
+
+	c, err := Collect(Retry[int](s, RetryPolicy{InitialBackoff: time.Second, Clock: clock}))
+
+	waits := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, time.Second}
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) || !reflect.DeepEqual(clock.waits, waits) {
+		t.Error(`Didn't Retry`, clock.waits)
+	}
+}
+
+func TestShouldRetryUpToMaxAttempts(t *testing.T) {
+	clock := &waitRecordingClock{}
+	s := &flakyStream{elems: []int{3, 1}, failures: []int{0, 3}}
+
+	c, err := Collect(Retry[int](s, RetryPolicy{MaxAttempts: 3, Clock: clock}))
+
+	if !errors.Is(err, errTransient) || !reflect.DeepEqual(c, []int{3}) || len(clock.waits) != 2 {
+		t.Error(`Didn't Retry up to max attempts`)
+	}
+}
+
+func TestShouldRetryUpToMaxBackoff(t *testing.T) {
+	clock := &waitRecordingClock{}
+	s := &flakyStream{elems: []int{3}, failures: []int{4}}
+
+	Collect(Retry[int](s, RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 3 * time.Second, Multiplier: 3, Clock: clock}))
+
+	waits := []time.Duration{time.Second, 3 * time.Second, 3 * time.Second, 3 * time.Second}
+	if !reflect.DeepEqual(clock.waits, waits) {
+		t.Error(`Didn't Retry up to max backoff`, clock.waits)
+	}
+}
+
+func TestShouldRetryOnlyRetryable(t *testing.T) {
+	clock := &waitRecordingClock{}
+	s := &flakyStream{elems: []int{3}, failures: []int{1}}
+
+	_, err := Collect(Retry[int](s, RetryPolicy{Retryable: func(err error) bool { return false }, Clock: clock}))
+
+	if !errors.Is(err, errTransient) || len(clock.waits) != 0 {
+		t.Error(`Didn't Retry only retryable`)
+	}
+}
+
+func TestShouldRetryAfter(t *testing.T) {
+	clock := &waitRecordingClock{}
+	s := &flakyStream{elems: []int{3}, failures: []int{1}, err: retryAfter(time.Minute)}
+
+	c, err := Collect(Retry[int](s, RetryPolicy{Clock: clock}))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3}) || !reflect.DeepEqual(clock.waits, []time.Duration{time.Minute}) {
+		t.Error(`Didn't Retry after`, clock.waits)
+	}
+}
+
+func TestShouldRetryNotHandlerErrors(t *testing.T) {
+	clock := &waitRecordingClock{}
+	failure := errors.New("failed")
+
+	_, err := SendAll[int](Retry[int](&flakyStream{elems: []int{3}, failures: []int{0}}, RetryPolicy{Clock: clock}), SinkFunc[int](func(v int) error {
+		return failure
+	}))
+
+	if !errors.Is(err, failure) || len(clock.waits) != 0 {
+		t.Error(`Didn't Retry not handler errors`)
+	}
+}
+
+func TestShouldRetryNotUpstreamHandlerErrors(t *testing.T) {
+	clock := &waitRecordingClock{}
+
+	_, err := Collect(Retry(atois("3", "x"), RetryPolicy{Clock: clock}))
+
+	var he *HandlerError
+	if !errors.As(err, &he) || len(clock.waits) != 0 {
+		t.Error(`Didn't Retry not upstream handler errors`)
+	}
+}
+
+func TestShouldRetryNotWrappedStop(t *testing.T) {
+	clock := &waitRecordingClock{}
+	s := &flakyStream{elems: []int{3, 1}, failures: []int{0, 1}, err: fmt.Errorf("done: %w", ErrStop)}
+
+	c, err := Collect(Retry[int](s, RetryPolicy{Clock: clock}))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3}) || len(clock.waits) != 0 {
+		t.Error(`Didn't Retry not wrapped stop`)
+	}
+}
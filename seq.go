@@ -0,0 +1,102 @@
+//go:build go1.23
+
+package streams
+
+import "iter"
+
+// A SeqPuller represents the stream of the values of a given iterator,
+// pulled one at a time. The iterator is stopped at the end of stream, or on
+// error, and otherwise must be stopped with `Stop`, or closed, when the
+// stream is not resolved to its end.
+type SeqPuller[T any] struct {
+	next func() (T, bool)
+	stop func()
+}
+
+func FromSeq[T any](it iter.Seq[T]) Stream[T] {
+	next, stop := iter.Pull(it)
+
+	return &SeqPuller[T]{next: next, stop: stop}
+}
+
+// FromSeq2 is as `FromSeq`, for an iterator of pairs of values, such as the
+// keys and values of a map.
+func FromSeq2[K, V any](it iter.Seq2[K, V]) Stream[Pair[K, V]] {
+	return FromSeq(func(yield func(Pair[K, V]) bool) {
+		for k, v := range it {
+			if !yield(Pair[K, V]{First: k, Second: v}) {
+				return
+			}
+		}
+	})
+}
+
+// Stop stops the iterator.
+func (s *SeqPuller[T]) Stop() {
+	if s.stop != nil {
+		s.stop()
+		s.next, s.stop = nil, nil
+	}
+}
+
+// Close stops the iterator, ending the stream, as `Stop`.
+func (s *SeqPuller[T]) Close() error {
+	s.Stop()
+
+	return nil
+}
+
+func (s *SeqPuller[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.next == nil {
+		return true, s, nil
+	}
+
+	v, ok := s.next()
+	if !ok {
+		s.Stop()
+
+		return true, s, nil
+	}
+
+	err := handled(h(v))
+	if err != nil {
+		s.Stop()
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
+
+// ToSeq is the iterator of the elements of the stream `s`, each along with a
+// nil error, as for ranging over `s`. An error resolving `s` is yielded last,
+// along with the zero value.
+func ToSeq[T any](s Stream[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		stopped := false
+		for {
+			eos, nxs, err := s.Resolve(func(v T) error {
+				if !yield(v, nil) {
+					stopped = true
+
+					return ErrStop
+				}
+
+				return nil
+			})
+			s = nxs
+			if stopped {
+				return
+			}
+			if err = driverError(err); err != nil {
+				var zero T
+				yield(zero, err)
+
+				return
+			}
+			if eos {
+				return
+			}
+		}
+	}
+}
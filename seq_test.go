@@ -0,0 +1,93 @@
+//go:build go1.23
+
+package streams
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestShouldStreamFromSeq(t *testing.T) {
+	c, err := Collect(FromSeq(slices.Values([]int{3, 1, 4})))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't stream from Seq`)
+	}
+}
+
+func TestShouldStreamFromSeqUntilTaken(t *testing.T) {
+	stopped := false
+	naturals := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; yield(i); i++ {
+		}
+	}
+	s := FromSeq(naturals)
+
+	c, _ := Collect(Take(s, 3))
+	s.(*SeqPuller[int]).Stop()
+
+	if !reflect.DeepEqual(c, []int{0, 1, 2}) || !stopped {
+		t.Error(`Didn't stream from Seq until taken`)
+	}
+}
+
+func TestShouldStreamFromSeq2(t *testing.T) {
+	c, _ := Collect(FromSeq2(maps.All(map[string]int{"a": 3, "b": 1})))
+	sort.Slice(c, func(i, j int) bool { return c[i].First < c[j].First })
+
+	if !reflect.DeepEqual(c, []Pair[string, int]{{"a", 3}, {"b", 1}}) {
+		t.Error(`Didn't stream from Seq2`)
+	}
+}
+
+func TestShouldRangeOverToSeq(t *testing.T) {
+	var c []int
+	for v, err := range ToSeq(NewFromSlice([]int{3, 1, 4, 1})) {
+		if err != nil || v == 4 {
+			break
+		}
+		c = append(c, v)
+	}
+
+	if !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't range over ToSeq`)
+	}
+}
+
+func TestShouldYieldErrorLastFromToSeq(t *testing.T) {
+	var c []int
+	var last error
+	for v, err := range ToSeq(Map(NewFromSlice([]int{3, 1, 4}), failingAt4)) {
+		if err != nil {
+			last = err
+			continue
+		}
+		c = append(c, v)
+	}
+
+	if last == nil || errors.Is(last, ErrStop) || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't yield error last from ToSeq`)
+	}
+}
+
+func TestShouldCloseStreamFromSeq(t *testing.T) {
+	stopped := false
+	naturals := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; yield(i); i++ {
+		}
+	}
+	s := Map(FromSeq(naturals), func(v int) (int, error) { return v, nil })
+
+	c, s, _ := CollectN(s, 2)
+	err := CloseStream(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{0, 1}) || !stopped {
+		t.Error(`Didn't CloseStream FromSeq`)
+	}
+}
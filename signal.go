@@ -0,0 +1,59 @@
+package streams
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+// A SignalStream represents the stream of the operating system signals
+// received by the process, of the given kinds. Signals are relayed from
+// the time the stream is created, until the context is done, at which
+// point the stream ends.
+type SignalStream struct {
+	ctx context.Context
+	c   chan os.Signal
+}
+
+func Signals(ctx context.Context, sigs ...os.Signal) Stream[os.Signal] {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sigs...)
+
+	return &SignalStream{ctx: ctx, c: c}
+}
+
+func (s *SignalStream) stop() {
+	signal.Stop(s.c)
+	s.c = nil
+}
+
+// Close stops relaying the signals, ending the stream.
+func (s *SignalStream) Close() error {
+	if s.c != nil {
+		s.stop()
+	}
+
+	return nil
+}
+
+func (s *SignalStream) Resolve(h func(v os.Signal) error) (bool, Stream[os.Signal], error) {
+	if s == nil || s.c == nil {
+		return true, s, nil
+	}
+
+	select {
+	case <-s.ctx.Done():
+		s.stop()
+
+		return true, s, nil
+	case sig := <-s.c:
+		err := handled(h(sig))
+		if err != nil {
+			s.stop()
+
+			return true, s, err
+		}
+
+		return false, s, nil
+	}
+}
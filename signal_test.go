@@ -0,0 +1,50 @@
+//go:build !windows
+
+package streams
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestShouldSignals(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := Signals(ctx, syscall.SIGUSR1)
+
+	syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+	c, s, _ := CollectN(s, 1)
+
+	if len(c) != 1 || c[0] != syscall.SIGUSR1 {
+		t.Error(`Didn't Signals`)
+	}
+
+	cancel()
+	eos, _, _ := s.Resolve(func(v os.Signal) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't Signals until done`)
+	}
+}
+
+func TestShouldSignalsOnZeroValue(t *testing.T) {
+	s := &SignalStream{}
+
+	eos, _, _ := s.Resolve(func(v os.Signal) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't Signals on zero value`)
+	}
+}
+
+func TestShouldCloseStreamSignals(t *testing.T) {
+	s := Map(Signals(context.Background(), syscall.SIGUSR1), func(v os.Signal) (os.Signal, error) { return v, nil })
+
+	err := CloseStream(s)
+	eos, _, _ := s.Resolve(func(v os.Signal) error { return nil })
+
+	if err != nil || !eos {
+		t.Error(`Didn't CloseStream Signals`)
+	}
+}
@@ -0,0 +1,36 @@
+package streams
+
+// A Sink consumes elements of type T, one at a time. As a gRPC client or
+// server stream sending messages of type T is a Sink.
+type Sink[T any] interface {
+	Send(v T) error
+}
+
+// A SinkFunc is a function used as a Sink.
+type SinkFunc[T any] func(v T) error
+
+func (f SinkFunc[T]) Send(v T) error {
+	return f(v)
+}
+
+// SendAll sends each element of the stream `s` to `sink`, returning how many
+// were sent.
+func SendAll[T any](s Stream[T], sink Sink[T]) (int, error) {
+	n := 0
+	for {
+		eos, nxs, err := s.Resolve(func(v T) error {
+			e := handled(sink.Send(v))
+			if e != nil {
+				return e
+			}
+
+			n++
+
+			return nil
+		})
+		s = nxs
+		if eos || err != nil {
+			return n, driverError(err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type sliceSink struct {
+	elems []int
+}
+
+func (s *sliceSink) Send(v int) error {
+	s.elems = append(s.elems, v)
+	return nil
+}
+
+func TestShouldSendAll(t *testing.T) {
+	sink := &sliceSink{}
+
+	n, err := SendAll[int](NewFromSlice([]int{3, 1, 4}), sink)
+
+	if n != 3 || err != nil || !reflect.DeepEqual(sink.elems, []int{3, 1, 4}) {
+		t.Error(`Didn't SendAll`)
+	}
+}
+
+func TestShouldSendAllErrorOnSendError(t *testing.T) {
+	e := errors.New("error")
+	sink := SinkFunc[int](func(v int) error {
+		if v == 4 {
+			return e
+		}
+		return nil
+	})
+
+	n, err := SendAll[int](NewFromSlice([]int{3, 1, 4, 1}), sink)
+
+	if n != 2 || !errors.Is(err, e) {
+		t.Error(`Didn't SendAll error on send error`)
+	}
+}
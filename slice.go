@@ -0,0 +1,116 @@
+package streams
+
+// A sliceBacked stream is backed by a slice, whose remaining elements are
+// available at once, so that terminal operations and some operators needn't
+// resolve the stream element by element.
+type sliceBacked[T any] interface {
+	// backing is the remaining elements, not to be modified, if the stream
+	// is currently backed by a slice
+	backing() ([]T, bool)
+	// advance resolves the first `n` remaining elements, without handling
+	advance(n int)
+}
+
+// backingOf is the remaining elements of the stream `s`, if it is backed by
+// a slice.
+func backingOf[T any](s Stream[T]) ([]T, sliceBacked[T], bool) {
+	b, ok := s.(sliceBacked[T])
+	if !ok {
+		return nil, nil, false
+	}
+
+	elems, ok := b.backing()
+
+	return elems, b, ok
+}
+
+func (s *StreamFromSlice[T]) backing() ([]T, bool) {
+	if len(s.elems) <= s.next {
+		return nil, true
+	}
+
+	return s.elems[s.next:], true
+}
+
+func (s *StreamFromSlice[T]) advance(n int) {
+	s.next += n
+}
+
+// dropBacked drops the elements still to be dropped at once, if the base
+// stream is backed by a slice.
+func (s *Dropper[T]) dropBacked() {
+	elems, b, ok := backingOf(s.base)
+	if !ok {
+		return
+	}
+
+	n := s.n - s.c
+	if len(elems) < n {
+		n = len(elems)
+	}
+
+	b.advance(n)
+	s.c += n
+}
+
+func (s *Dropper[T]) backing() ([]T, bool) {
+	if s == nil || s.base == nil {
+		return nil, true
+	}
+
+	s.dropBacked()
+	if s.c < s.n {
+		return nil, false
+	}
+
+	elems, _, ok := backingOf(s.base)
+
+	return elems, ok
+}
+
+func (s *Dropper[T]) advance(n int) {
+	if n == 0 {
+		return
+	}
+
+	s.base.(sliceBacked[T]).advance(n)
+}
+
+// skipBacked skips the elements still to be skipped at once, if the base
+// stream is backed by a slice.
+func (s *Stepper[T]) skipBacked() {
+	elems, b, ok := backingOf(s.base)
+	if !ok {
+		return
+	}
+
+	n := s.skip
+	if len(elems) < n {
+		n = len(elems)
+	}
+
+	b.advance(n)
+	s.skip -= n
+}
+
+func (s *Taker[T]) backing() ([]T, bool) {
+	if s == nil || s.base == nil || s.n <= s.c {
+		return nil, true
+	}
+
+	elems, _, ok := backingOf(s.base)
+	if n := s.n - s.c; n < len(elems) {
+		elems = elems[:n]
+	}
+
+	return elems, ok
+}
+
+func (s *Taker[T]) advance(n int) {
+	if n == 0 {
+		return
+	}
+
+	s.base.(sliceBacked[T]).advance(n)
+	s.c += n
+}
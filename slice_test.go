@@ -0,0 +1,93 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShouldCollectSliceBacked(t *testing.T) {
+	elems := []int{3, 1, 4}
+	s := NewFromSlice(elems)
+
+	c, _ := Collect(s)
+	c[0] = 0
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !reflect.DeepEqual(elems, []int{3, 1, 4}) || !eos || s.(Positioner).Position().Index != 3 {
+		t.Error(`Didn't Collect slice backed`)
+	}
+}
+
+func TestShouldCountDropSliceBacked(t *testing.T) {
+	s := Drop(NewFromSlice([]int{3, 1, 4, 1, 5}), 2)
+
+	if _, ok := s.(sliceBacked[int]).backing(); !ok {
+		t.Error(`Didn't Drop slice backed`)
+	}
+
+	n, _ := Count(s)
+
+	if n != 3 {
+		t.Error(`Didn't Count Drop slice backed`)
+	}
+}
+
+func TestShouldDropPastSliceBacked(t *testing.T) {
+	c, _ := Collect(Drop(Drop(NewFromSlice([]int{3, 1, 4}), 1), 5))
+
+	if len(c) != 0 {
+		t.Error(`Didn't Drop past slice backed`)
+	}
+}
+
+func TestShouldDropNotSliceBacked(t *testing.T) {
+	s := Drop(Map(NewFromSlice([]int{3, 1, 4}), func(v int) (int, error) { return v, nil }), 1)
+
+	if _, ok := s.(sliceBacked[int]).backing(); ok {
+		t.Error(`Didn't Drop not slice backed`)
+	}
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{1, 4}) {
+		t.Error(`Didn't Drop not slice backed`)
+	}
+}
+
+func TestShouldCollectTakeSliceBacked(t *testing.T) {
+	base := NewFromSlice([]int{3, 1, 4, 1, 5})
+	s := Take(Drop(base, 1), 3)
+
+	if _, ok := s.(sliceBacked[int]).backing(); !ok {
+		t.Error(`Didn't Take slice backed`)
+	}
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{1, 4, 1}) || base.(Positioner).Position().Index != 4 {
+		t.Error(`Didn't Collect Take slice backed`)
+	}
+}
+
+func TestShouldStepBySliceBacked(t *testing.T) {
+	base := NewFromSlice([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	s := StepBy(base, 3)
+
+	n := 0
+	for eos := false; !eos; n++ {
+		eos, s, _ = s.Resolve(func(v int) error { return nil })
+	}
+
+	c, _ := Collect(StepBy(NewFromSlice([]int{0, 1, 2, 3, 4, 5, 6, 7}), 3))
+
+	if n != 4 || !reflect.DeepEqual(c, []int{0, 3, 6}) {
+		t.Error(`Didn't StepBy slice backed`, n)
+	}
+}
+
+func BenchmarkCollectSliceBacked(b *testing.B) {
+	elems := make([]int, 1000)
+	for i := 0; i < b.N; i++ {
+		Collect(Drop(NewFromSlice(elems), 10))
+	}
+}
@@ -0,0 +1,231 @@
+package streams
+
+import (
+	"encoding/binary"
+	"os"
+	"sync"
+)
+
+// A DiskBuffer represents the stream of the elements of a given base stream,
+// which is resolved concurrently, as fast as it produces elements. Elements
+// not yet resolved downstream are buffered in memory up to a given number
+// of elements, and the overflow is spilled to a temporary file in a given
+// directory, so that the base stream never waits for the downstream. Under
+// a memory budget, the overflow of the budget is also spilled.
+type DiskBuffer[T any] struct {
+	base     Stream[T]
+	memLimit int
+	codec    Codec[T]
+	dir      string
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	started bool
+	mem     []T
+	file    *os.File
+	// The spilled elements are those between the read and write offsets
+	spilled   int
+	readOff   int64
+	writeOff  int64
+	done      bool
+	err       error
+	cancelled bool
+	// The base stream as left by its resolution, once done
+	rest Stream[T]
+	acct budgetShare
+}
+
+func BufferedToDisk[T any](s Stream[T], memLimit int, codec Codec[T], dir string) Stream[T] {
+	b := &DiskBuffer[T]{base: s, memLimit: memLimit, codec: codec, dir: dir}
+	b.cond = sync.NewCond(&b.mu)
+
+	return b
+}
+
+// spill appends the element `v` to the spill file. It is called with the
+// lock held.
+func (s *DiskBuffer[T]) spill(v T) error {
+	if s.file == nil {
+		f, err := os.CreateTemp(s.dir, "streams-spill-*")
+		if err != nil {
+			return err
+		}
+		s.file = f
+	}
+
+	data, err := s.codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	frame := make([]byte, binary.MaxVarintLen64+len(data))
+	k := binary.PutUvarint(frame, uint64(len(data)))
+	frame = append(frame[:k], data...)
+
+	_, err = s.file.WriteAt(frame, s.writeOff)
+	if err != nil {
+		return err
+	}
+
+	s.writeOff += int64(len(frame))
+	s.spilled++
+
+	return nil
+}
+
+// unspill reads the next element from the spill file. It is called with
+// the lock held.
+func (s *DiskBuffer[T]) unspill() (T, error) {
+	var v T
+
+	header := make([]byte, binary.MaxVarintLen64)
+	n, err := s.file.ReadAt(header, s.readOff)
+	if n == 0 {
+		return v, err
+	}
+
+	size, k := binary.Uvarint(header[:n])
+	data := make([]byte, size)
+	_, err = s.file.ReadAt(data, s.readOff+int64(k))
+	if err != nil {
+		return v, err
+	}
+
+	s.readOff += int64(k) + int64(size)
+	s.spilled--
+	if s.spilled == 0 {
+		// Reuse the file from the start
+		s.readOff = 0
+		s.writeOff = 0
+		err = s.file.Truncate(0)
+		if err != nil {
+			return v, err
+		}
+	}
+
+	return s.codec.Unmarshal(data)
+}
+
+func (s *DiskBuffer[T]) produce(base Stream[T]) {
+	for {
+		eos, nxs, err := base.Resolve(func(v T) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			if s.cancelled {
+				return ErrStop
+			}
+
+			if s.spilled == 0 && len(s.mem) < s.memLimit && s.acct.reserve("BufferedToDisk", sizeOf[T](1)) == nil {
+				s.mem = append(s.mem, v)
+			} else {
+				e := s.spill(v)
+				if e != nil {
+					return internal(e)
+				}
+			}
+
+			s.cond.Signal()
+
+			return nil
+		})
+		base = nxs
+		if eos || err != nil {
+			s.mu.Lock()
+			s.done = true
+			s.rest = base
+			s.err = driverError(err)
+			s.cond.Signal()
+			s.mu.Unlock()
+
+			return
+		}
+	}
+}
+
+// release stops the production and removes the spill file. It is called
+// with the lock held.
+func (s *DiskBuffer[T]) release() {
+	s.cancelled = true
+	s.base = nil
+	s.mem = nil
+	s.acct.done()
+
+	if s.file != nil {
+		s.file.Close()
+		os.Remove(s.file.Name())
+		s.file = nil
+	}
+}
+
+// Close stops the resolution of the base stream, once the resolution in
+// progress, if any, is done, and removes the spill file, ending the stream.
+func (s *DiskBuffer[T]) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		s.rest = s.base
+	}
+	s.release()
+
+	for s.started && !s.done {
+		s.cond.Wait()
+	}
+
+	return nil
+}
+
+func (s *DiskBuffer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	s.mu.Lock()
+
+	if !s.started {
+		s.started = true
+		go s.produce(s.base)
+	}
+
+	for len(s.mem) == 0 && s.spilled == 0 && !s.done {
+		s.cond.Wait()
+	}
+
+	var v T
+	var err error
+	switch {
+	case len(s.mem) != 0:
+		v = s.mem[0]
+		s.mem = s.mem[1:]
+		s.acct.release(sizeOf[T](1))
+	case s.spilled != 0:
+		v, err = s.unspill()
+	default:
+		err = s.err
+		s.release()
+		s.mu.Unlock()
+
+		return true, s, err
+	}
+
+	if err != nil {
+		s.release()
+		s.mu.Unlock()
+
+		return true, s, internal(err)
+	}
+
+	s.mu.Unlock()
+
+	err = handled(h(v))
+	if err != nil {
+		s.mu.Lock()
+		s.release()
+		s.mu.Unlock()
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,72 @@
+package streams
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestShouldBufferedToDisk(t *testing.T) {
+	dir := t.TempDir()
+	s := BufferedToDisk(NewFromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6}), 2, Codec[int](JSONCodec[int]{}), dir)
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4, 1, 5, 9, 2, 6}) {
+		t.Error(`Didn't BufferedToDisk`)
+	}
+}
+
+func TestShouldBufferedToDiskSpill(t *testing.T) {
+	dir := t.TempDir()
+	release := make(chan struct{})
+	var elems []string
+	for i := 0; i < 100; i++ {
+		elems = append(elems, string(rune('a'+i%26)))
+	}
+	// Hold the consumer until the base stream is exhausted
+	produced := 0
+	base := Map(NewFromSlice(elems), func(v string) (string, error) {
+		produced++
+		if produced == len(elems) {
+			close(release)
+		}
+		return v, nil
+	})
+	s := BufferedToDisk(base, 10, Codec[string](GobCodec[string]{}), dir)
+
+	s = Map(s, func(v string) (string, error) {
+		<-release
+		return v, nil
+	})
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, elems) {
+		t.Error(`Didn't BufferedToDisk spill`)
+	}
+
+	files, _ := os.ReadDir(dir)
+	if len(files) != 0 {
+		t.Error(`Didn't BufferedToDisk remove spill file`)
+	}
+}
+
+func TestShouldBufferedToDiskError(t *testing.T) {
+	s := BufferedToDisk(Map(NewFromSlice([]int{3, 1, 4, 1}), failingAt4), 1, Codec[int](JSONCodec[int]{}), t.TempDir())
+
+	c, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't BufferedToDisk error`)
+	}
+}
+
+func TestShouldBufferedToDiskOnZeroValue(t *testing.T) {
+	s := &DiskBuffer[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't BufferedToDisk on zero value`)
+	}
+}
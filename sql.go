@@ -0,0 +1,57 @@
+package streams
+
+import "database/sql"
+
+// A SQLRowScanner represents the stream of the rows of a given query result,
+// each scanned into a value of type T by a given function, one row at a
+// time. The rows are closed at the end of stream, or on error.
+type SQLRowScanner[T any] struct {
+	rows *sql.Rows
+	scan func(rows *sql.Rows) (T, error)
+}
+
+func NewSQLRowsStream[T any](rows *sql.Rows, scan func(rows *sql.Rows) (T, error)) Stream[T] {
+	return &SQLRowScanner[T]{rows: rows, scan: scan}
+}
+
+// close closes the rows, returning `err`, or else the error of closing.
+func (s *SQLRowScanner[T]) close(err error) error {
+	cerr := s.rows.Close()
+	s.rows = nil
+	if err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+// Close closes the rows, ending the stream.
+func (s *SQLRowScanner[T]) Close() error {
+	if s.rows == nil {
+		return nil
+	}
+
+	return s.close(nil)
+}
+
+func (s *SQLRowScanner[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.rows == nil {
+		return true, s, nil
+	}
+
+	if !s.rows.Next() {
+		return true, s, internal(s.close(s.rows.Err()))
+	}
+
+	v, err := s.scan(s.rows)
+	if err != nil {
+		return true, s, internal(s.close(handled(err)))
+	}
+
+	err = handled(h(v))
+	if err != nil {
+		return true, s, internal(s.close(err))
+	}
+
+	return false, s, nil
+}
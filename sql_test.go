@@ -0,0 +1,127 @@
+package streams
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// A fakeSQL is a database/sql driver whose queries all return the same rows
+// of a single integer column, or fail at a given row.
+type fakeSQL struct {
+	mu     sync.Mutex
+	rows   []int64
+	failAt int
+	closed int
+}
+
+type fakeSQLConn struct{ db *fakeSQL }
+
+type fakeSQLRows struct {
+	db   *fakeSQL
+	next int
+}
+
+func (d *fakeSQL) Open(name string) (driver.Conn, error) { return &fakeSQLConn{db: d}, nil }
+
+func (c *fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("unsupported")
+}
+
+func (c *fakeSQLConn) Close() error              { return nil }
+func (c *fakeSQLConn) Begin() (driver.Tx, error) { return nil, errors.New("unsupported") }
+
+func (c *fakeSQLConn) Query(query string, args []driver.Value) (driver.Rows, error) {
+	return &fakeSQLRows{db: c.db}, nil
+}
+
+func (r *fakeSQLRows) Columns() []string { return []string{"n"} }
+
+func (r *fakeSQLRows) Close() error {
+	r.db.mu.Lock()
+	r.db.closed++
+	r.db.mu.Unlock()
+
+	return nil
+}
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if r.next == r.db.failAt {
+		return errors.New("failed")
+	}
+	if r.next == len(r.db.rows) {
+		return io.EOF
+	}
+
+	dest[0] = r.db.rows[r.next]
+	r.next++
+
+	return nil
+}
+
+func openFakeSQL(t *testing.T, rows []int64, failAt int) (*sql.DB, *fakeSQL) {
+	d := &fakeSQL{rows: rows, failAt: failAt}
+	db := sql.OpenDB(fakeSQLConnector{d})
+	t.Cleanup(func() { db.Close() })
+
+	return db, d
+}
+
+type fakeSQLConnector struct{ d *fakeSQL }
+
+func (c fakeSQLConnector) Connect(ctx context.Context) (driver.Conn, error) { return c.d.Open("") }
+func (c fakeSQLConnector) Driver() driver.Driver                            { return c.d }
+
+func scanInt(rows *sql.Rows) (int, error) {
+	var n int
+	err := rows.Scan(&n)
+
+	return n, err
+}
+
+func TestShouldStreamSQLRows(t *testing.T) {
+	db, d := openFakeSQL(t, []int64{3, 1, 4}, -1)
+	rows, err := db.Query("select n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Collect(NewSQLRowsStream(rows, scanInt))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) || d.closed != 1 {
+		t.Error(`Didn't stream SQL rows`)
+	}
+}
+
+func TestShouldStreamSQLRowsClosedOnError(t *testing.T) {
+	db, d := openFakeSQL(t, []int64{3, 1, 4}, 2)
+	rows, _ := db.Query("select n")
+
+	c, err := Collect(NewSQLRowsStream(rows, scanInt))
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 1}) || d.closed != 1 {
+		t.Error(`Didn't stream SQL rows closed on error`)
+	}
+}
+
+func TestShouldStreamSQLRowsClosedOnStop(t *testing.T) {
+	db, d := openFakeSQL(t, []int64{3, 1, 4}, -1)
+	rows, _ := db.Query("select n")
+	s := Map(NewSQLRowsStream(rows, scanInt), func(v int) (int, error) {
+		if v == 1 {
+			return 0, ErrStop
+		}
+		return v, nil
+	})
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3}) || d.closed != 1 {
+		t.Error(`Didn't stream SQL rows closed on stop`)
+	}
+}
@@ -0,0 +1,214 @@
+package streams
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultSSERetry is the time to wait before reconnecting to an event
+// stream, unless set by the server.
+const defaultSSERetry = 3 * time.Second
+
+// An SSEEvent is an event of a server-sent event stream.
+type SSEEvent struct {
+	// ID is the last event ID set by the server, as of the event
+	ID string
+	// Event is the type of the event, "message" if not set
+	Event string
+	// Data is the data of the event, with the data lines joined by newlines
+	Data string
+}
+
+// An SSESource represents the stream of the events of a server-sent event
+// stream, as served with the "text/event-stream" content type, parsed as
+// they arrive. Once the connection is lost, the source reconnects after the
+// reconnection time, sending the last event ID, so that the server may
+// resume the stream. Failing to connect, or a response other than a
+// successful event stream, fails the stream. A 204 No Content response, or
+// the context being done, is the end of stream.
+type SSESource struct {
+	ctx    context.Context
+	url    string
+	client *http.Client
+	body   io.ReadCloser
+	r      *bufio.Reader
+	lastID string
+	retry  time.Duration
+	// Whether the connection was lost, so that reconnecting waits
+	lost bool
+	done bool
+}
+
+func NewSSEStream(ctx context.Context, url string, client *http.Client) Stream[SSEEvent] {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &SSESource{ctx: ctx, url: url, client: client, retry: defaultSSERetry}
+}
+
+func (s *SSESource) connect() error {
+	if s.lost {
+		select {
+		case <-time.After(s.retry):
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if s.lastID != "" {
+		req.Header.Set("Last-Event-ID", s.lastID)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		resp.Body.Close()
+		s.done = true
+
+		return nil
+	}
+
+	mediaType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	if resp.StatusCode != http.StatusOK || mediaType != "text/event-stream" {
+		resp.Body.Close()
+
+		return fmt.Errorf("streams: sse: %s, content type %q", resp.Status, mediaType)
+	}
+
+	s.body = resp.Body
+	s.r = bufio.NewReader(resp.Body)
+
+	return nil
+}
+
+func (s *SSESource) disconnect() {
+	if s.body != nil {
+		s.body.Close()
+		s.body, s.r = nil, nil
+	}
+}
+
+// next reads the next event, dispatched by a blank line.
+func (s *SSESource) next() (SSEEvent, error) {
+	var ev SSEEvent
+	var data []string
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			return ev, err
+		}
+
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		if line == "" {
+			if data == nil {
+				ev.Event = ""
+
+				continue
+			}
+
+			ev.ID = s.lastID
+			if ev.Event == "" {
+				ev.Event = "message"
+			}
+			ev.Data = strings.Join(data, "\n")
+
+			return ev, nil
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.IndexByte(line, ':'); 0 <= i {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "event":
+			ev.Event = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.lastID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && 0 <= ms {
+				s.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// Close closes the connection, if any, ending the stream.
+func (s *SSESource) Close() error {
+	s.disconnect()
+	s.done = true
+
+	return nil
+}
+
+func (s *SSESource) Resolve(h func(v SSEEvent) error) (bool, Stream[SSEEvent], error) {
+	if s == nil || s.done {
+		return true, s, nil
+	}
+
+	for {
+		if s.ctx.Err() != nil {
+			s.disconnect()
+			s.done = true
+
+			return true, s, nil
+		}
+
+		if s.r == nil {
+			err := s.connect()
+			if err != nil || s.done {
+				s.done = true
+				if s.ctx.Err() != nil {
+					err = nil
+				}
+
+				return true, s, internal(err)
+			}
+		}
+
+		ev, err := s.next()
+		if err != nil {
+			// The connection is lost, or closed by the server, and an
+			// incomplete event is discarded
+			s.disconnect()
+			s.lost = true
+
+			continue
+		}
+
+		err = handled(h(ev))
+		if err != nil {
+			s.disconnect()
+			s.done = true
+
+			return true, s, err
+		}
+
+		return false, s, nil
+	}
+}
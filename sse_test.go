@@ -0,0 +1,89 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestShouldStreamSSE(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		fmt.Fprint(w, ": comment\n\ndata: 3\ndata:1\n\nevent: tick\nid: 7\ndata: 4\r\n\r\nid\ndata\n\n")
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, _, err := CollectN(NewSSEStream(ctx, srv.URL, srv.Client()), 3)
+
+	want := []SSEEvent{{Event: "message", Data: "3\n1"}, {ID: "7", Event: "tick", Data: "4"}, {Event: "message"}}
+	if err != nil || !reflect.DeepEqual(c, want) {
+		t.Error(`Didn't stream SSE`, c, err)
+	}
+}
+
+func TestShouldStreamSSEReconnectingFromLastID(t *testing.T) {
+	var mu sync.Mutex
+	var lastIDs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lastIDs = append(lastIDs, r.Header.Get("Last-Event-ID"))
+		n := len(lastIDs)
+		mu.Unlock()
+
+		if n == 3 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "retry: 1\nid: %d\ndata: %d\n\ndata: incomplete\n", n, n)
+	}))
+	defer srv.Close()
+
+	c, err := Collect(NewSSEStream(context.Background(), srv.URL, srv.Client()))
+
+	if err != nil || len(c) != 2 || c[1].Data != "2" || !reflect.DeepEqual(lastIDs, []string{"", "1", "2"}) {
+		t.Error(`Didn't stream SSE reconnecting from last ID`, c, lastIDs, err)
+	}
+}
+
+func TestShouldStreamSSEErrorOnBadResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, "{}")
+	}))
+	defer srv.Close()
+
+	_, err := Collect(NewSSEStream(context.Background(), srv.URL, srv.Client()))
+
+	if err == nil {
+		t.Error(`Didn't stream SSE error on bad response`)
+	}
+}
+
+func TestShouldStreamSSEUntilCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: 3\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewSSEStream(ctx, srv.URL, srv.Client())
+
+	c, s, _ := CollectN(s, 1)
+	cancel()
+	d, err := Collect(s)
+
+	if err != nil || len(c) != 1 || len(d) != 0 {
+		t.Error(`Didn't stream SSE until canceled`, err)
+	}
+}
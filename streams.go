@@ -2,8 +2,10 @@ package streams
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"golang.org/x/exp/constraints"
+	"io"
 	"os"
 )
 
@@ -32,19 +34,45 @@ import (
 // If there is an error, an end of stream condition is signaled, along with
 // the error. Notice that an error may be *internal* or *external*, meaning,
 // it may originate from code in the stream library, or code in the stream
-// processing, respectively. These situations should be distinguishable on
-// the client.
+// processing, respectively. The sources and operators of the library wrap
+// the former in an `InternalError`, and the latter, the errors of handlers
+// and of the functions given to them, in a `HandlerError`, so that the two
+// are told apart with `errors.As`.
+//
+// A handler may return `ErrStop` to request the clean termination of the
+// stream, in which case the stream resolves to the end of stream condition
+// and drivers do not report an error.
 type Stream[T any] interface {
 	Resolve(func(v T) error) (bool, Stream[T], error)
 }
 
+// ErrStop is returned by a `Resolve` handler to stop the stream early.
+// Operators propagate it as any other error, signaling end of stream,
+// sources release their resources, and drivers such as `Collect` or
+// `Count` treat it as a normal end of stream rather than a failure.
+var ErrStop = errors.New("streams: stop")
+
+// driverError is the error to be reported by a driver that got `err` from
+// its last evaluation of `Resolve`.
+func driverError(err error) error {
+	if errors.Is(err, ErrStop) {
+		return nil
+	}
+
+	return err
+}
+
 // A Mapper represents the stream that results from applying a given function
 // `f` to each element of a given base stream. The base stream has elements
 // of type `T`, and the Mapper has elements of type `U`. The `Resolve` operation
 // decomposes this latter stream of `U`s.
+//
+// An error of `f` is a `PositionError`, with the position of the element in
+// the base stream.
 type Mapper[T, U any] struct {
 	base Stream[T]
 	f    func(T) (U, error)
+	i    int
 }
 
 func (s *Mapper[T, U]) Resolve(h func(U) error) (bool, Stream[U], error) {
@@ -54,11 +82,12 @@ func (s *Mapper[T, U]) Resolve(h func(U) error) (bool, Stream[U], error) {
 
 	eos, nxs, err := s.base.Resolve(func(v T) error {
 		u, e := s.f(v)
+		s.i++
 		if e != nil {
-			return e
+			return handled(positioned("Map", s.i-1, e))
 		}
 
-		e = h(u)
+		e = handled(h(u))
 
 		return e
 	})
@@ -76,10 +105,66 @@ func Map[T, U any](s Stream[T], f func(T) (U, error)) Stream[U] {
 	return &Mapper[T, U]{base: s, f: f}
 }
 
+// An IndexedMapper is as a Mapper, but the function `f` is also given the
+// position of each element in the base stream, starting at 0.
+type IndexedMapper[T, U any] struct {
+	base Stream[T]
+	f    func(int, T) (U, error)
+	i    int
+}
+
+func (s *IndexedMapper[T, U]) Resolve(h func(U) error) (bool, Stream[U], error) {
+	if s == nil || s.base == nil {
+		return true, nil, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		u, e := s.f(s.i, v)
+		s.i++
+		if e != nil {
+			return handled(positioned("MapIndexed", s.i-1, e))
+		}
+
+		e = handled(h(u))
+
+		return e
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+func MapIndexed[T, U any](s Stream[T], f func(i int, v T) (U, error)) Stream[U] {
+	return &IndexedMapper[T, U]{base: s, f: f}
+}
+
+// An Indexed is an element along with its position in its stream, starting
+// at 0.
+type Indexed[T any] struct {
+	I int
+	V T
+}
+
+// Enumerate is the stream of the elements of `s` along with their position,
+// as for numbering lines.
+func Enumerate[T any](s Stream[T]) Stream[Indexed[T]] {
+	return MapIndexed(s, func(i int, v T) (Indexed[T], error) { return Indexed[T]{I: i, V: v}, nil })
+}
+
+// A FlatMapper represents the stream that results from applying a given
+// function `f` to each element of each of the streams of a given base
+// stream, one stream after the other. An error of `f` is a `PositionError`,
+// with the position of the element among the elements of all the streams.
 type FlatMapper[T, U any] struct {
 	base    Stream[Stream[T]]
 	current Stream[T]
 	f       func(T) (U, error)
+	i       int
 }
 
 func (s *FlatMapper[T, U]) Resolve(h func(U) error) (bool, Stream[U], error) {
@@ -105,11 +190,12 @@ func (s *FlatMapper[T, U]) Resolve(h func(U) error) (bool, Stream[U], error) {
 
 	eos, nxs, err := s.current.Resolve(func(v T) error {
 		u, e := s.f(v)
+		s.i++
 		if e != nil {
-			return e
+			return handled(positioned("FlatMap", s.i-1, e))
 		}
 
-		e = h(u)
+		e = handled(h(u))
 
 		return e
 	})
@@ -133,6 +219,18 @@ func FlatMap[T, U any](s Stream[Stream[T]], f func(T) (U, error)) Stream[U] {
 	return &FlatMapper[T, U]{base: s, f: f}
 }
 
+// Flatten is the stream of the elements of each of the streams of `ss`, one
+// stream after the other. An error resolving any of them ends the stream.
+func Flatten[T any](ss Stream[Stream[T]]) Stream[T] {
+	return FlatMap(ss, func(v T) (T, error) { return v, nil })
+}
+
+// Bind is the stream of the elements of the streams that each element of `s`
+// expands into, as given by `f`, one stream after the other.
+func Bind[T, U any](s Stream[T], f func(T) (Stream[U], error)) Stream[U] {
+	return Flatten(Map(s, f))
+}
+
 // A Dropper represents the stream that results from "dropping" the
 // first few elements from a given stream.
 type Dropper[T any] struct {
@@ -145,6 +243,10 @@ func (s *Dropper[T]) Resolve(h func(u T) error) (bool, Stream[T], error) {
 		return true, nil, nil
 	}
 
+	if s.c < s.n {
+		s.dropBacked()
+	}
+
 	eos, nxs, err := s.base.Resolve(func(v T) error {
 		if s.c < s.n {
 			s.c++
@@ -152,7 +254,7 @@ func (s *Dropper[T]) Resolve(h func(u T) error) (bool, Stream[T], error) {
 			return nil
 		}
 
-		return h(v)
+		return handled(h(v))
 	})
 
 	s.base = nxs
@@ -168,10 +270,267 @@ func Drop[T any](s Stream[T], n int) Stream[T] {
 	return &Dropper[T]{base: s, n: n}
 }
 
+// A Taker represents the stream of the first few elements of a given
+// stream. The base stream is not resolved any further once they have been
+// resolved, but is kept, so that it may be closed.
+type Taker[T any] struct {
+	base Stream[T]
+	n, c int
+}
+
+func (s *Taker[T]) Resolve(h func(u T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if s.n <= s.c {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		s.c++
+
+		return handled(h(v))
+	})
+
+	s.base = nxs
+
+	if eos {
+		s.base = nil
+	}
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+func Take[T any](s Stream[T], n int) Stream[T] {
+	return &Taker[T]{base: s, n: n}
+}
+
+// A Stepper represents the stream of every few elements of a given stream,
+// from the first, as when downsampling. The elements in between are skipped
+// without being handled, and at once if the base stream is backed by a
+// slice. The handler of the base stream is made once, so that no element
+// allocates.
+type Stepper[T any] struct {
+	base Stream[T]
+	n    int
+	// The number of elements to skip before the next one
+	skip int
+	h    func(v T) error
+	step func(v T) error
+}
+
+// StepBy keeps every `n`th element of the stream `s`, from the first.
+func StepBy[T any](s Stream[T], n int) Stream[T] {
+	if n < 1 {
+		n = 1
+	}
+
+	st := &Stepper[T]{base: s, n: n}
+	st.step = func(v T) error {
+		if 0 < st.skip {
+			st.skip--
+
+			return nil
+		}
+
+		st.skip = st.n - 1
+
+		return handled(st.h(v))
+	}
+
+	return st
+}
+
+func (s *Stepper[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if 0 < s.skip {
+		s.skipBacked()
+	}
+
+	s.h = h
+	eos, nxs, err := s.base.Resolve(s.step)
+	s.h = nil
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+// A WhileTaker represents the stream of the leading elements of a given
+// stream that satisfy a given predicate. The stream ends at the first
+// element that does not, without resolving the base stream any further.
+type WhileTaker[T any] struct {
+	base Stream[T]
+	f    func(v T) bool
+	done bool
+}
+
+func TakeWhile[T any](s Stream[T], f func(v T) bool) Stream[T] {
+	return &WhileTaker[T]{base: s, f: f}
+}
+
+func (s *WhileTaker[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil || s.done {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		if !s.f(v) {
+			s.done = true
+
+			return nil
+		}
+
+		return handled(h(v))
+	})
+
+	s.base = nxs
+
+	if eos {
+		s.base = nil
+	}
+	if s.done {
+		eos = true
+	}
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+// A WhileDropper represents the stream that results from dropping the
+// leading elements of a given stream that satisfy a given predicate.
+type WhileDropper[T any] struct {
+	base     Stream[T]
+	f        func(v T) bool
+	dropping bool
+}
+
+func DropWhile[T any](s Stream[T], f func(v T) bool) Stream[T] {
+	return &WhileDropper[T]{base: s, f: f, dropping: true}
+}
+
+func (s *WhileDropper[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		if s.dropping && s.f(v) {
+			return nil
+		}
+
+		s.dropping = false
+
+		return handled(h(v))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+// A Concatenator represents the stream of the elements of given streams, one
+// stream after the other.
+type Concatenator[T any] struct {
+	ss []Stream[T]
+}
+
+func Concat[T any](ss ...Stream[T]) Stream[T] {
+	return &Concatenator[T]{ss: ss}
+}
+
+func (s *Concatenator[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || len(s.ss) == 0 {
+		return true, s, nil
+	}
+
+	if s.ss[0] == nil {
+		s.ss = s.ss[1:]
+
+		return len(s.ss) == 0, s, nil
+	}
+
+	eos, nxs, err := s.ss[0].Resolve(h)
+
+	s.ss[0] = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	if eos {
+		s.ss[0] = nil
+		s.ss = s.ss[1:]
+	}
+
+	return len(s.ss) == 0, s, nil
+}
+
+// A Scanner represents the stream of the running accumulations of the
+// elements of a given base stream, from a given initial accumulator: each
+// element of the base stream gives the next accumulator. An error of `f` is
+// a `PositionError`, with the position of the element in the base stream.
+type Scanner[T, R any] struct {
+	base Stream[T]
+	r    R
+	f    func(R, T) (R, error)
+	i    int
+}
+
+func Scan[T, R any](s Stream[T], init R, f func(R, T) (R, error)) Stream[R] {
+	return &Scanner[T, R]{base: s, r: init, f: f}
+}
+
+func (s *Scanner[T, R]) Resolve(h func(R) error) (bool, Stream[R], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		r, e := s.f(s.r, v)
+		s.i++
+		if e != nil {
+			return handled(positioned("Scan", s.i-1, e))
+		}
+
+		s.r = r
+
+		return handled(h(r))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
 type Truncater[T any] struct {
 	base Stream[T]
 	hold []T
 	n, i int
+	acct budgetShare
 }
 
 func Truncate[T any](s Stream[T], n int) Stream[T] {
@@ -179,6 +538,13 @@ func Truncate[T any](s Stream[T], n int) Stream[T] {
 }
 
 func (s *Truncater[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s.acct.reserved == 0 {
+		err := s.acct.reserve("Truncate", sizeOf[T](s.n))
+		if err != nil {
+			return true, s, err
+		}
+	}
+
 	eos, nxs, err := s.base.Resolve(func(v T) error {
 		if len(s.hold) < cap(s.hold) {
 			s.hold = append(s.hold, v)
@@ -192,13 +558,17 @@ func (s *Truncater[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
 			s.i = 0
 		}
 
-		err := h(head)
+		err := handled(h(head))
 
 		return err
 	})
 
 	s.base = nxs
 
+	if eos || err != nil {
+		s.acct.done()
+	}
+
 	if err != nil {
 		return true, s, err
 	}
@@ -235,7 +605,7 @@ func (s *Differ[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
 			s.i = 0
 		}
 
-		err := h(head)
+		err := handled(h(head))
 
 		return err
 	})
@@ -249,6 +619,82 @@ func (s *Differ[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
 	return eos, s, nil
 }
 
+// A Pair is a pair of values, of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// A Pairer represents the stream of the pairs of adjacent elements of a
+// given base stream: the first and second elements, then the second and
+// third, and so on. It is as a Differ, for elements of any type.
+type Pairer[T any] struct {
+	base Stream[T]
+	prev T
+	seen bool
+}
+
+func Pairwise[T any](s Stream[T]) Stream[Pair[T, T]] {
+	return &Pairer[T]{base: s}
+}
+
+func (s *Pairer[T]) Resolve(h func(v Pair[T, T]) error) (bool, Stream[Pair[T, T]], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		prev, seen := s.prev, s.seen
+		s.prev = v
+		s.seen = true
+		if !seen {
+			return nil
+		}
+
+		return handled(h(Pair[T, T]{First: prev, Second: v}))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+// A Tapper represents the stream of the elements of a given base stream,
+// unchanged, each of which is observed by a given function before being
+// handled, as for logging or metrics.
+type Tapper[T any] struct {
+	base Stream[T]
+	f    func(v T)
+}
+
+func Tap[T any](s Stream[T], f func(v T)) Stream[T] {
+	return &Tapper[T]{base: s, f: f}
+}
+
+func (s *Tapper[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		s.f(v)
+
+		return handled(h(v))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
 type Filterer[T any] struct {
 	base Stream[T]
 	f    func(v T) bool
@@ -268,7 +714,45 @@ func (s *Filterer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
 			return nil
 		}
 
-		err := h(v)
+		err := handled(h(v))
+
+		return err
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
+
+// An IndexedFilterer is as a Filterer, but the predicate `f` is also given
+// the position of each element in the base stream, starting at 0.
+type IndexedFilterer[T any] struct {
+	base Stream[T]
+	f    func(i int, v T) bool
+	i    int
+}
+
+func FilterIndexed[T any](s Stream[T], f func(i int, v T) bool) Stream[T] {
+	return &IndexedFilterer[T]{base: s, f: f}
+}
+
+func (s *IndexedFilterer[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		i := s.i
+		s.i++
+		if !s.f(i, v) {
+			return nil
+		}
+
+		err := handled(h(v))
 
 		return err
 	})
@@ -286,6 +770,7 @@ type Windower[T any] struct {
 	base    Stream[T]
 	hold    []T
 	i, n, f int
+	acct    budgetShare
 }
 
 func Windowed[T any](s Stream[T], n int, f int) Stream[Stream[T]] {
@@ -297,6 +782,13 @@ func (s *Windower[T]) Resolve(h func(v Stream[T]) error) (bool, Stream[Stream[T]
 		return true, s, nil
 	}
 
+	if s.acct.reserved == 0 {
+		err := s.acct.reserve("Windowed", sizeOf[T](s.f*s.n))
+		if err != nil {
+			return true, s, err
+		}
+	}
+
 	eos, nxs, err := s.base.Resolve(func(v T) error {
 		s.hold = append(s.hold, v)
 		s.i++
@@ -308,7 +800,7 @@ func (s *Windower[T]) Resolve(h func(v Stream[T]) error) (bool, Stream[Stream[T]
 
 		headSlice := s.hold[s.i-s.n : s.i]
 		headStream := NewFromSlice(headSlice)
-		err := h(headStream)
+		err := handled(h(headStream))
 
 		if s.i == s.f*s.n {
 			s.i = s.n - 1
@@ -321,6 +813,10 @@ func (s *Windower[T]) Resolve(h func(v Stream[T]) error) (bool, Stream[Stream[T]
 
 	s.base = nxs
 
+	if eos || err != nil {
+		s.acct.done()
+	}
+
 	if err != nil {
 		return true, s, err
 	}
@@ -330,6 +826,7 @@ func (s *Windower[T]) Resolve(h func(v Stream[T]) error) (bool, Stream[Stream[T]
 
 type StreamOfFileInts struct {
 	filename string
+	offset   int64
 }
 
 func NewStreamOfFileInts(filename string) Stream[int] {
@@ -339,87 +836,211 @@ func NewStreamOfFileInts(filename string) Stream[int] {
 func (s *StreamOfFileInts) Resolve(h func(v int) error) (bool, Stream[int], error) {
 	in, err := os.Open(s.filename)
 	if err != nil {
-		return false, s, err
+		return false, s, internal(err)
+	}
+
+	if s.offset != 0 {
+		_, err = in.Seek(s.offset, io.SeekStart)
+		if err != nil {
+			in.Close()
+
+			return true, s, internal(err)
+		}
 	}
 
 	var v int
 	_, err = fmt.Fscanf(in, "%d", &v)
 	if err != nil {
+		in.Close()
+
 		return true, s, nil
 	}
 
-	err = h(v)
+	err = handled(h(v))
 	if err != nil {
+		in.Close()
+
 		return true, s, err
 	}
 
-	return false, &StreamOfFileIntsOpen{in: in}, nil
+	o := &StreamOfFileIntsOpen{in: in, index: 1}
+	o.offset, _ = in.Seek(0, io.SeekCurrent)
+
+	return false, o, nil
+}
+
+// Position is that of the offset to resume from, before any element.
+func (s *StreamOfFileInts) Position() Position {
+	return Position{Offset: s.offset}
 }
 
 type StreamOfFileIntsOpen struct {
-	in *os.File
+	in    io.ReadCloser
+	index int64
+	// The offset of the input after the last element read, or once closed,
+	// if seekable
+	offset int64
+	closed bool
+}
+
+// NewStreamOfIntsOpen is the stream of the integers read from the already
+// open `in`, such as a pipe, which is closed at the end of stream.
+func NewStreamOfIntsOpen(in io.ReadCloser) Stream[int] {
+	return &StreamOfFileIntsOpen{in: in}
+}
+
+// Close closes the input, ending the stream.
+func (s *StreamOfFileIntsOpen) Close() error {
+	if s.in == nil {
+		return nil
+	}
+
+	if in, ok := s.in.(io.Seeker); ok {
+		offset, err := in.Seek(0, io.SeekCurrent)
+		if err == nil {
+			s.offset = offset
+			s.closed = true
+		}
+	}
+
+	err := s.in.Close()
+	s.in = nil
+
+	return err
 }
 
 func (s *StreamOfFileIntsOpen) Resolve(h func(v int) error) (bool, Stream[int], error) {
+	if s == nil || s.in == nil {
+		return true, s, nil
+	}
+
 	var v int
 	_, err := fmt.Fscanf(s.in, "%d", &v)
 	if err != nil {
+		s.Close()
+
 		return true, s, nil
 	}
 
-	err = h(v)
+	s.index++
+	if in, ok := s.in.(io.Seeker); ok {
+		s.offset, _ = in.Seek(0, io.SeekCurrent)
+	}
+
+	err = handled(h(v))
 	if err != nil {
+		s.Close()
+
 		return true, s, err
 	}
 
 	return false, s, nil
 }
 
+// Position is that of the last element read, with the offset of the input
+// after it, if seekable.
+func (s *StreamOfFileIntsOpen) Position() Position {
+	return Position{Index: s.index, Offset: s.offset}
+}
+
 type StreamOfFileLines struct {
 	filename string
+	intern   *Interner
 }
 
 func NewStreamOfFileLines(filename string) Stream[string] {
 	return &StreamOfFileLines{filename: filename}
 }
 
+// NewStreamOfFileLinesInterned is as `NewStreamOfFileLines`, with the lines
+// interned by `in`.
+func NewStreamOfFileLinesInterned(filename string, in *Interner) Stream[string] {
+	return &StreamOfFileLines{filename: filename, intern: in}
+}
+
+// scannedText is the text last scanned by `in`, interned if `intern` is not
+// nil.
+func scannedText(in *bufio.Scanner, intern *Interner) string {
+	if intern == nil {
+		return in.Text()
+	}
+
+	return intern.InternBytes(in.Bytes())
+}
+
 func (s *StreamOfFileLines) Resolve(h func(v string) error) (bool, Stream[string], error) {
 	file, err := os.Open(s.filename)
 	if err != nil {
-		return false, s, err
+		return false, s, internal(err)
 	}
 
 	in := bufio.NewScanner(file)
 
-	eoi := in.Scan()
-	if eoi {
-		return true, s, in.Err()
+	if !in.Scan() {
+		file.Close()
+
+		return true, s, internal(in.Err())
 	}
 
-	line := in.Text()
+	line := scannedText(in, s.intern)
 
-	err = h(line)
+	err = handled(h(line))
 	if err != nil {
+		file.Close()
+
 		return true, s, err
 	}
 
-	return false, &StreamOfFileLinesOpen{in: in}, nil
+	return false, &StreamOfFileLinesOpen{file: file, in: in, intern: s.intern}, nil
 }
 
 type StreamOfFileLinesOpen struct {
-	in *bufio.Scanner
+	file   io.ReadCloser
+	in     *bufio.Scanner
+	intern *Interner
+}
+
+// NewStreamOfLinesOpen is the stream of the lines read from the already open
+// `file`, such as a pipe, which is closed at the end of stream.
+func NewStreamOfLinesOpen(file io.ReadCloser) Stream[string] {
+	return &StreamOfFileLinesOpen{file: file, in: bufio.NewScanner(file)}
+}
+
+// NewStdinLines is the stream of the lines read from the standard input, as
+// for a Unix filter. The standard input is left open.
+func NewStdinLines() Stream[string] {
+	return NewStreamOfLinesOpen(io.NopCloser(os.Stdin))
+}
+
+// Close closes the file, ending the stream.
+func (s *StreamOfFileLinesOpen) Close() error {
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+
+	return err
 }
 
 func (s *StreamOfFileLinesOpen) Resolve(h func(v string) error) (bool, Stream[string], error) {
-	eoi := s.in.Scan()
-	if eoi {
-		return true, s, s.in.Err()
+	if s == nil || s.file == nil {
+		return true, s, nil
 	}
 
-	line := s.in.Text()
+	if !s.in.Scan() {
+		s.Close()
+
+		return true, s, internal(s.in.Err())
+	}
 
-	err := h(line)
+	line := scannedText(s.in, s.intern)
+
+	err := handled(h(line))
 	if err != nil {
+		s.Close()
+
 		return true, s, err
 	}
 
@@ -443,7 +1064,7 @@ func (s *StreamFromSlice[T]) Resolve(h func(v T) error) (bool, Stream[T], error)
 	head := s.elems[s.next]
 	s.next++
 
-	err := h(head)
+	err := handled(h(head))
 	if err != nil {
 		return true, s, err
 	}
@@ -451,35 +1072,59 @@ func (s *StreamFromSlice[T]) Resolve(h func(v T) error) (bool, Stream[T], error)
 	return false, s, nil
 }
 
+func (s *StreamFromSlice[T]) Position() Position {
+	return Position{Index: int64(s.next), Offset: int64(s.next)}
+}
+
 func Collect[T any](s Stream[T]) ([]T, error) {
-	var collection []T
+	return CollectInto(s, nil)
+}
+
+func Accumulate[T any](s Stream[T], r T, f func(a, b T) T) (T, error) {
 	for {
 		eos, nxs, err := s.Resolve(func(v T) error {
-			collection = append(collection, v)
-
+			r = f(r, v)
 			return nil
 		})
 		s = nxs
 		if eos || err != nil {
-			return collection, err
+			return r, driverError(err)
 		}
 	}
 }
 
-func Accumulate[T any](s Stream[T], r T, f func(a, b T) T) (T, error) {
+// Fold accumulates the elements of `s` into a result of any type, from the
+// initial result `init`. On error, it returns the result accumulated before.
+// An error of `f` is a `PositionError`, with the position of the element.
+func Fold[T, R any](s Stream[T], init R, f func(R, T) (R, error)) (R, error) {
+	r := init
+	i := 0
 	for {
 		eos, nxs, err := s.Resolve(func(v T) error {
-			r = f(r, v)
+			u, e := f(r, v)
+			i++
+			if e != nil {
+				return handled(positioned("Fold", i-1, e))
+			}
+
+			r = u
+
 			return nil
 		})
 		s = nxs
 		if eos || err != nil {
-			return r, err
+			return r, driverError(err)
 		}
 	}
 }
 
 func Count[T any](s Stream[T]) (int, error) {
+	if elems, b, ok := backingOf(s); ok {
+		b.advance(len(elems))
+
+		return len(elems), nil
+	}
+
 	r := 0
 	for {
 		eos, nxs, err := s.Resolve(func(v T) error {
@@ -488,7 +1133,7 @@ func Count[T any](s Stream[T]) (int, error) {
 		})
 		s = nxs
 		if eos || err != nil {
-			return r, err
+			return r, driverError(err)
 		}
 	}
 }
@@ -1,8 +1,13 @@
 package streams
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -102,6 +107,29 @@ func TestMapShouldErrorOnError(t *testing.T) {
 	}
 }
 
+func TestShouldMapIndexed(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4})
+	s = MapIndexed(s, func(i int, v int) (int, error) {
+		return i * v, nil
+	})
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{0, 1, 8}) {
+		t.Error(`Didn't MapIndexed`)
+	}
+}
+
+func TestShouldMapIndexedOnZeroValue(t *testing.T) {
+	s := &IndexedMapper[int, int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't MapIndexed on zero value`)
+	}
+}
+
 func TestShouldFlatMap(t *testing.T) {
 	s := NewFromSlice([]int{3, 1, 4, 1})
 	ss := Windowed(s, 2, 2)
@@ -178,6 +206,298 @@ func TestShouldDropOnZeroValueAsEmptyStream(t *testing.T) {
 	}
 }
 
+func TestShouldTake(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4})
+	s = Take(s, 2)
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't Take`)
+	}
+}
+
+func TestShouldTakeWithoutResolvingFurther(t *testing.T) {
+	resolved := 0
+	s := Map(NewFromSlice([]int{3, 1, 4, 1, 5}), func(v int) (int, error) {
+		resolved++
+		return v, nil
+	})
+
+	c, _ := Collect(Take(s, 2))
+
+	if !reflect.DeepEqual(c, []int{3, 1}) || resolved != 2 {
+		t.Error(`Didn't Take without resolving further`)
+	}
+}
+
+func TestShouldTakeEndAfterLastElement(t *testing.T) {
+	s := Take(NewFromSlice([]int{3, 1, 4}), 1)
+
+	eos, s, _ := s.Resolve(func(v int) error { return nil })
+	last, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if eos || !last {
+		t.Error(`Didn't Take end after last element`)
+	}
+}
+
+func TestShouldTakeAll(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4})
+	s = Take(s, 5)
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't Take all`)
+	}
+}
+
+func TestShouldTakeOnZeroValueAsEmptyStream(t *testing.T) {
+	s := &Taker[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't Take on zero value`)
+	}
+}
+
+func TestShouldStepBy(t *testing.T) {
+	s := Map(NewFromSlice([]int{0, 1, 2, 3, 4, 5, 6, 7}), func(v int) (int, error) { return v, nil })
+
+	c, err := Collect(StepBy(s, 3))
+
+	if err != nil || !reflect.DeepEqual(c, []int{0, 3, 6}) {
+		t.Error(`Didn't StepBy`)
+	}
+}
+
+func TestShouldStepByOne(t *testing.T) {
+	c, _ := Collect(StepBy(NewFromSlice([]int{3, 1, 4}), 0))
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't StepBy one`)
+	}
+}
+
+func TestShouldStepByWithoutAllocating(t *testing.T) {
+	r := &Ranger[int]{}
+	s := StepBy[int](r, 10).(*Stepper[int])
+	h := func(v int) error { return nil }
+
+	allocs := testing.AllocsPerRun(10, func() {
+		*r = Ranger[int]{v: 0, to: 1000, step: 1}
+		s.base, s.skip = r, 0
+		for eos := false; !eos; {
+			eos, _, _ = s.Resolve(h)
+		}
+	})
+
+	if allocs != 0 {
+		t.Error(`Didn't StepBy without allocating`)
+	}
+}
+
+func TestShouldStepByOnZeroValueAsEmptyStream(t *testing.T) {
+	s := &Stepper[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't StepBy on zero value`)
+	}
+}
+
+func TestShouldTakeWhile(t *testing.T) {
+	resolved := 0
+	s := Map(NewFromSlice([]int{1, 2, 5, 3, 7}), func(v int) (int, error) {
+		resolved++
+		return v, nil
+	})
+
+	c, _ := Collect(TakeWhile(s, func(v int) bool { return v < 4 }))
+
+	if !reflect.DeepEqual(c, []int{1, 2}) || resolved != 3 {
+		t.Error(`Didn't TakeWhile`)
+	}
+}
+
+func TestShouldTakeWhileOnZeroValueAsEmptyStream(t *testing.T) {
+	s := &WhileTaker[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't TakeWhile on zero value`)
+	}
+}
+
+func TestShouldDropWhile(t *testing.T) {
+	s := NewFromSlice([]int{1, 2, 5, 3, 7})
+
+	c, _ := Collect(DropWhile(s, func(v int) bool { return v < 4 }))
+
+	if !reflect.DeepEqual(c, []int{5, 3, 7}) {
+		t.Error(`Didn't DropWhile`)
+	}
+}
+
+func TestShouldDropWhileAll(t *testing.T) {
+	s := NewFromSlice([]int{1, 2, 3})
+
+	c, _ := Collect(DropWhile(s, func(v int) bool { return true }))
+
+	if len(c) != 0 {
+		t.Error(`Didn't DropWhile all`)
+	}
+}
+
+func TestShouldConcat(t *testing.T) {
+	s := Concat(NewFromSlice([]int{3, 1}), nil, NewFromSlice([]int{}), NewFromSlice([]int{4}))
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't Concat`)
+	}
+}
+
+func TestShouldConcatNone(t *testing.T) {
+	c, err := Collect(Concat[int]())
+
+	if err != nil || len(c) != 0 {
+		t.Error(`Didn't Concat none`)
+	}
+}
+
+func TestShouldScan(t *testing.T) {
+	s := Scan(NewFromSlice([]int{3, 1, 4}), 0, func(r, v int) (int, error) { return r + v, nil })
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 4, 8}) {
+		t.Error(`Didn't Scan`)
+	}
+}
+
+func TestShouldScanIntoOtherType(t *testing.T) {
+	s := Scan(NewFromSlice([]int{3, 1, 4}), "", func(r string, v int) (string, error) { return r + strconv.Itoa(v), nil })
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []string{"3", "31", "314"}) {
+		t.Error(`Didn't Scan into other type`)
+	}
+}
+
+func TestShouldScanErrorOnError(t *testing.T) {
+	s := Scan(NewFromSlice([]int{3, 1, 4}), 0, func(r, v int) (int, error) {
+		if v == 4 {
+			return 0, errors.New("failed")
+		}
+		return r + v, nil
+	})
+
+	c, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 4}) {
+		t.Error(`Didn't Scan error on error`)
+	}
+}
+
+func TestShouldFlatten(t *testing.T) {
+	s := Flatten(Windowed(NewFromSlice([]int{3, 1, 4}), 2, 2))
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 1, 1, 4}) {
+		t.Error(`Didn't Flatten`)
+	}
+}
+
+func TestShouldFlattenErrorOnInnerError(t *testing.T) {
+	inner := Map(NewFromSlice([]int{4, 1}), func(v int) (int, error) {
+		if v == 1 {
+			return 0, errors.New("failed")
+		}
+		return v, nil
+	})
+	s := Flatten(NewFromSlice([]Stream[int]{NewFromSlice([]int{3}), nil, inner, NewFromSlice([]int{5})}))
+
+	c, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 4}) {
+		t.Error(`Didn't Flatten error on inner error`)
+	}
+}
+
+func TestShouldBind(t *testing.T) {
+	s := Bind(NewFromSlice([]int{3, 0, 2}), func(v int) (Stream[string], error) {
+		return NewFromSlice(strings.Split(strings.Repeat("x", v), "")), nil
+	})
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []string{"x", "x", "x", "x", "x"}) {
+		t.Error(`Didn't Bind`)
+	}
+}
+
+func TestShouldBindErrorOnError(t *testing.T) {
+	s := Bind(NewFromSlice([]int{3, 1, 4}), func(v int) (Stream[int], error) {
+		if v == 1 {
+			return nil, errors.New("failed")
+		}
+		return NewFromSlice([]int{v, v}), nil
+	})
+
+	c, err := Collect(s)
+
+	if err == nil || !reflect.DeepEqual(c, []int{3, 3}) {
+		t.Error(`Didn't Bind error on error`)
+	}
+}
+
+func TestShouldTap(t *testing.T) {
+	var tapped []int
+	s := Tap(NewFromSlice([]int{3, 1, 4}), func(v int) { tapped = append(tapped, v) })
+
+	c, _ := Collect(Take(s, 2))
+
+	if !reflect.DeepEqual(c, []int{3, 1}) || !reflect.DeepEqual(tapped, []int{3, 1}) {
+		t.Error(`Didn't Tap`)
+	}
+}
+
+func TestShouldEnumerate(t *testing.T) {
+	s := Enumerate(Drop(NewFromSlice([]string{"a", "b", "c"}), 1))
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []Indexed[string]{{0, "b"}, {1, "c"}}) {
+		t.Error(`Didn't Enumerate`)
+	}
+}
+
+func TestShouldPairwise(t *testing.T) {
+	s := Pairwise(NewFromSlice([]float64{3, 1.5, 4}))
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []Pair[float64, float64]{{3, 1.5}, {1.5, 4}}) {
+		t.Error(`Didn't Pairwise`)
+	}
+}
+
+func TestShouldPairwiseOnSingleton(t *testing.T) {
+	c, _ := Collect(Pairwise(NewFromSlice([]int{3})))
+
+	if len(c) != 0 {
+		t.Error(`Didn't Pairwise on singleton`)
+	}
+}
+
 func TestShouldTruncate(t *testing.T) {
 	s := NewFromSlice([]int{3, 1, 4})
 	s = Truncate(s, 2)
@@ -242,6 +562,34 @@ func TestShouldAccumulateOnEmpty(t *testing.T) {
 	}
 }
 
+func TestShouldFold(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1})
+
+	m, _ := Fold(s, map[int]int{}, func(m map[int]int, v int) (map[int]int, error) {
+		m[v]++
+		return m, nil
+	})
+
+	if !reflect.DeepEqual(m, map[int]int{3: 1, 1: 2, 4: 1}) {
+		t.Error(`Didn't Fold`)
+	}
+}
+
+func TestShouldFoldUntilError(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1})
+
+	r, err := Fold(s, 0, func(r, v int) (int, error) {
+		if v == 4 {
+			return 0, errors.New("failed")
+		}
+		return r + v, nil
+	})
+
+	if err == nil || r != 4 {
+		t.Error(`Didn't Fold until error`)
+	}
+}
+
 func TestShouldCount(t *testing.T) {
 	s := NewFromSlice([]int{3, 1, 4, 1})
 
@@ -304,6 +652,27 @@ func TestShouldFilterOnZeroValue(t *testing.T) {
 	}
 }
 
+func TestShouldFilterIndexed(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1, 5})
+	s = FilterIndexed(s, func(i int, v int) bool { return i%2 == 0 })
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 4, 5}) {
+		t.Error(`Didn't FilterIndexed`)
+	}
+}
+
+func TestShouldFilterIndexedOnNil(t *testing.T) {
+	s := (*IndexedFilterer[int])(nil)
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't FilterIndexed on nil`)
+	}
+}
+
 func TestShouldWindow(t *testing.T) {
 	s := NewFromSlice([]int{3, 1, 4, 1})
 	ss := Windowed(s, 2, 2)
@@ -351,3 +720,103 @@ func TestShouldWindowOnZeroValue(t *testing.T) {
 		t.Error(`Didn't Window on zero value`)
 	}
 }
+
+func TestShouldCollectUntilStop(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1})
+	s = Map(s, func(v int) (int, error) {
+		if v == 4 {
+			return 0, ErrStop
+		}
+		return v, nil
+	})
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't Collect until stop`)
+	}
+}
+
+func TestShouldAccumulateUntilStop(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1})
+	s = Map(s, func(v int) (int, error) {
+		if v == 4 {
+			return 0, ErrStop
+		}
+		return v, nil
+	})
+
+	a, err := Accumulate(s, 0, func(a, b int) int { return a + b })
+
+	if err != nil || a != 4 {
+		t.Error(`Didn't Accumulate until stop`)
+	}
+}
+
+func TestShouldStopFileLines(t *testing.T) {
+	filename := t.TempDir() + "/input"
+	os.WriteFile(filename, []byte("3\n1\n4\n"), 0o644)
+
+	var s Stream[string] = NewStreamOfFileLines(filename)
+	var c []string
+	for {
+		eos, nxs, err := s.Resolve(func(v string) error {
+			c = append(c, v)
+			return ErrStop
+		})
+		s = nxs
+		if eos {
+			if err != ErrStop {
+				t.Error(`Didn't propagate stop`)
+			}
+			break
+		}
+	}
+
+	if !reflect.DeepEqual(c, []string{"3"}) {
+		t.Error(`Didn't stop file lines`)
+	}
+}
+
+func TestShouldStreamLinesOpen(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		w.Write([]byte("3\n1\n4\n"))
+		w.Close()
+	}()
+
+	c, err := Collect(NewStreamOfLinesOpen(r))
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "1", "4"}) || r.Close() == nil {
+		t.Error(`Didn't stream lines open`)
+	}
+}
+
+func TestShouldStreamIntsOpen(t *testing.T) {
+	c, err := Collect(NewStreamOfIntsOpen(io.NopCloser(strings.NewReader("3\n1\n4\n"))))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't stream ints open`)
+	}
+}
+
+func TestShouldStreamStdinLines(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+	w.Write([]byte("3\n1\n"))
+	w.Close()
+
+	c, err := Collect(NewStdinLines())
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "1"}) || r.Close() != nil {
+		t.Error(`Didn't stream stdin lines`)
+	}
+}
@@ -0,0 +1,93 @@
+package streams
+
+import "time"
+
+// A Throttler represents the stream of the elements of a given base stream,
+// paced by a token bucket, so that elements come at most at a given rate on
+// average, and in bursts of at most a given number of elements. An element
+// waits for a token once resolved from the base stream, before it is
+// resolved downstream.
+type Throttler[T any] struct {
+	base     Stream[T]
+	interval time.Duration
+	burst    int
+	clock    Clock
+	// The theoretical arrival time of the next element, were the bucket
+	// of a single token
+	tat time.Time
+}
+
+// Throttle is the stream `s`, at most at `rate` elements per second, in
+// bursts of at most `burst` elements. A non positive rate is no limit.
+func Throttle[T any](s Stream[T], rate float64, burst int) Stream[T] {
+	return ThrottleClock(s, rate, burst, SystemClock)
+}
+
+// ThrottleClock is as `Throttle`, in the time of `clock`.
+func ThrottleClock[T any](s Stream[T], rate float64, burst int, clock Clock) Stream[T] {
+	var interval time.Duration
+	if 0 < rate {
+		interval = time.Duration(float64(time.Second) / rate)
+	}
+
+	return throttled(s, interval, burst, clock)
+}
+
+// MinInterval is the stream `s`, with consecutive elements at least `d`
+// apart.
+func MinInterval[T any](s Stream[T], d time.Duration) Stream[T] {
+	return MinIntervalClock(s, d, SystemClock)
+}
+
+// MinIntervalClock is as `MinInterval`, in the time of `clock`.
+func MinIntervalClock[T any](s Stream[T], d time.Duration, clock Clock) Stream[T] {
+	return throttled(s, d, 1, clock)
+}
+
+func throttled[T any](s Stream[T], interval time.Duration, burst int, clock Clock) Stream[T] {
+	if burst < 1 {
+		burst = 1
+	}
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	return &Throttler[T]{base: s, interval: interval, burst: burst, clock: clock}
+}
+
+// wait waits for a token.
+func (s *Throttler[T]) wait() {
+	now := s.clock.Now()
+	if s.tat.Before(now) {
+		s.tat = now
+	}
+
+	at := s.tat.Add(-time.Duration(s.burst-1) * s.interval)
+	if now.Before(at) {
+		<-s.clock.After(at.Sub(now))
+	}
+
+	s.tat = s.tat.Add(s.interval)
+}
+
+func (s *Throttler[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		if 0 < s.interval {
+			s.wait()
+		}
+
+		return handled(h(v))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
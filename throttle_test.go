@@ -0,0 +1,88 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShouldThrottle(t *testing.T) {
+	clock := newManualClock(time.Unix(0, 0))
+	s := ThrottleClock(NewFromSlice([]int{3, 1, 4, 1, 5}), 1, 2, clock)
+
+	c, s, _ := CollectN(s, 2)
+
+	done := make(chan []int)
+	go func() {
+		c, _, _ := CollectN(s, 1)
+		done <- c
+	}()
+
+	select {
+	case <-done:
+		t.Error(`Didn't Throttle`)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+
+	if d := <-done; !reflect.DeepEqual(c, []int{3, 1}) || !reflect.DeepEqual(d, []int{4}) {
+		t.Error(`Didn't Throttle`, c, d)
+	}
+}
+
+func TestShouldThrottleRefill(t *testing.T) {
+	clock := newManualClock(time.Unix(0, 0))
+	s := ThrottleClock(NewFromSlice([]int{3, 1, 4, 1, 5}), 10, 2, clock)
+
+	CollectN(s, 2)
+	clock.Advance(time.Second)
+	c, _, _ := CollectN(s, 2)
+
+	if !reflect.DeepEqual(c, []int{4, 1}) {
+		t.Error(`Didn't Throttle refill`)
+	}
+}
+
+func TestShouldThrottleInWallClockTime(t *testing.T) {
+	start := time.Now()
+
+	c, err := Collect(Throttle(NewFromSlice([]int{3, 1, 4}), 100, 1))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) || time.Since(start) < 20*time.Millisecond {
+		t.Error(`Didn't Throttle in wall clock time`)
+	}
+}
+
+func TestShouldThrottleNoLimit(t *testing.T) {
+	c, err := Collect(Throttle(NewFromSlice([]int{3, 1, 4}), 0, 0))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't Throttle no limit`)
+	}
+}
+
+func TestShouldMinInterval(t *testing.T) {
+	clock := newManualClock(time.Unix(0, 0))
+	s := MinIntervalClock(NewFromSlice([]int{3, 1}), time.Minute, clock)
+
+	c, s, _ := CollectN(s, 1)
+
+	done := make(chan []int)
+	go func() {
+		c, _ := Collect(s)
+		done <- c
+	}()
+
+	select {
+	case <-done:
+		t.Error(`Didn't MinInterval`)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Minute)
+
+	if d := <-done; !reflect.DeepEqual(c, []int{3}) || !reflect.DeepEqual(d, []int{1}) {
+		t.Error(`Didn't MinInterval`, c, d)
+	}
+}
@@ -0,0 +1,93 @@
+package streams
+
+import (
+	"sync"
+	"time"
+)
+
+// A TickerStream represents the stream of the ticks of a clock at a given
+// interval, as for driving periodic sampling. Resolving the stream waits for
+// the next tick. Ticks missed by a slow consumer are dropped, as for a
+// `time.Ticker`. The stream ends once closed, which also ends a resolution
+// waiting for a tick.
+type TickerStream struct {
+	clock Clock
+	d     time.Duration
+	next  time.Time
+	// Whether the stream ends after its first tick
+	once      bool
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewTickerStream is the stream of the ticks every `d`, from now.
+func NewTickerStream(d time.Duration) *TickerStream {
+	return NewTickerStreamClock(d, SystemClock)
+}
+
+// NewTickerStreamClock is as `NewTickerStream`, with the ticks of `clock`.
+// It panics if `d` is not positive, as `time.NewTicker`.
+func NewTickerStreamClock(d time.Duration, clock Clock) *TickerStream {
+	if d <= 0 {
+		panic("streams: non-positive interval for NewTickerStream")
+	}
+
+	return newTickerStream(d, clock)
+}
+
+func newTickerStream(d time.Duration, clock Clock) *TickerStream {
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	return &TickerStream{clock: clock, d: d, next: clock.Now().Add(d), done: make(chan struct{})}
+}
+
+// NewTimerStream is the stream of a single tick, after `d`.
+func NewTimerStream(d time.Duration) *TickerStream {
+	return NewTimerStreamClock(d, SystemClock)
+}
+
+// NewTimerStreamClock is as `NewTimerStream`, with the tick of `clock`.
+func NewTimerStreamClock(d time.Duration, clock Clock) *TickerStream {
+	s := newTickerStream(d, clock)
+	s.once = true
+
+	return s
+}
+
+// Close ends the stream.
+func (s *TickerStream) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+
+	return nil
+}
+
+func (s *TickerStream) Resolve(h func(v time.Time) error) (bool, Stream[time.Time], error) {
+	if s == nil || s.done == nil {
+		return true, s, nil
+	}
+
+	select {
+	case <-s.done:
+		return true, s, nil
+	case now := <-s.clock.After(s.next.Sub(s.clock.Now())):
+		tick := s.next
+		if s.once {
+			s.Close()
+		} else {
+			for !s.next.After(now) {
+				s.next = s.next.Add(s.d)
+			}
+		}
+
+		err := handled(h(tick))
+		if err != nil {
+			s.Close()
+
+			return true, s, err
+		}
+
+		return s.once, s, nil
+	}
+}
@@ -0,0 +1,84 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShouldTick(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := newManualClock(start)
+	s := NewTickerStreamClock(time.Second, clock)
+
+	done := make(chan []time.Time)
+	go func() {
+		c, _, _ := CollectN[time.Time](s, 2)
+		done <- c
+	}()
+
+	for i := 0; i < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+		clock.Advance(time.Second)
+	}
+
+	if c := <-done; !reflect.DeepEqual(c, []time.Time{start.Add(time.Second), start.Add(2 * time.Second)}) {
+		t.Error(`Didn't tick`, c)
+	}
+}
+
+func TestShouldTickDroppingMissedTicks(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := newManualClock(start)
+	s := NewTickerStreamClock(time.Second, clock)
+
+	clock.Advance(3500 * time.Millisecond)
+	c, _, _ := CollectN[time.Time](s, 1)
+	clock.Advance(500 * time.Millisecond)
+	d, _, _ := CollectN[time.Time](s, 1)
+
+	if !c[0].Equal(start.Add(time.Second)) || !d[0].Equal(start.Add(4*time.Second)) {
+		t.Error(`Didn't tick dropping missed ticks`, c, d)
+	}
+}
+
+func TestShouldTickUntilClosed(t *testing.T) {
+	s := NewTickerStream(time.Hour)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		s.Close()
+	}()
+
+	c, err := Collect[time.Time](s)
+
+	if err != nil || len(c) != 0 {
+		t.Error(`Didn't tick until closed`)
+	}
+}
+
+func TestShouldTickOnceForTimer(t *testing.T) {
+	c, err := Collect[time.Time](NewTimerStream(time.Millisecond))
+
+	if err != nil || len(c) != 1 {
+		t.Error(`Didn't tick once for timer`)
+	}
+}
+
+func TestShouldTickPanicOnNonPositiveInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error(`Didn't tick panic on non positive interval`)
+		}
+	}()
+
+	NewTickerStream(0)
+}
+
+func TestShouldTickOnceForTimerOnNonPositiveDuration(t *testing.T) {
+	c, err := Collect[time.Time](NewTimerStream(0))
+
+	if err != nil || len(c) != 1 {
+		t.Error(`Didn't tick once for timer on non positive duration`)
+	}
+}
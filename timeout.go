@@ -0,0 +1,161 @@
+package streams
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimeout is the error wrapped by a `TimeoutError`.
+var ErrTimeout = errors.New("streams: timeout")
+
+// A TimeoutError is the error of a stream that did not resolve the element
+// at Index, numbered from 0, by Deadline.
+type TimeoutError struct {
+	Index    int
+	Deadline time.Time
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%v: element %d not resolved by %v", ErrTimeout, e.Index, e.Deadline)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return ErrTimeout
+}
+
+// Timeout reports that the error is a timeout, as for the errors of the net
+// package.
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// A TimeLimiter represents the stream of the elements of a given base
+// stream, which fails with a `TimeoutError` if the base stream takes longer
+// than a given duration to resolve an element, or does not resolve it by a
+// given deadline.
+//
+// The base stream is resolved concurrently, in its own goroutine, so that a
+// resolution blocked on the base stream, such as on a stalled socket, is
+// abandoned on timeout.
+type TimeLimiter[T any] struct {
+	base     Stream[T]
+	d        time.Duration
+	deadline time.Time
+	clock    Clock
+	i        int
+	in       <-chan resolution[T]
+	done     chan struct{}
+	// The base stream as left by its resolution, once stopped
+	rest    Stream[T]
+	stopped bool
+}
+
+// TimeoutPerElement is the stream `s`, failing if it takes longer than `d`
+// to resolve any element, or the end of stream.
+func TimeoutPerElement[T any](s Stream[T], d time.Duration) Stream[T] {
+	return TimeoutPerElementClock(s, d, SystemClock)
+}
+
+// TimeoutPerElementClock is as `TimeoutPerElement`, in the time of `clock`.
+func TimeoutPerElementClock[T any](s Stream[T], d time.Duration, clock Clock) Stream[T] {
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	return &TimeLimiter[T]{base: s, d: d, clock: clock}
+}
+
+// Deadline is the stream `s`, failing if it has not ended by `t`.
+func Deadline[T any](s Stream[T], t time.Time) Stream[T] {
+	return DeadlineClock(s, t, SystemClock)
+}
+
+// DeadlineClock is as `Deadline`, in the time of `clock`.
+func DeadlineClock[T any](s Stream[T], t time.Time, clock Clock) Stream[T] {
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	return &TimeLimiter[T]{base: s, deadline: t, clock: clock}
+}
+
+func (s *TimeLimiter[T]) stop() {
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
+	}
+	s.stopped = true
+}
+
+// Close stops the resolution of the base stream, once the resolution in
+// progress, if any, is done, ending the stream.
+func (s *TimeLimiter[T]) Close() error {
+	s.stop()
+	if s.in != nil {
+		for range s.in {
+		}
+		s.base, s.in = s.rest, nil
+	}
+
+	return nil
+}
+
+// limit is the time by which the next element is due, from `now`, or the
+// zero time if none.
+func (s *TimeLimiter[T]) limit(now time.Time) time.Time {
+	limit := s.deadline
+	if 0 < s.d && (limit.IsZero() || now.Add(s.d).Before(limit)) {
+		limit = now.Add(s.d)
+	}
+
+	return limit
+}
+
+func (s *TimeLimiter[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil || s.stopped {
+		return true, s, nil
+	}
+
+	now := s.clock.Now()
+	limit := s.limit(now)
+	if !limit.IsZero() && !now.Before(limit) {
+		s.stop()
+
+		return true, s, internal(&TimeoutError{Index: s.i, Deadline: limit})
+	}
+
+	if s.in == nil {
+		s.done = make(chan struct{})
+		s.in = pump(s.base, 0, s.done, &s.rest)
+	}
+
+	var timer <-chan time.Time
+	if !limit.IsZero() {
+		timer = s.clock.After(limit.Sub(now))
+	}
+
+	select {
+	case r := <-s.in:
+		if r.eos {
+			s.stop()
+
+			return true, s, r.err
+		}
+
+		s.i++
+
+		err := handled(h(r.v))
+		if err != nil {
+			s.stop()
+
+			return true, s, err
+		}
+
+		return false, s, nil
+	case <-timer:
+		s.stop()
+
+		return true, s, internal(&TimeoutError{Index: s.i, Deadline: limit})
+	}
+}
@@ -0,0 +1,97 @@
+package streams
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShouldTimeoutPerElement(t *testing.T) {
+	c, err := Collect(TimeoutPerElement(NewFromSlice([]int{3, 1, 4}), time.Second))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't TimeoutPerElement`)
+	}
+}
+
+func TestShouldTimeoutPerElementStalled(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 3
+	s := TimeoutPerElement(FromChannel(ch), 10*time.Millisecond)
+
+	c, err := Collect(s)
+	var timeout *TimeoutError
+
+	if !errors.As(err, &timeout) || timeout.Index != 1 || !errors.Is(err, ErrTimeout) || !reflect.DeepEqual(c, []int{3}) {
+		t.Error(`Didn't TimeoutPerElement stalled`)
+	}
+}
+
+func TestShouldTimeoutPerElementClock(t *testing.T) {
+	clock := newManualClock(time.Unix(0, 0))
+	ch := make(chan int)
+	s := TimeoutPerElementClock(FromChannel(ch), time.Minute, clock)
+
+	done := make(chan error)
+	go func() {
+		_, err := Collect(s)
+		done <- err
+	}()
+
+	ch <- 3
+	select {
+	case <-done:
+		t.Error(`Didn't TimeoutPerElement clock`)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Minute)
+
+	if err := <-done; !errors.Is(err, ErrTimeout) {
+		t.Error(`Didn't TimeoutPerElement clock`, err)
+	}
+}
+
+func TestShouldDeadline(t *testing.T) {
+	c, err := Collect(Deadline(NewFromSlice([]int{3, 1, 4}), time.Now().Add(time.Second)))
+
+	if err != nil || !reflect.DeepEqual(c, []int{3, 1, 4}) {
+		t.Error(`Didn't Deadline`)
+	}
+}
+
+func TestShouldDeadlinePassed(t *testing.T) {
+	deadline := time.Now().Add(-time.Second)
+
+	c, err := Collect(Deadline(NewFromSlice([]int{3, 1, 4}), deadline))
+	var timeout *TimeoutError
+
+	if !errors.As(err, &timeout) || !timeout.Deadline.Equal(deadline) || !timeout.Timeout() || len(c) != 0 {
+		t.Error(`Didn't Deadline passed`)
+	}
+}
+
+func TestShouldDeadlineStalled(t *testing.T) {
+	ch := make(chan int, 2)
+	ch <- 3
+	ch <- 1
+	start := time.Now()
+
+	c, err := Collect(Deadline(FromChannel(ch), start.Add(20*time.Millisecond)))
+
+	if !errors.Is(err, ErrTimeout) || !reflect.DeepEqual(c, []int{3, 1}) || time.Since(start) < 20*time.Millisecond {
+		t.Error(`Didn't Deadline stalled`)
+	}
+}
+
+func TestShouldTimeoutEndAfterTimeout(t *testing.T) {
+	s := TimeoutPerElement(FromChannel(make(chan int)), time.Millisecond)
+
+	_, s, err := CollectN(s, 1)
+	eos, _, rerr := s.Resolve(func(v int) error { return nil })
+
+	if !errors.Is(err, ErrTimeout) || !eos || rerr != nil {
+		t.Error(`Didn't end after timeout`)
+	}
+}
@@ -0,0 +1,159 @@
+package streams
+
+import "time"
+
+// A WindowResult holds the elements that arrived in the time window from
+// Start, inclusive, to End, exclusive.
+type WindowResult[T any] struct {
+	Start, End time.Time
+	Elems      []T
+}
+
+// An AlignedWindower represents the stream of the consecutive time windows
+// of a given duration, aligned on multiples of the duration since the zero
+// time, holding the elements of a given base stream by their arrival time.
+// Windows are closed by the clock, so that windows are produced even when
+// no elements arrive, in which case they are empty.
+//
+// The base stream is resolved concurrently, in its own goroutine.
+type AlignedWindower[T any] struct {
+	base  Stream[T]
+	d     time.Duration
+	clock Clock
+	in    <-chan resolution[T]
+	done  chan struct{}
+	// The base stream as left by its resolution, once stopped
+	rest    Stream[T]
+	timer   <-chan time.Time
+	current WindowResult[T]
+	ready   []WindowResult[T]
+	eos     bool
+	acct    budgetShare
+}
+
+// AlignedWindows is the stream of the windows of duration `d` of the stream
+// `s`, by the time of `clock`. It panics if `d` is not positive.
+func AlignedWindows[T any](s Stream[T], d time.Duration, clock Clock) Stream[WindowResult[T]] {
+	if d <= 0 {
+		panic("streams: non-positive duration for AlignedWindows")
+	}
+
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	start := clock.Now().Truncate(d)
+
+	return &AlignedWindower[T]{
+		base:    s,
+		d:       d,
+		clock:   clock,
+		current: WindowResult[T]{Start: start, End: start.Add(d)},
+	}
+}
+
+// advance closes the windows that end by `now`.
+func (s *AlignedWindower[T]) advance(now time.Time) {
+	for !now.Before(s.current.End) {
+		s.ready = append(s.ready, s.current)
+		s.current = WindowResult[T]{Start: s.current.End, End: s.current.End.Add(s.d)}
+		s.timer = nil
+	}
+}
+
+func (s *AlignedWindower[T]) stop() {
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
+	}
+}
+
+// release stops the resolution of the base stream, and releases the
+// buffered windows.
+func (s *AlignedWindower[T]) release() {
+	s.stop()
+	s.ready = nil
+	s.current.Elems = nil
+	s.acct.done()
+}
+
+// Close stops the resolution of the base stream, once the resolution in
+// progress, if any, is done, and releases the buffered windows, ending the
+// stream.
+func (s *AlignedWindower[T]) Close() error {
+	s.release()
+	if s.in != nil {
+		for range s.in {
+		}
+		s.base, s.in = s.rest, nil
+	}
+	s.eos = true
+
+	return nil
+}
+
+func (s *AlignedWindower[T]) Resolve(h func(v WindowResult[T]) error) (bool, Stream[WindowResult[T]], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if len(s.ready) == 0 && !s.eos {
+		if s.in == nil {
+			s.done = make(chan struct{})
+			s.in = pump(s.base, 0, s.done, &s.rest)
+		}
+
+		if s.timer == nil {
+			s.timer = s.clock.After(s.current.End.Sub(s.clock.Now()))
+		}
+
+		select {
+		case r := <-s.in:
+			if r.err != nil {
+				s.release()
+
+				return true, s, r.err
+			}
+
+			s.advance(s.clock.Now())
+
+			if r.eos {
+				s.eos = true
+				s.stop()
+
+				if len(s.current.Elems) != 0 {
+					s.ready = append(s.ready, s.current)
+				}
+			} else {
+				err := s.acct.reserve("AlignedWindows", sizeOf[T](1))
+				if err != nil {
+					s.release()
+
+					return true, s, err
+				}
+
+				s.current.Elems = append(s.current.Elems, r.v)
+			}
+		case now := <-s.timer:
+			s.timer = nil
+			s.advance(now)
+		}
+	}
+
+	if len(s.ready) == 0 {
+		return s.eos, s, nil
+	}
+
+	w := s.ready[0]
+	s.ready = s.ready[1:]
+	s.acct.release(sizeOf[T](len(w.Elems)))
+
+	err := handled(h(w))
+	if err != nil {
+		s.release()
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
@@ -0,0 +1,89 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// A blockingStream has no elements, but only ends once released.
+type blockingStream[T any] struct {
+	release chan struct{}
+}
+
+func (s *blockingStream[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	<-s.release
+
+	return true, s, nil
+}
+
+func TestShouldAlignedWindows(t *testing.T) {
+	clock := newManualClock(time.Date(2022, 9, 1, 12, 0, 30, 0, time.UTC))
+	s := AlignedWindows(NewFromSlice([]int{3, 1, 4}), time.Minute, clock)
+
+	c, _ := Collect(s)
+
+	start := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+	if !reflect.DeepEqual(c, []WindowResult[int]{{Start: start, End: start.Add(time.Minute), Elems: []int{3, 1, 4}}}) {
+		t.Error(`Didn't AlignedWindows`)
+	}
+}
+
+func TestShouldAlignedWindowsOnNilClock(t *testing.T) {
+	c, err := Collect(AlignedWindows(NewFromSlice([]int{3, 1, 4}), time.Hour, nil))
+
+	n := 0
+	for _, w := range c {
+		n += len(w.Elems)
+	}
+	if err != nil || n != 3 {
+		t.Error(`Didn't AlignedWindows on nil clock`)
+	}
+}
+
+func TestShouldAlignedWindowsOnEmpty(t *testing.T) {
+	clock := newManualClock(time.Date(2022, 9, 1, 12, 0, 30, 0, time.UTC))
+	s := AlignedWindows(NewFromSlice([]int{}), time.Minute, clock)
+
+	c, _ := Collect(s)
+
+	if len(c) != 0 {
+		t.Error(`Didn't AlignedWindows on empty`)
+	}
+}
+
+func TestShouldAlignedWindowsEmitEmptyWindows(t *testing.T) {
+	clock := newManualClock(time.Date(2022, 9, 1, 12, 0, 30, 0, time.UTC))
+	base := &blockingStream[int]{release: make(chan struct{})}
+	defer close(base.release)
+	s := AlignedWindows[int](base, time.Minute, clock)
+
+	go clock.Advance(90 * time.Second)
+	c, _, _ := CollectN(s, 2)
+
+	start := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+	if len(c) != 2 || !c[0].Start.Equal(start) || !c[1].Start.Equal(start.Add(time.Minute)) ||
+		len(c[0].Elems) != 0 || len(c[1].Elems) != 0 {
+		t.Error(`Didn't AlignedWindows emit empty windows`)
+	}
+}
+
+func TestShouldAlignedWindowsPanicOnNonPositiveDuration(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error(`Didn't AlignedWindows panic on non positive duration`)
+		}
+	}()
+
+	AlignedWindows(NewFromSlice([]int{3, 1, 4}), 0, nil)
+}
+
+func TestShouldAlignedWindowsOnZeroValue(t *testing.T) {
+	s := &AlignedWindower[int]{}
+
+	eos, _, _ := s.Resolve(func(v WindowResult[int]) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't AlignedWindows on zero value`)
+	}
+}
@@ -0,0 +1,48 @@
+package streams
+
+import (
+	"bufio"
+	"io"
+)
+
+// An UnsafeLineReader represents the stream of the lines read from a reader,
+// without their line terminators, as byte slices referencing the buffer of
+// the reader, without copying them.
+//
+// The ownership of a line is only lent to the handler: the slice is valid
+// only for the duration of the handler call, and must neither be modified
+// nor kept once the handler returns, as it is overwritten by the lines read
+// next. A handler needing the line afterwards must copy it, as with
+// `string(line)` or `append([]byte(nil), line...)`. Hence, such a stream
+// must not be collected, nor passed to operators that hold elements, such
+// as `Windowed` or `Truncate`, without first mapping the lines to copies.
+type UnsafeLineReader struct {
+	in *bufio.Scanner
+}
+
+func UnsafeLines(r io.Reader) Stream[[]byte] {
+	return &UnsafeLineReader{in: bufio.NewScanner(r)}
+}
+
+// Buffer sets the initial buffer of the reader, and the maximum line length,
+// as `bufio.Scanner.Buffer`, before the first resolution.
+func (s *UnsafeLineReader) Buffer(buf []byte, max int) {
+	s.in.Buffer(buf, max)
+}
+
+func (s *UnsafeLineReader) Resolve(h func(v []byte) error) (bool, Stream[[]byte], error) {
+	if s == nil || s.in == nil {
+		return true, s, nil
+	}
+
+	if !s.in.Scan() {
+		return true, s, internal(s.in.Err())
+	}
+
+	err := handled(h(s.in.Bytes()))
+	if err != nil {
+		return true, s, err
+	}
+
+	return false, s, nil
+}
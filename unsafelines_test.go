@@ -0,0 +1,68 @@
+package streams
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShouldUnsafeLines(t *testing.T) {
+	s := Map(UnsafeLines(strings.NewReader("3\n14\n159\n")), func(line []byte) (string, error) {
+		return string(line), nil
+	})
+
+	c, err := Collect(s)
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "14", "159"}) {
+		t.Error(`Didn't UnsafeLines`)
+	}
+}
+
+func TestShouldUnsafeLinesLendBuffer(t *testing.T) {
+	r := UnsafeLines(strings.NewReader("aaaa\nbbbb\n"))
+	r.(*UnsafeLineReader).Buffer(make([]byte, 8), 8)
+
+	var kept []byte
+	var s Stream[[]byte] = r
+	_, s, _ = s.Resolve(func(line []byte) error {
+		kept = line
+		return nil
+	})
+	copied := append([]byte(nil), kept...)
+	s.Resolve(func(line []byte) error { return nil })
+
+	// The kept slice now references the overwritten buffer, the copy doesn't
+	if !bytes.Equal(copied, []byte("aaaa")) || bytes.Equal(kept, []byte("aaaa")) {
+		t.Error(`Didn't UnsafeLines lend buffer`)
+	}
+}
+
+func TestShouldUnsafeLinesErrorOnLongLine(t *testing.T) {
+	r := UnsafeLines(strings.NewReader("aaaaaaaaaaaa\n"))
+	r.(*UnsafeLineReader).Buffer(make([]byte, 4), 4)
+
+	_, err := Count(r)
+
+	if err == nil {
+		t.Error(`Didn't UnsafeLines error on long line`)
+	}
+}
+
+func BenchmarkUnsafeLines(b *testing.B) {
+	input := strings.Repeat("some log line of moderate length\n", 1000)
+	for i := 0; i < b.N; i++ {
+		Count(UnsafeLines(strings.NewReader(input)))
+	}
+}
+
+func TestShouldUnsafeLinesOnZeroValue(t *testing.T) {
+	var s *UnsafeLineReader
+
+	eos, _, err := s.Resolve(func(v []byte) error { return nil })
+	zeos, _, zerr := (&UnsafeLineReader{}).Resolve(func(v []byte) error { return nil })
+
+	if !eos || err != nil || !zeos || zerr != nil {
+		t.Error(`Didn't UnsafeLines on zero value`)
+	}
+}
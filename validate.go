@@ -0,0 +1,104 @@
+package streams
+
+import "fmt"
+
+// ValidationSampleSize is the maximum number of failures sampled in a
+// ValidationReport.
+const ValidationSampleSize = 10
+
+// A Rule is a named check on elements of type T. The check returns a non
+// nil error, describing the violation, for invalid elements.
+type Rule[T any] struct {
+	Name  string
+	Check func(v T) error
+}
+
+// A ValidationFailure describes an element that failed a validation rule.
+type ValidationFailure struct {
+	Index int
+	Rule  string
+	Value any
+	Err   error
+}
+
+// A ValidationReport accumulates the outcome of validating the elements of
+// a stream. It is updated as the validated stream is resolved.
+type ValidationReport struct {
+	Checked, Valid, Invalid int
+	// Failures counts the violations of each rule, by name
+	Failures map[string]int
+	// Samples holds the first few violations
+	Samples []ValidationFailure
+}
+
+// A Validator represents the stream of the elements of a given base stream
+// that pass all of the given rules. The elements failing some rule are set
+// aside, passed to a reject function, if any, and recorded in the report.
+type Validator[T any] struct {
+	base   Stream[T]
+	rules  []Rule[T]
+	reject func(v T, err error)
+	report *ValidationReport
+}
+
+func Validate[T any](s Stream[T], rules ...Rule[T]) (Stream[T], *ValidationReport) {
+	return ValidateRejecting(s, nil, rules...)
+}
+
+// ValidateRejecting is as `Validate`, passing each invalid element to
+// `reject`, along with the error of the first rule it fails, prefixed with
+// the name of the rule, as for routing invalid elements to a quarantine.
+func ValidateRejecting[T any](s Stream[T], reject func(v T, err error), rules ...Rule[T]) (Stream[T], *ValidationReport) {
+	report := &ValidationReport{Failures: make(map[string]int)}
+
+	return &Validator[T]{base: s, rules: rules, reject: reject, report: report}, report
+}
+
+func (s *Validator[T]) Resolve(h func(v T) error) (bool, Stream[T], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		r := s.report
+		i := r.Checked
+		r.Checked++
+
+		var failure error
+		for _, rule := range s.rules {
+			e := rule.Check(v)
+			if e == nil {
+				continue
+			}
+
+			if failure == nil {
+				failure = fmt.Errorf("%s: %w", rule.Name, e)
+			}
+			r.Failures[rule.Name]++
+			if len(r.Samples) < ValidationSampleSize {
+				r.Samples = append(r.Samples, ValidationFailure{Index: i, Rule: rule.Name, Value: v, Err: e})
+			}
+		}
+
+		if failure != nil {
+			r.Invalid++
+			if s.reject != nil {
+				s.reject(v, failure)
+			}
+
+			return nil
+		}
+
+		r.Valid++
+
+		return handled(h(v))
+	})
+
+	s.base = nxs
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
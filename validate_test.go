@@ -0,0 +1,77 @@
+package streams
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+var positive = Rule[int]{Name: "positive", Check: func(v int) error {
+	if v <= 0 {
+		return fmt.Errorf("not positive: %d", v)
+	}
+	return nil
+}}
+
+var odd = Rule[int]{Name: "odd", Check: func(v int) error {
+	if v%2 == 0 {
+		return fmt.Errorf("not odd: %d", v)
+	}
+	return nil
+}}
+
+func TestShouldValidate(t *testing.T) {
+	s := NewFromSlice([]int{3, -1, 4, 1, -2})
+	s, r := Validate(s, positive, odd)
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 1}) {
+		t.Error(`Didn't Validate`)
+	}
+	if r.Checked != 5 || r.Valid != 2 || r.Invalid != 3 {
+		t.Error(`Didn't count validations`)
+	}
+	if !reflect.DeepEqual(r.Failures, map[string]int{"positive": 2, "odd": 2}) {
+		t.Error(`Didn't count failures per rule`)
+	}
+}
+
+func TestShouldSampleValidationFailures(t *testing.T) {
+	s := NewFromSlice([]int{3, -1, 4})
+	s, r := Validate(s, positive)
+
+	Collect(s)
+
+	if len(r.Samples) != 1 || r.Samples[0].Index != 1 || r.Samples[0].Value != -1 {
+		t.Error(`Didn't sample validation failures`)
+	}
+}
+
+func TestShouldValidateRejecting(t *testing.T) {
+	var rejected []int
+	var errs []string
+	s, r := ValidateRejecting(NewFromSlice([]int{3, -1, 4, 1, -2}), func(v int, err error) {
+		rejected = append(rejected, v)
+		errs = append(errs, err.Error())
+	}, positive, odd)
+
+	c, _ := Collect(s)
+
+	if !reflect.DeepEqual(c, []int{3, 1}) || !reflect.DeepEqual(rejected, []int{-1, 4, -2}) || r.Invalid != 3 {
+		t.Error(`Didn't Validate rejecting`)
+	}
+	if !reflect.DeepEqual(errs, []string{"positive: not positive: -1", "odd: not odd: 4", "positive: not positive: -2"}) {
+		t.Error(`Didn't Validate rejecting with the first failure`)
+	}
+}
+
+func TestShouldValidateOnZeroValue(t *testing.T) {
+	s := &Validator[int]{}
+
+	eos, _, _ := s.Resolve(func(v int) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't Validate on zero value`)
+	}
+}
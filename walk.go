@@ -0,0 +1,180 @@
+package streams
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// A FileInfoEntry is an entry of a directory tree, along with its path,
+// joined to the root of the tree, and its depth below the root, 1 for the
+// entries of the root.
+type FileInfoEntry struct {
+	fs.DirEntry
+	Path  string
+	Depth int
+}
+
+// A walkConfig is the configuration of a directory walk.
+type walkConfig struct {
+	fsys     fs.FS
+	patterns []string
+	maxDepth int
+	dirs     bool
+}
+
+// A WalkOption configures a directory walk.
+type WalkOption func(c *walkConfig)
+
+// WalkGlob only yields the files whose name matches any of the given
+// patterns, as for `filepath.Match`. Directories are walked regardless.
+func WalkGlob(patterns ...string) WalkOption {
+	return func(c *walkConfig) { c.patterns = append(c.patterns, patterns...) }
+}
+
+// WalkMaxDepth only walks the entries at most `n` levels below the root, or
+// at any depth if 0.
+func WalkMaxDepth(n int) WalkOption {
+	return func(c *walkConfig) { c.maxDepth = n }
+}
+
+// WalkDirs also yields the directories, before their entries.
+func WalkDirs() WalkOption {
+	return func(c *walkConfig) { c.dirs = true }
+}
+
+// WalkFS walks the file system `fsys` rather than that of the operating
+// system, the root being a path of `fsys`.
+func WalkFS(fsys fs.FS) WalkOption {
+	return func(c *walkConfig) { c.fsys = fsys }
+}
+
+// A walkFrame is a directory being walked, with its entries still to walk.
+type walkFrame struct {
+	dir     string
+	depth   int
+	entries []fs.DirEntry
+}
+
+// A DirWalker represents the stream of the entries of a directory tree, in
+// lexical order, each directory before its entries, as for `fs.WalkDir`.
+// Each directory is read once reached, so that the tree is walked lazily.
+type DirWalker struct {
+	root   string
+	cfg    walkConfig
+	stack  []walkFrame
+	opened bool
+}
+
+func NewDirWalkStream(root string, opts ...WalkOption) Stream[FileInfoEntry] {
+	s := &DirWalker{root: root}
+	for _, opt := range opts {
+		opt(&s.cfg)
+	}
+
+	return s
+}
+
+func (s *DirWalker) push(dir string, depth int) error {
+	var entries []fs.DirEntry
+	var err error
+	if s.cfg.fsys != nil {
+		entries, err = fs.ReadDir(s.cfg.fsys, dir)
+	} else {
+		entries, err = os.ReadDir(dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.stack = append(s.stack, walkFrame{dir: dir, depth: depth, entries: entries})
+
+	return nil
+}
+
+func (s *DirWalker) join(dir, name string) string {
+	if s.cfg.fsys != nil {
+		return path.Join(dir, name)
+	}
+
+	return filepath.Join(dir, name)
+}
+
+func (s *DirWalker) match(e fs.DirEntry) bool {
+	if e.IsDir() {
+		return s.cfg.dirs
+	}
+
+	if len(s.cfg.patterns) == 0 {
+		return true
+	}
+
+	for _, p := range s.cfg.patterns {
+		if ok, _ := filepath.Match(p, e.Name()); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// next is the next entry to yield, if any.
+func (s *DirWalker) next() (FileInfoEntry, bool, error) {
+	for len(s.stack) != 0 {
+		top := &s.stack[len(s.stack)-1]
+		if len(top.entries) == 0 {
+			s.stack = s.stack[:len(s.stack)-1]
+
+			continue
+		}
+
+		e := top.entries[0]
+		top.entries = top.entries[1:]
+
+		entry := FileInfoEntry{DirEntry: e, Path: s.join(top.dir, e.Name()), Depth: top.depth + 1}
+		if e.IsDir() && (s.cfg.maxDepth == 0 || entry.Depth < s.cfg.maxDepth) {
+			err := s.push(entry.Path, entry.Depth)
+			if err != nil {
+				return entry, false, err
+			}
+		}
+
+		if s.match(e) {
+			return entry, true, nil
+		}
+	}
+
+	return FileInfoEntry{}, false, nil
+}
+
+func (s *DirWalker) Resolve(h func(v FileInfoEntry) error) (bool, Stream[FileInfoEntry], error) {
+	if s == nil {
+		return true, s, nil
+	}
+
+	if !s.opened {
+		s.opened = true
+
+		err := s.push(s.root, 0)
+		if err != nil {
+			return true, s, internal(err)
+		}
+	}
+
+	entry, ok, err := s.next()
+	if err != nil || !ok {
+		s.stack = nil
+
+		return true, s, internal(err)
+	}
+
+	err = handled(h(entry))
+	if err != nil {
+		s.stack = nil
+
+		return true, s, err
+	}
+
+	return false, s, nil
+}
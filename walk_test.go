@@ -0,0 +1,67 @@
+package streams
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func walkedPaths(c []FileInfoEntry) []string {
+	var ps []string
+	for _, e := range c {
+		ps = append(ps, e.Path)
+	}
+	return ps
+}
+
+var walkTree = fstest.MapFS{
+	"b.log":         {},
+	"a/x.log":       {},
+	"a/y.txt":       {},
+	"a/deep/z.log":  {},
+	"c/nested/w.md": {},
+}
+
+func TestShouldWalkDir(t *testing.T) {
+	c, err := Collect(NewDirWalkStream(".", WalkFS(walkTree)))
+
+	want := []string{"a/deep/z.log", "a/x.log", "a/y.txt", "b.log", "c/nested/w.md"}
+	if err != nil || !reflect.DeepEqual(walkedPaths(c), want) {
+		t.Error(`Didn't walk dir`, walkedPaths(c))
+	}
+}
+
+func TestShouldWalkDirWithOptions(t *testing.T) {
+	c, err := Collect(NewDirWalkStream(".", WalkFS(walkTree), WalkGlob("*.log"), WalkMaxDepth(2), WalkDirs()))
+
+	want := []string{"a", "a/deep", "a/x.log", "b.log", "c", "c/nested"}
+	if err != nil || !reflect.DeepEqual(walkedPaths(c), want) || c[1].Depth != 2 {
+		t.Error(`Didn't walk dir with options`, walkedPaths(c))
+	}
+}
+
+func TestShouldWalkOSDir(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "a"), 0o755)
+	os.WriteFile(filepath.Join(root, "a", "x.log"), []byte("3\n1\n"), 0o644)
+	os.WriteFile(filepath.Join(root, "y.log"), []byte("4\n"), 0o644)
+
+	lines := Bind(NewDirWalkStream(root), func(e FileInfoEntry) (Stream[string], error) {
+		return NewStreamOfFileLines(e.Path), nil
+	})
+	c, err := Collect(lines)
+
+	if err != nil || !reflect.DeepEqual(c, []string{"3", "1", "4"}) {
+		t.Error(`Didn't walk OS dir`, c, err)
+	}
+}
+
+func TestShouldWalkDirErrorOnMissingRoot(t *testing.T) {
+	_, err := Collect(NewDirWalkStream(filepath.Join(t.TempDir(), "missing")))
+
+	if err == nil {
+		t.Error(`Didn't walk dir error on missing root`)
+	}
+}
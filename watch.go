@@ -0,0 +1,195 @@
+package streams
+
+import (
+	"context"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// An FSOp is a set of file system operations.
+type FSOp uint32
+
+const (
+	FSCreate FSOp = 1 << iota
+	FSWrite
+	FSRemove
+	FSRename
+	FSChmod
+)
+
+// An FSEvent reports the operations on the file at Path.
+type FSEvent struct {
+	Path string
+	Op   FSOp
+}
+
+// A Watcher watches files and directories for file system events.
+type Watcher interface {
+	Add(path string) error
+	Events() <-chan FSEvent
+	Errors() <-chan error
+	Close() error
+}
+
+// WatchOptions are the options of `WatchDir`.
+type WatchOptions struct {
+	// Watcher is the watcher to use, or nil for a new fsnotify watcher
+	Watcher Watcher
+	// Ops are the operations of interest, or 0 for all of them
+	Ops FSOp
+}
+
+// A DirWatcher represents the stream of the file system events in a given
+// directory. The stream ends when the context is done.
+type DirWatcher struct {
+	ctx     context.Context
+	dir     string
+	opts    WatchOptions
+	watcher Watcher
+}
+
+func WatchDir(ctx context.Context, dir string, opts WatchOptions) Stream[FSEvent] {
+	return &DirWatcher{ctx: ctx, dir: dir, opts: opts}
+}
+
+func (s *DirWatcher) close() error {
+	var err error
+	if s.watcher != nil {
+		err = s.watcher.Close()
+		s.watcher = nil
+	}
+	s.ctx = nil
+
+	return err
+}
+
+// Close closes the watcher, ending the stream.
+func (s *DirWatcher) Close() error {
+	return s.close()
+}
+
+func (s *DirWatcher) Resolve(h func(v FSEvent) error) (bool, Stream[FSEvent], error) {
+	if s == nil || s.ctx == nil {
+		return true, s, nil
+	}
+
+	if s.watcher == nil {
+		w := s.opts.Watcher
+		if w == nil {
+			var err error
+			w, err = newFSNotifyWatcher()
+			if err != nil {
+				s.ctx = nil
+
+				return true, s, internal(err)
+			}
+		}
+		s.watcher = w
+
+		err := w.Add(s.dir)
+		if err != nil {
+			s.close()
+
+			return true, s, internal(err)
+		}
+	}
+
+	select {
+	case <-s.ctx.Done():
+		s.close()
+
+		return true, s, nil
+	case err := <-s.watcher.Errors():
+		s.close()
+
+		return true, s, internal(err)
+	case ev, ok := <-s.watcher.Events():
+		if !ok {
+			s.close()
+
+			return true, s, nil
+		}
+
+		if s.opts.Ops != 0 {
+			ev.Op &= s.opts.Ops
+			if ev.Op == 0 {
+				return false, s, nil
+			}
+		}
+
+		err := handled(h(ev))
+		if err != nil {
+			s.close()
+
+			return true, s, err
+		}
+
+		return false, s, nil
+	}
+}
+
+// An fsnotifyWatcher adapts a fsnotify watcher to the Watcher interface.
+type fsnotifyWatcher struct {
+	w      *fsnotify.Watcher
+	events chan FSEvent
+	done   chan struct{}
+}
+
+func newFSNotifyWatcher() (Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &fsnotifyWatcher{w: w, events: make(chan FSEvent), done: make(chan struct{})}
+	go fw.run()
+
+	return fw, nil
+}
+
+func (w *fsnotifyWatcher) run() {
+	defer close(w.events)
+
+	for ev := range w.w.Events {
+		var op FSOp
+		if ev.Has(fsnotify.Create) {
+			op |= FSCreate
+		}
+		if ev.Has(fsnotify.Write) {
+			op |= FSWrite
+		}
+		if ev.Has(fsnotify.Remove) {
+			op |= FSRemove
+		}
+		if ev.Has(fsnotify.Rename) {
+			op |= FSRename
+		}
+		if ev.Has(fsnotify.Chmod) {
+			op |= FSChmod
+		}
+
+		select {
+		case w.events <- FSEvent{Path: ev.Name, Op: op}:
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *fsnotifyWatcher) Add(path string) error {
+	return w.w.Add(path)
+}
+
+func (w *fsnotifyWatcher) Events() <-chan FSEvent {
+	return w.events
+}
+
+func (w *fsnotifyWatcher) Errors() <-chan error {
+	return w.w.Errors
+}
+
+func (w *fsnotifyWatcher) Close() error {
+	close(w.done)
+
+	return w.w.Close()
+}
@@ -0,0 +1,101 @@
+package streams
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// A fakeWatcher is a Watcher whose events are sent by the test.
+type fakeWatcher struct {
+	added  []string
+	events chan FSEvent
+	errors chan error
+	closed bool
+}
+
+func newFakeWatcher() *fakeWatcher {
+	return &fakeWatcher{events: make(chan FSEvent, 8), errors: make(chan error, 1)}
+}
+
+func (w *fakeWatcher) Add(path string) error {
+	w.added = append(w.added, path)
+	return nil
+}
+
+func (w *fakeWatcher) Events() <-chan FSEvent { return w.events }
+
+func (w *fakeWatcher) Errors() <-chan error { return w.errors }
+
+func (w *fakeWatcher) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestShouldWatchDir(t *testing.T) {
+	w := newFakeWatcher()
+	w.events <- FSEvent{Path: "a", Op: FSCreate}
+	w.events <- FSEvent{Path: "a", Op: FSWrite}
+	close(w.events)
+
+	c, _ := Collect(WatchDir(context.Background(), "dir", WatchOptions{Watcher: w}))
+
+	if !reflect.DeepEqual(c, []FSEvent{{"a", FSCreate}, {"a", FSWrite}}) {
+		t.Error(`Didn't WatchDir`)
+	}
+	if !reflect.DeepEqual(w.added, []string{"dir"}) || !w.closed {
+		t.Error(`Didn't WatchDir on the watcher`)
+	}
+}
+
+func TestShouldWatchDirFilteringOps(t *testing.T) {
+	w := newFakeWatcher()
+	w.events <- FSEvent{Path: "a", Op: FSCreate}
+	w.events <- FSEvent{Path: "a", Op: FSWrite | FSChmod}
+	w.events <- FSEvent{Path: "a", Op: FSRemove}
+	close(w.events)
+
+	c, _ := Collect(WatchDir(context.Background(), "dir", WatchOptions{Watcher: w, Ops: FSWrite | FSRemove}))
+
+	if !reflect.DeepEqual(c, []FSEvent{{"a", FSWrite}, {"a", FSRemove}}) {
+		t.Error(`Didn't WatchDir filtering ops`)
+	}
+}
+
+func TestShouldWatchDirUntilDone(t *testing.T) {
+	w := newFakeWatcher()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c, err := Collect(WatchDir(ctx, "dir", WatchOptions{Watcher: w}))
+
+	if len(c) != 0 || err != nil || !w.closed {
+		t.Error(`Didn't WatchDir until done`)
+	}
+}
+
+func TestShouldWatchDirWithFSNotify(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The watch is only set up on the first resolution, so keep creating
+	// files until one is seen
+	name := filepath.Join(dir, "a")
+	go func() {
+		for ctx.Err() == nil {
+			os.WriteFile(name, nil, 0o644)
+			os.Remove(name)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	c, _, _ := CollectN(WatchDir(ctx, dir, WatchOptions{Ops: FSCreate}), 1)
+
+	if len(c) != 1 || c[0].Path != name || c[0].Op != FSCreate {
+		t.Error(`Didn't WatchDir with fsnotify`)
+	}
+}
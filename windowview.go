@@ -0,0 +1,96 @@
+package streams
+
+// A WindowView is a read-only view of a window over the buffer of a
+// ViewWindower. It is only valid for the duration of the handler call it is
+// passed to, after which the buffer is reused for the next windows: a
+// window needed afterwards must be materialized.
+type WindowView[T any] struct {
+	elems []T
+}
+
+func (w WindowView[T]) Len() int {
+	return len(w.elems)
+}
+
+// At is the `i`th element of the window.
+func (w WindowView[T]) At(i int) T {
+	return w.elems[i]
+}
+
+// Each applies `f` to the elements of the window, in order.
+func (w WindowView[T]) Each(f func(v T)) {
+	for _, v := range w.elems {
+		f(v)
+	}
+}
+
+// Materialize is a copy of the elements of the window, which stays valid.
+func (w WindowView[T]) Materialize() []T {
+	return append([]T(nil), w.elems...)
+}
+
+// A ViewWindower represents the stream of the sliding windows of `n`
+// elements of a given base stream, as `Windowed` does, but as views over a
+// buffer of `f*n` elements, which is reused without allocating once full.
+type ViewWindower[T any] struct {
+	base    Stream[T]
+	hold    []T
+	i, n, f int
+	acct    budgetShare
+}
+
+// WindowedViews is the stream of the views of the sliding windows of `n`
+// elements of the stream `s`. A window size less than 1 is taken as 1, as
+// for `Chunk`.
+func WindowedViews[T any](s Stream[T], n int, f int) Stream[WindowView[T]] {
+	if n < 1 {
+		n = 1
+	}
+	if f < 1 {
+		f = 1
+	}
+
+	return &ViewWindower[T]{base: s, hold: make([]T, f*n), n: n, f: f}
+}
+
+func (s *ViewWindower[T]) Resolve(h func(v WindowView[T]) error) (bool, Stream[WindowView[T]], error) {
+	if s == nil || s.base == nil {
+		return true, s, nil
+	}
+
+	if s.acct.reserved == 0 {
+		err := s.acct.reserve("WindowedViews", sizeOf[T](s.f*s.n))
+		if err != nil {
+			return true, s, err
+		}
+	}
+
+	eos, nxs, err := s.base.Resolve(func(v T) error {
+		if s.i == len(s.hold) {
+			// Shift the last incomplete window to the start of the buffer
+			s.i = copy(s.hold, s.hold[s.i-s.n+1:s.i])
+		}
+
+		s.hold[s.i] = v
+		s.i++
+
+		if s.i < s.n {
+			// No complete window yet
+			return nil
+		}
+
+		return handled(h(WindowView[T]{elems: s.hold[s.i-s.n : s.i : s.i]}))
+	})
+
+	s.base = nxs
+
+	if eos || err != nil {
+		s.acct.done()
+	}
+
+	if err != nil {
+		return true, s, err
+	}
+
+	return eos, s, nil
+}
@@ -0,0 +1,90 @@
+package streams
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShouldWindowedViews(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1, 5})
+	ws := Map(WindowedViews(s, 2, 2), func(w WindowView[int]) ([]int, error) {
+		return w.Materialize(), nil
+	})
+
+	c, _ := Collect(ws)
+
+	if !reflect.DeepEqual(c, [][]int{{3, 1}, {1, 4}, {4, 1}, {1, 5}}) {
+		t.Error(`Didn't WindowedViews`)
+	}
+}
+
+func TestShouldWindowedViewsAggregate(t *testing.T) {
+	s := NewFromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6})
+	ws := Map(WindowedViews(s, 3, 1), func(w WindowView[int]) (int, error) {
+		sum := 0
+		for i := 0; i < w.Len(); i++ {
+			sum += w.At(i)
+		}
+		return sum, nil
+	})
+
+	c, _ := Collect(ws)
+
+	if !reflect.DeepEqual(c, []int{8, 6, 10, 15, 16, 17}) {
+		t.Error(`Didn't WindowedViews aggregate`)
+	}
+}
+
+func TestShouldWindowedViewsMatchWindowed(t *testing.T) {
+	elems := []int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5}
+	for _, f := range []int{1, 2, 3} {
+		want, _ := Collect(Map(Windowed(NewFromSlice(elems), 3, f), func(w Stream[int]) ([]int, error) { return Collect(w) }))
+		c, _ := Collect(Map(WindowedViews(NewFromSlice(elems), 3, f), func(w WindowView[int]) ([]int, error) { return w.Materialize(), nil }))
+
+		if !reflect.DeepEqual(c, want) {
+			t.Error(`Didn't WindowedViews match Windowed`, f)
+		}
+	}
+}
+
+func TestShouldWindowedViewsOnNonPositiveSize(t *testing.T) {
+	ws := Map(WindowedViews(NewFromSlice([]int{3, 1, 4}), 0, 2), func(w WindowView[int]) ([]int, error) {
+		return w.Materialize(), nil
+	})
+
+	c, _ := Collect(ws)
+
+	if !reflect.DeepEqual(c, [][]int{{3}, {1}, {4}}) {
+		t.Error(`Didn't WindowedViews on non positive size`, c)
+	}
+}
+
+func TestShouldWindowedViewsOnZeroValue(t *testing.T) {
+	s := &ViewWindower[int]{}
+
+	eos, _, _ := s.Resolve(func(v WindowView[int]) error { return nil })
+
+	if !eos {
+		t.Error(`Didn't WindowedViews on zero value`)
+	}
+}
+
+func BenchmarkWindowedViews(b *testing.B) {
+	elems := make([]int, 1000)
+	sum := func(s Stream[int]) (int, error) { return Accumulate(s, 0, func(a, b int) int { return a + b }) }
+
+	b.Run("streams", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Count(Map(Windowed(NewFromSlice(elems), 4, 4), sum))
+		}
+	})
+	b.Run("views", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Count(Map(WindowedViews(NewFromSlice(elems), 4, 4), func(w WindowView[int]) (int, error) {
+				s := 0
+				w.Each(func(v int) { s += v })
+				return s, nil
+			}))
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package streams
+
+import (
+	"bufio"
+	"io"
+)
+
+// WriteLines writes each element of the stream `s` to `w`, as a line ending
+// with a newline, returning how many lines were written. Writes are
+// buffered, and flushed before returning, even on error.
+func WriteLines(s Stream[string], w io.Writer) (int, error) {
+	bw := bufio.NewWriter(w)
+
+	n, err := SendAll[string](s, SinkFunc[string](func(v string) error {
+		_, e := bw.WriteString(v)
+		if e == nil {
+			e = bw.WriteByte('\n')
+		}
+
+		return e
+	}))
+
+	ferr := bw.Flush()
+	if err == nil {
+		err = ferr
+	}
+
+	return n, err
+}
+
+// WriteBytes writes each element of the stream `s` to `w`, returning how
+// many bytes were written. Writes are buffered, and flushed before returning,
+// even on error.
+func WriteBytes(s Stream[[]byte], w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+
+	_, err := SendAll[[]byte](s, SinkFunc[[]byte](func(v []byte) error {
+		m, e := bw.Write(v)
+		n += int64(m)
+
+		return e
+	}))
+
+	ferr := bw.Flush()
+	if err == nil {
+		err = ferr
+	}
+
+	return n, err
+}
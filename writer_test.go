@@ -0,0 +1,63 @@
+package streams
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// A failingWriter fails once more than its limit of bytes is written.
+type failingWriter struct {
+	limit int
+	buf   bytes.Buffer
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.limit < w.buf.Len()+len(p) {
+		return 0, errors.New("failed")
+	}
+
+	return w.buf.Write(p)
+}
+
+func TestShouldWriteLines(t *testing.T) {
+	var buf bytes.Buffer
+
+	n, err := WriteLines(NewFromSlice([]string{"3", "", "14"}), &buf)
+
+	if err != nil || n != 3 || buf.String() != "3\n\n14\n" {
+		t.Error(`Didn't WriteLines`)
+	}
+}
+
+func TestShouldWriteLinesFlushedOnError(t *testing.T) {
+	var buf bytes.Buffer
+	s := Map(Map(NewFromSlice([]int{3, 1, 4}), failingAt4), func(v int) (string, error) { return strconv.Itoa(v), nil })
+
+	n, err := WriteLines(s, &buf)
+
+	if err == nil || n != 2 || buf.String() != "3\n1\n" {
+		t.Error(`Didn't WriteLines flushed on error`)
+	}
+}
+
+func TestShouldWriteBytes(t *testing.T) {
+	var buf bytes.Buffer
+
+	n, err := WriteBytes(NewFromSlice([][]byte{[]byte("3.1"), []byte("415")}), &buf)
+
+	if err != nil || n != 6 || buf.String() != "3.1415" {
+		t.Error(`Didn't WriteBytes`)
+	}
+}
+
+func TestShouldWriteBytesErrorOnWriteError(t *testing.T) {
+	w := &failingWriter{limit: 4}
+
+	_, err := WriteBytes(NewFromSlice([][]byte{[]byte("3.1"), []byte("415")}), w)
+
+	if err == nil {
+		t.Error(`Didn't WriteBytes error on write error`)
+	}
+}